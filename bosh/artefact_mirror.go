@@ -0,0 +1,157 @@
+package bosh
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+	"gopkg.in/yaml.v2"
+)
+
+var releaseURLPath = regexp.MustCompile(`^/releases/name=([^/]+)/url\??$`)
+
+// artefactMirrorOpsFiles writes an ops file that moves the URLs of the releases in the Concourse manifest
+// to the artefact mirror, and returns the --ops-file flag for it
+func artefactMirrorOpsFiles(c config.ConfigView, workingdir workingdir.IClient, releaseVersions []byte) ([]string, error) {
+	if c.GetArtefactMirror() == "" {
+		return nil, nil
+	}
+
+	urls, err := releaseURLs(releaseVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []opsFileEntry
+	for _, name := range sortedKeys(urls) {
+		mirrored, err := mirror.URL(c.GetArtefactMirror(), urls[name])
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, opsFileEntry{Type: "replace", Path: fmt.Sprintf("/releases/name=%s/url?", name), Value: mirrored})
+	}
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(artefactMirrorFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
+
+// releaseURLs returns the URL of each release in the Concourse manifest, as pinned by the versions ops file
+func releaseURLs(releaseVersions []byte) (map[string]string, error) {
+	var manifest struct {
+		Releases []struct {
+			Name string `yaml:"name"`
+			URL  string `yaml:"url"`
+		} `yaml:"releases"`
+	}
+	if err := yaml.Unmarshal(concourseManifestContents, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse Concourse manifest: [%v]", err)
+	}
+	urls := map[string]string{}
+	for _, release := range manifest.Releases {
+		// URLs built from variables are only known once the manifest is interpolated, and must be pinned by an ops file
+		if release.URL != "" && !strings.Contains(release.URL, "((") {
+			urls[release.Name] = release.URL
+		}
+	}
+
+	var ops []struct {
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(releaseVersions, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse release versions: [%v]", err)
+	}
+	for _, op := range ops {
+		match := releaseURLPath.FindStringSubmatch(op.Path)
+		if match == nil {
+			continue
+		}
+		var url string
+		if err := json.Unmarshal(op.Value, &url); err != nil {
+			return nil, err
+		}
+		urls[match[1]] = url
+	}
+	return urls, nil
+}
+
+// ArtefactSHA1s returns the SHA1s pinned for releases that ArtefactURLs lists, by URL. Releases from the
+// versions files are verified by the director as it deploys them instead
+func ArtefactSHA1s() map[string]string {
+	return map[string]string{nodeExporterURL: nodeExporterSHA1}
+}
+
+// ArtefactURLs returns the URLs of the releases and stemcells that deploying Concourse on an IAAS can download,
+// for the stemcell OS lines that are pinned. Addon releases of a runtime config are not included
+func ArtefactURLs(name iaas.Name, stemcellOSes []string) ([]string, error) {
+	var (
+		releaseVersions []byte
+		environment     func(stemcellOS string) boshcli.IAASEnvironment
+	)
+	switch name {
+	case iaas.AWS:
+		releaseVersions = awsConcourseVersions
+		environment = func(stemcellOS string) boshcli.IAASEnvironment { return boshcli.AWSEnvironment{StemcellOS: stemcellOS} }
+	case iaas.GCP:
+		releaseVersions = gcpConcourseVersions
+		environment = func(stemcellOS string) boshcli.IAASEnvironment { return boshcli.GCPEnvironment{StemcellOS: stemcellOS} }
+	default:
+		return nil, fmt.Errorf("bosh: %s is not a valid iaas provider", name)
+	}
+
+	releases, err := releaseURLs(releaseVersions)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	for _, name := range sortedKeys(releases) {
+		urls = append(urls, releases[name])
+	}
+	// Releases that optional ops files add to the director and the Concourse deployment
+	urls = append(urls, nodeExporterURL, syslogReleaseURL)
+
+	for _, stemcellOS := range stemcellOSes {
+		// Stemcell lines that are not pinned cannot be deployed, so there is nothing to mirror
+		if _, err := boshcli.StemcellVersion(string(releaseVersions), stemcellOS); err != nil {
+			continue
+		}
+		url, err := environment(stemcellOS).ConcourseStemcellURL()
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	var versions []resource.ConcourseVersion
+	if err := yaml.Unmarshal(resource.ConcourseVersions, &versions); err != nil {
+		return nil, fmt.Errorf("failed to parse Concourse versions: [%v]", err)
+	}
+	for _, version := range versions {
+		urls = append(urls, version.URL)
+	}
+	return urls, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
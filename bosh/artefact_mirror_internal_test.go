@@ -0,0 +1,53 @@
+package bosh
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+func TestArtefactURLs_OptionalReleases(t *testing.T) {
+	urls, err := ArtefactURLs(iaas.AWS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{nodeExporterURL, syslogReleaseURL} {
+		found := false
+		for _, url := range urls {
+			found = found || url == want
+		}
+		if !found {
+			t.Errorf("ArtefactURLs() = %v, want it to include %s", urls, want)
+		}
+	}
+}
+
+func Test_syslogVars_ArtefactMirror(t *testing.T) {
+	tests := []struct {
+		name           string
+		artefactMirror string
+		want           string
+	}{
+		{
+			name: "bosh.io without a mirror",
+			want: "https://bosh.io/d/github.com/cloudfoundry/syslog-release?v=" + syslogReleaseVersion,
+		},
+		{
+			name:           "the mirror when there is one",
+			artefactMirror: "https://artefacts.example.com/control-tower",
+			want:           "https://artefacts.example.com/control-tower/bosh.io/d/github.com/cloudfoundry/syslog-release/v=" + syslogReleaseVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := syslogVars(config.Config{SyslogAddress: "logs.example.com:6514", ArtefactMirror: tt.artefactMirror})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := vars["syslog_release_url"]; got != tt.want {
+				t.Errorf("syslogVars()[syslog_release_url] = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package bosh
+
+import (
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+var defaultOIDCScopes = []string{"openid", "profile", "email", "groups"}
+
+const defaultOIDCGroupsClaim = "groups"
+const defaultLDAPUserSearchUsername = "uid"
+
+// authOpsFiles adds the vars for every auth connector enabled in config to vmap
+// and returns the --ops-file flags that configure them on the web job
+func authOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}) []string {
+	var flagFiles []string
+
+	if c.IsGithubAuthSet() {
+		vmap["github_client_id"] = c.GetGithubClientID()
+		vmap["github_client_secret"] = c.GetGithubClientSecret()
+		vmap["main_team_github_orgs"] = c.GetMainTeamGithubOrgs()
+		vmap["main_team_github_teams"] = c.GetMainTeamGithubTeams()
+		vmap["main_team_github_users"] = c.GetMainTeamGithubUsers()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseGitHubAuthFilename))
+	}
+
+	if c.IsOIDCAuthSet() {
+		vmap["oidc_issuer"] = c.GetOIDCIssuer()
+		vmap["oidc_client_id"] = c.GetOIDCClientID()
+		vmap["oidc_client_secret"] = c.GetOIDCClientSecret()
+		vmap["oidc_scopes"] = defaultOIDCScopes
+		if len(c.GetOIDCScopes()) > 0 {
+			vmap["oidc_scopes"] = c.GetOIDCScopes()
+		}
+		vmap["oidc_groups_claim"] = defaultOIDCGroupsClaim
+		if c.GetOIDCGroupsClaim() != "" {
+			vmap["oidc_groups_claim"] = c.GetOIDCGroupsClaim()
+		}
+		vmap["main_team_oidc_groups"] = c.GetMainTeamOIDCGroups()
+		vmap["main_team_oidc_users"] = c.GetMainTeamOIDCUsers()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseOIDCAuthFilename))
+	}
+
+	if c.IsGitLabAuthSet() {
+		vmap["gitlab_client_id"] = c.GetGitLabClientID()
+		vmap["gitlab_client_secret"] = c.GetGitLabClientSecret()
+		vmap["gitlab_host"] = c.GetGitLabHost()
+		vmap["main_team_gitlab_groups"] = c.GetMainTeamGitLabGroups()
+		vmap["main_team_gitlab_users"] = c.GetMainTeamGitLabUsers()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseGitLabAuthFilename))
+	}
+
+	if c.IsBitbucketCloudAuthSet() {
+		vmap["bitbucket_cloud_client_id"] = c.GetBitbucketCloudClientID()
+		vmap["bitbucket_cloud_client_secret"] = c.GetBitbucketCloudClientSecret()
+		vmap["main_team_bitbucket_cloud_teams"] = c.GetMainTeamBitbucketCloudTeams()
+		vmap["main_team_bitbucket_cloud_users"] = c.GetMainTeamBitbucketCloudUsers()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseBitbucketCloudAuthFilename))
+	}
+
+	if c.IsLDAPAuthSet() {
+		vmap["ldap_host"] = c.GetLDAPHost()
+		vmap["ldap_bind_dn"] = c.GetLDAPBindDN()
+		vmap["ldap_bind_password"] = c.GetLDAPBindPassword()
+		vmap["ldap_ca_cert"] = c.GetLDAPCACert()
+		vmap["ldap_user_search_base_dn"] = c.GetLDAPUserSearchBaseDN()
+		vmap["ldap_user_search_username"] = defaultLDAPUserSearchUsername
+		if c.GetLDAPUserSearchUsername() != "" {
+			vmap["ldap_user_search_username"] = c.GetLDAPUserSearchUsername()
+		}
+		vmap["ldap_group_search_base_dn"] = c.GetLDAPGroupSearchBaseDN()
+		vmap["main_team_ldap_groups"] = c.GetMainTeamLDAPGroups()
+		vmap["main_team_ldap_users"] = c.GetMainTeamLDAPUsers()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseLDAPAuthFilename))
+	}
+
+	return flagFiles
+}
@@ -0,0 +1,223 @@
+package bosh
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir/workingdirfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	utilyaml "github.com/EngineerBetter/control-tower/util/yaml"
+	"gopkg.in/yaml.v2"
+)
+
+const authTestManifest = `
+instance_groups:
+- name: web
+  jobs:
+  - name: web
+    properties:
+      main_team:
+        auth:
+          local:
+            users: [admin]
+`
+
+var authTestOpsFiles = map[string][]byte{
+	concourseGitHubAuthFilename:         concourseGitHubAuth,
+	concourseOIDCAuthFilename:           concourseOIDCAuth,
+	concourseGitLabAuthFilename:         concourseGitLabAuth,
+	concourseBitbucketCloudAuthFilename: concourseBitbucketCloudAuth,
+	concourseLDAPAuthFilename:           concourseLDAPAuth,
+}
+
+// renderAuthTestProperties applies the ops files authOpsFiles chose, in order, and returns the web job's properties
+func renderAuthTestProperties(t *testing.T, flags []string, vmap map[string]interface{}) interface{} {
+	manifest := authTestManifest
+	for i := 1; i < len(flags); i += 2 {
+		var err error
+		manifest, err = utilyaml.Interpolate(manifest, string(authTestOpsFiles[filepath.Base(flags[i])]), vmap)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var rendered struct {
+		InstanceGroups []struct {
+			Jobs []struct {
+				Properties interface{}
+			}
+		} `yaml:"instance_groups"`
+	}
+	if err := yaml.Unmarshal([]byte(manifest), &rendered); err != nil {
+		t.Fatal(err)
+	}
+	return rendered.InstanceGroups[0].Jobs[0].Properties
+}
+
+func TestAuthOpsFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    config.Config
+		wantFiles []string
+		want      string
+	}{
+		{
+			name: "no connectors",
+			want: `
+main_team:
+  auth:
+    local:
+      users: [admin]
+`,
+		},
+		{
+			name: "GitHub",
+			config: config.Config{
+				GithubClientID:      "github-id",
+				GithubClientSecret:  "github-secret",
+				MainTeamGithubOrgs:  []string{"EngineerBetter"},
+				MainTeamGithubTeams: []string{"EngineerBetter:ops"},
+				MainTeamGithubUsers: []string{"octocat"},
+			},
+			wantFiles: []string{concourseGitHubAuthFilename},
+			want: `
+github_auth:
+  client_id: github-id
+  client_secret: github-secret
+main_team:
+  auth:
+    local:
+      users: [admin]
+    github:
+      orgs: [EngineerBetter]
+      teams: ["EngineerBetter:ops"]
+      users: [octocat]
+`,
+		},
+		{
+			name: "OIDC with the default scopes and groups claim",
+			config: config.Config{
+				OIDCIssuer:         "https://example.okta.com",
+				OIDCClientID:       "oidc-id",
+				OIDCClientSecret:   "oidc-secret",
+				MainTeamOIDCGroups: []string{"admins"},
+				MainTeamOIDCUsers:  []string{"jane@example.com"},
+			},
+			wantFiles: []string{concourseOIDCAuthFilename},
+			want: `
+generic_oidc:
+  issuer: https://example.okta.com
+  client_id: oidc-id
+  client_secret: oidc-secret
+  scopes: [openid, profile, email, groups]
+  groups_key: groups
+main_team:
+  auth:
+    local:
+      users: [admin]
+    oidc:
+      groups: [admins]
+      users: [jane@example.com]
+`,
+		},
+		{
+			name: "GitLab and Bitbucket Cloud together",
+			config: config.Config{
+				GitLabClientID:              "gitlab-id",
+				GitLabClientSecret:          "gitlab-secret",
+				GitLabHost:                  "https://gitlab.example.com",
+				MainTeamGitLabGroups:        []string{"platform"},
+				MainTeamGitLabUsers:         []string{},
+				BitbucketCloudClientID:      "bitbucket-id",
+				BitbucketCloudClientSecret:  "bitbucket-secret",
+				MainTeamBitbucketCloudTeams: []string{},
+				MainTeamBitbucketCloudUsers: []string{"jane"},
+			},
+			wantFiles: []string{concourseGitLabAuthFilename, concourseBitbucketCloudAuthFilename},
+			want: `
+gitlab_auth:
+  client_id: gitlab-id
+  client_secret: gitlab-secret
+  host: https://gitlab.example.com
+bitbucket_cloud_auth:
+  client_id: bitbucket-id
+  client_secret: bitbucket-secret
+main_team:
+  auth:
+    local:
+      users: [admin]
+    gitlab:
+      groups: [platform]
+      users: []
+    bitbucket_cloud:
+      teams: []
+      users: [jane]
+`,
+		},
+		{
+			name: "LDAP with the default username attribute",
+			config: config.Config{
+				LDAPHost:              "ldap.example.com:636",
+				LDAPBindDN:            "cn=concourse,dc=example,dc=com",
+				LDAPBindPassword:      "ldap-password",
+				LDAPUserSearchBaseDN:  "ou=people,dc=example,dc=com",
+				LDAPGroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+				MainTeamLDAPGroups:    []string{"admins"},
+				MainTeamLDAPUsers:     []string{},
+			},
+			wantFiles: []string{concourseLDAPAuthFilename},
+			want: `
+ldap_auth:
+  host: ldap.example.com:636
+  bind_dn: cn=concourse,dc=example,dc=com
+  bind_pw: ldap-password
+  ca_cert: ""
+  user_search:
+    base_dn: ou=people,dc=example,dc=com
+    username: uid
+  group_search:
+    base_dn: ou=groups,dc=example,dc=com
+    user_attr: DN
+    group_attr: member
+    name_attr: cn
+main_team:
+  auth:
+    local:
+      users: [admin]
+    ldap:
+      groups: [admins]
+      users: []
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingdir := &workingdirfakes.FakeIClient{}
+			workingdir.PathInWorkingDirStub = func(filename string) string {
+				return "/working/" + filename
+			}
+			vmap := map[string]interface{}{}
+
+			flags := authOpsFiles(tt.config, workingdir, vmap)
+			var files []string
+			for i := 0; i < len(flags); i += 2 {
+				if flags[i] != "--ops-file" {
+					t.Fatalf("authOpsFiles() = %v, want only --ops-file flags", flags)
+				}
+				files = append(files, filepath.Base(flags[i+1]))
+			}
+			if !reflect.DeepEqual(files, tt.wantFiles) {
+				t.Errorf("authOpsFiles() chose %v, want %v", files, tt.wantFiles)
+			}
+
+			var want interface{}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if got := renderAuthTestProperties(t, flags, vmap); !reflect.DeepEqual(got, want) {
+				gotYAML, _ := yaml.Marshal(got)
+				t.Errorf("rendered web properties:\n%s\nwant:\n%s", gotYAML, tt.want)
+			}
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/db"
 	"github.com/apparentlymart/go-cidr/cidr"
 )
 
-func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, error) {
+func (client *AWSClient) deployConcourse(creds []byte, detach bool, deployFlags ...string) ([]byte, error) {
 
 	err := saveFilesToWorkingDir(client.workingdir, client.provider, creds)
 	if err != nil {
@@ -54,7 +56,7 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		"postgres_ca_cert":         db.RDSRootCert,
 		"web_vm_type":              "concourse-web-" + client.config.GetConcourseWebSize(),
 		"worker_vm_type":           "concourse-" + client.config.GetConcourseWorkerSize(),
-		"worker_count":             client.config.GetConcourseWorkerCount(),
+		"worker_count":             client.config.GetDeployedWorkerCount(),
 		"atc_eip":                  atcPublicIP,
 		"external_tls.certificate": client.config.GetConcourseCert(),
 		"external_tls.private_key": client.config.GetConcourseKey(),
@@ -81,10 +83,75 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		vmap["atc_password"] = client.config.GetConcoursePassword()
 	}
 
-	if client.config.IsGithubAuthSet() {
-		vmap["github_client_id"] = client.config.GetGithubClientID()
-		vmap["github_client_secret"] = client.config.GetGithubClientSecret()
-		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseGitHubAuthFilename))
+	mirrorFlagFiles, err := artefactMirrorOpsFiles(client.config, client.workingdir, awsConcourseVersions)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, mirrorFlagFiles...)
+
+	versionFlagFiles, err := concourseVersionOpsFiles(client.config, client.workingdir, awsConcourseVersions)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, versionFlagFiles...)
+
+	flagFiles = append(flagFiles, authOpsFiles(client.config, client.workingdir, vmap)...)
+	flagFiles = append(flagFiles, credentialManagerOpsFiles(client.config, client.workingdir, vmap)...)
+
+	metricsFlagFiles, err := metricsOpsFiles(client.config, client.workingdir, vmap)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, metricsFlagFiles...)
+
+	grafanaFlagFiles, err := grafanaOpsFiles(client.config, client.workingdir)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, grafanaFlagFiles...)
+
+	syslogFlagFiles, err := syslogOpsFiles(client.config, client.workingdir, vmap)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, syslogFlagFiles...)
+
+	externalWorkerFlagFiles, err := externalWorkersOpsFiles(client.config, client.workingdir)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, externalWorkerFlagFiles...)
+
+	propertiesFlagFiles, err := concoursePropertiesOpsFiles(client.config, client.workingdir)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, propertiesFlagFiles...)
+
+	proxyFlagFiles, err := proxyOpsFiles(client.config, client.workingdir)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, proxyFlagFiles...)
+
+	webPublicIPs, err := client.outputs.Get("WebPublicIPs")
+	if err != nil {
+		return creds, err
+	}
+	webFlagFiles, err := webOpsFiles(client.config, client.workingdir, vmap, 8, atcPublicIP, webPublicIPs)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, webFlagFiles...)
+
+	if client.config.GetStemcellOS() != config.DefaultStemcellOS {
+		stemcellVersion, err1 := boshcli.StemcellVersion(string(awsConcourseVersions), client.config.GetStemcellOS())
+		if err1 != nil {
+			return creds, err1
+		}
+		vmap["stemcell_os"] = client.config.GetStemcellOS()
+		vmap["stemcell_version"] = stemcellVersion
+		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseStemcellOSFilename))
 	}
 
 	t, err1 := client.buildTagsYaml(vmap["project"], "concourse")
@@ -94,6 +161,18 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 	vmap["tags"] = t
 	flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(extraTagsFilename))
 
+	workerPoolFlagFiles, err := workerPoolsOpsFiles(client.config, client.workingdir, flagFiles)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, workerPoolFlagFiles...)
+
+	customFlagFiles, err := customOpsFiles(client.config, client.workingdir)
+	if err != nil {
+		return creds, err
+	}
+	flagFiles = append(flagFiles, customFlagFiles...)
+
 	vs := vars(vmap)
 
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
@@ -108,7 +187,7 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		client.config.GetDirectorCACert(),
 		detach,
 		os.Stdout,
-		append(flagFiles, vs...)...)
+		append(append(flagFiles, vs...), deployFlags...)...)
 	if err != nil {
 		return creds, fmt.Errorf("failed to run bosh deploy with commands %+v: [%v]", flagFiles, err)
 	}
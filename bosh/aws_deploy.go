@@ -18,6 +18,9 @@ func (client *AWSClient) Deploy(state, creds []byte, detach bool) (newState, new
 	if err = client.updateCloudConfig(client.boshCLI); err != nil {
 		return state, creds, err
 	}
+	if err = client.updateRuntimeConfig(); err != nil {
+		return state, creds, err
+	}
 	if err = client.uploadConcourseStemcell(client.boshCLI); err != nil {
 		return state, creds, err
 	}
@@ -33,6 +36,22 @@ func (client *AWSClient) Deploy(state, creds []byte, detach bool) (newState, new
 	return state, creds, err
 }
 
+// DeployConcourse runs bosh deploy for the Concourse deployment alone, for changes such as the worker
+// count that need neither the director nor the cloud config to be updated
+func (client *AWSClient) DeployConcourse(creds []byte, detach bool) ([]byte, error) {
+	return client.deployConcourse(creds, detach)
+}
+
+// PlanConcourse runs bosh deploy --dry-run for the Concourse deployment, which shows the changes a deploy would
+// make without making them. The stemcell is uploaded first, as BOSH cannot render a manifest without it
+func (client *AWSClient) PlanConcourse(creds []byte) error {
+	if err := client.uploadConcourseStemcell(client.boshCLI); err != nil {
+		return err
+	}
+	_, err := client.deployConcourse(creds, false, "--dry-run")
+	return err
+}
+
 // Locks implements locks for AWS client
 func (client *AWSClient) Locks() ([]byte, error) {
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
@@ -54,6 +73,11 @@ func (client *AWSClient) CreateEnv(state, creds []byte, customOps string) (newSt
 	tags["control-tower-project"] = client.config.GetProject()
 	tags["control-tower-component"] = "concourse"
 
+	directorOps, err1 := directorOpsFiles(client.config, customOps)
+	if err1 != nil {
+		return state, creds, err1
+	}
+
 	boshUserAccessKeyID, err1 := client.outputs.Get("BoshUserAccessKeyID")
 	if err1 != nil {
 		return state, creds, err1
@@ -155,7 +179,7 @@ func (client *AWSClient) CreateEnv(state, creds []byte, customOps string) (newSt
 		S3AWSSecretAccessKey: blobstoreSecretAccessKey,
 		Spot:                 client.config.IsSpot(),
 		WorkerType:           client.config.GetWorkerType(),
-		CustomOperations:     customOps,
+		CustomOperations:     directorOps,
 		VersionFile:          client.versionFile,
 	}, client.config.GetDirectorPassword(), client.config.GetDirectorCert(), client.config.GetDirectorKey(), client.config.GetDirectorCACert(), tags)
 	if err1 != nil {
@@ -175,6 +199,24 @@ func (client *AWSClient) Recreate() error {
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
 
+// Stop stops the Concourse VMs and deletes them, keeping their persistent disks
+func (client *AWSClient) Stop() error {
+	return client.runConcourseCommand("stop", "--hard")
+}
+
+// Start recreates and starts the Concourse VMs after Stop
+func (client *AWSClient) Start() error {
+	return client.runConcourseCommand("start")
+}
+
+func (client *AWSClient) runConcourseCommand(action string, flags ...string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return err
+	}
+	return client.boshCLI.RunAuthenticatedCommand(action, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert(), false, client.stdout, flags...)
+}
+
 func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 	publicSubnetID, err := client.outputs.Get("PublicSubnetID")
 	if err != nil {
@@ -192,6 +234,14 @@ func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 	if err != nil {
 		return err
 	}
+	webInstanceProfile, err := client.outputs.Get("WebInstanceProfile")
+	if err != nil {
+		return err
+	}
+	webTargetGroups, err := client.outputs.Get("WebTargetGroups")
+	if err != nil {
+		return err
+	}
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
 	if err != nil {
 		return err
@@ -207,7 +257,7 @@ func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		return err
 	}
 	publicCIDRGateway := pubGateway.String()
-	publicCIDRStatic, err := formatIPRange(publicCIDR, ", ", []int{8})
+	publicCIDRStatic, err := formatIPRange(publicCIDR, ", ", webStaticIPHosts(8, client.config.GetConcourseWebCount()))
 	if err != nil {
 		return err
 	}
@@ -231,6 +281,11 @@ func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		return err
 	}
 
+	cloudConfigOps, err := joinCustomOps(client.config.GetCloudConfigOpsFiles())
+	if err != nil {
+		return err
+	}
+
 	return bosh.UpdateCloudConfig(boshcli.AWSEnvironment{
 		AZ:                  client.config.GetAvailabilityZone(),
 		PublicSubnetID:      publicSubnetID,
@@ -240,6 +295,10 @@ func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		Spot:                client.config.IsSpot(),
 		ExternalIP:          directorPublicIP,
 		WorkerType:          client.config.GetWorkerType(),
+		WebInstanceProfile:  webInstanceProfile,
+		WebTargetGroups:     webTargetGroups,
+		WorkerPools:         workerPoolVMTypes(client.config),
+		CloudConfigOps:      cloudConfigOps,
 		PublicCIDR:          publicCIDR,
 		PublicCIDRGateway:   publicCIDRGateway,
 		PublicCIDRStatic:    publicCIDRStatic,
@@ -249,12 +308,22 @@ func (client *AWSClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		PrivateCIDRReserved: privateCIDRReserved,
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
+func (client *AWSClient) updateRuntimeConfig() error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return err
+	}
+	return updateRuntimeConfig(client.config, client.boshCLI, client.workingdir, directorPublicIP, client.stdout)
+}
+
 func (client *AWSClient) uploadConcourseStemcell(bosh boshcli.ICLI) error {
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
 	if err != nil {
 		return err
 	}
 	return bosh.UploadConcourseStemcell(boshcli.AWSEnvironment{
-		ExternalIP: directorPublicIP,
+		ExternalIP:     directorPublicIP,
+		StemcellOS:     client.config.GetStemcellOS(),
+		ArtefactMirror: client.config.GetArtefactMirror(),
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
@@ -52,6 +52,20 @@ type FakeIClient struct {
 		result2 []byte
 		result3 error
 	}
+	DeployConcourseStub        func([]byte, bool) ([]byte, error)
+	deployConcourseMutex       sync.RWMutex
+	deployConcourseArgsForCall []struct {
+		arg1 []byte
+		arg2 bool
+	}
+	deployConcourseReturns struct {
+		result1 []byte
+		result2 error
+	}
+	deployConcourseReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	InstancesStub        func() ([]bosh.Instance, error)
 	instancesMutex       sync.RWMutex
 	instancesArgsForCall []struct {
@@ -76,6 +90,17 @@ type FakeIClient struct {
 		result1 []byte
 		result2 error
 	}
+	PlanConcourseStub        func([]byte) error
+	planConcourseMutex       sync.RWMutex
+	planConcourseArgsForCall []struct {
+		arg1 []byte
+	}
+	planConcourseReturns struct {
+		result1 error
+	}
+	planConcourseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RecreateStub        func() error
 	recreateMutex       sync.RWMutex
 	recreateArgsForCall []struct {
@@ -86,6 +111,26 @@ type FakeIClient struct {
 	recreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StartStub        func() error
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+	}
+	startReturns struct {
+		result1 error
+	}
+	startReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StopStub        func() error
+	stopMutex       sync.RWMutex
+	stopArgsForCall []struct {
+	}
+	stopReturns struct {
+		result1 error
+	}
+	stopReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -298,6 +343,75 @@ func (fake *FakeIClient) DeployReturnsOnCall(i int, result1 []byte, result2 []by
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIClient) DeployConcourse(arg1 []byte, arg2 bool) ([]byte, error) {
+	fake.deployConcourseMutex.Lock()
+	ret, specificReturn := fake.deployConcourseReturnsOnCall[len(fake.deployConcourseArgsForCall)]
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.deployConcourseArgsForCall = append(fake.deployConcourseArgsForCall, struct {
+		arg1 []byte
+		arg2 bool
+	}{arg1Copy, arg2})
+	fake.recordInvocation("DeployConcourse", []interface{}{arg1Copy, arg2})
+	fake.deployConcourseMutex.Unlock()
+	if fake.DeployConcourseStub != nil {
+		return fake.DeployConcourseStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deployConcourseReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) DeployConcourseCallCount() int {
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
+	return len(fake.deployConcourseArgsForCall)
+}
+
+func (fake *FakeIClient) DeployConcourseCalls(stub func([]byte, bool) ([]byte, error)) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = stub
+}
+
+func (fake *FakeIClient) DeployConcourseArgsForCall(i int) ([]byte, bool) {
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
+	argsForCall := fake.deployConcourseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIClient) DeployConcourseReturns(result1 []byte, result2 error) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = nil
+	fake.deployConcourseReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) DeployConcourseReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = nil
+	if fake.deployConcourseReturnsOnCall == nil {
+		fake.deployConcourseReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.deployConcourseReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIClient) Instances() ([]bosh.Instance, error) {
 	fake.instancesMutex.Lock()
 	ret, specificReturn := fake.instancesReturnsOnCall[len(fake.instancesArgsForCall)]
@@ -373,6 +487,8 @@ func (fake *FakeIClient) Locks() ([]byte, error) {
 func (fake *FakeIClient) LocksCallCount() int {
 	fake.locksMutex.RLock()
 	defer fake.locksMutex.RUnlock()
+	fake.planConcourseMutex.RLock()
+	defer fake.planConcourseMutex.RUnlock()
 	return len(fake.locksArgsForCall)
 }
 
@@ -408,6 +524,71 @@ func (fake *FakeIClient) LocksReturnsOnCall(i int, result1 []byte, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeIClient) PlanConcourse(arg1 []byte) error {
+	fake.planConcourseMutex.Lock()
+	ret, specificReturn := fake.planConcourseReturnsOnCall[len(fake.planConcourseArgsForCall)]
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.planConcourseArgsForCall = append(fake.planConcourseArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("PlanConcourse", []interface{}{arg1Copy})
+	fake.planConcourseMutex.Unlock()
+	if fake.PlanConcourseStub != nil {
+		return fake.PlanConcourseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.planConcourseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) PlanConcourseCallCount() int {
+	fake.planConcourseMutex.RLock()
+	defer fake.planConcourseMutex.RUnlock()
+	return len(fake.planConcourseArgsForCall)
+}
+
+func (fake *FakeIClient) PlanConcourseCalls(stub func([]byte) error) {
+	fake.planConcourseMutex.Lock()
+	defer fake.planConcourseMutex.Unlock()
+	fake.PlanConcourseStub = stub
+}
+
+func (fake *FakeIClient) PlanConcourseArgsForCall(i int) []byte {
+	fake.planConcourseMutex.RLock()
+	defer fake.planConcourseMutex.RUnlock()
+	argsForCall := fake.planConcourseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) PlanConcourseReturns(result1 error) {
+	fake.planConcourseMutex.Lock()
+	defer fake.planConcourseMutex.Unlock()
+	fake.PlanConcourseStub = nil
+	fake.planConcourseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) PlanConcourseReturnsOnCall(i int, result1 error) {
+	fake.planConcourseMutex.Lock()
+	defer fake.planConcourseMutex.Unlock()
+	fake.PlanConcourseStub = nil
+	if fake.planConcourseReturnsOnCall == nil {
+		fake.planConcourseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.planConcourseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Recreate() error {
 	fake.recreateMutex.Lock()
 	ret, specificReturn := fake.recreateReturnsOnCall[len(fake.recreateArgsForCall)]
@@ -460,6 +641,110 @@ func (fake *FakeIClient) RecreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) Start() error {
+	fake.startMutex.Lock()
+	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Start", []interface{}{})
+	fake.startMutex.Unlock()
+	if fake.StartStub != nil {
+		return fake.StartStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.startReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *FakeIClient) StartCalls(stub func() error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *FakeIClient) StartReturns(result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	fake.startReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) StartReturnsOnCall(i int, result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	if fake.startReturnsOnCall == nil {
+		fake.startReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) Stop() error {
+	fake.stopMutex.Lock()
+	ret, specificReturn := fake.stopReturnsOnCall[len(fake.stopArgsForCall)]
+	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Stop", []interface{}{})
+	fake.stopMutex.Unlock()
+	if fake.StopStub != nil {
+		return fake.StopStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.stopReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) StopCallCount() int {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return len(fake.stopArgsForCall)
+}
+
+func (fake *FakeIClient) StopCalls(stub func() error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = stub
+}
+
+func (fake *FakeIClient) StopReturns(result1 error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = nil
+	fake.stopReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) StopReturnsOnCall(i int, result1 error) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = nil
+	if fake.stopReturnsOnCall == nil {
+		fake.stopReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.stopReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -469,12 +754,20 @@ func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	defer fake.createEnvMutex.RUnlock()
 	fake.deployMutex.RLock()
 	defer fake.deployMutex.RUnlock()
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
 	fake.instancesMutex.RLock()
 	defer fake.instancesMutex.RUnlock()
 	fake.locksMutex.RLock()
 	defer fake.locksMutex.RUnlock()
+	fake.planConcourseMutex.RLock()
+	defer fake.planConcourseMutex.RUnlock()
 	fake.recreateMutex.RLock()
 	defer fake.recreateMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
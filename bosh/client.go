@@ -29,10 +29,14 @@ const CredsFilename = "director-creds.yml"
 // IClient is a client for performing bosh-init commands
 type IClient interface {
 	Deploy([]byte, []byte, bool) ([]byte, []byte, error)
+	DeployConcourse([]byte, bool) ([]byte, error)
+	PlanConcourse([]byte) error
 	Cleanup() error
 	Instances() ([]Instance, error)
 	CreateEnv([]byte, []byte, string) ([]byte, []byte, error)
 	Recreate() error
+	Start() error
+	Stop() error
 	Locks() ([]byte, error)
 }
 
@@ -131,14 +135,29 @@ func saveFilesToWorkingDir(workingdir workingdir.IClient, provider iaas.Provider
 	}).([]byte)
 
 	filesToSave := map[string][]byte{
-		concourseVersionsFilename:      concourseVersionsContents,
-		concourseSHAsFilename:          concourseSHAsContents,
-		concourseManifestFilename:      concourseManifestContents,
-		concourseCompatibilityFilename: concourseCompatibility,
-		concourseGrafanaFilename:       concourseGrafana,
-		concourseGitHubAuthFilename:    concourseGitHubAuth,
-		credsFilename:                  creds,
-		extraTagsFilename:              extraTags,
+		concourseVersionsFilename:           concourseVersionsContents,
+		concourseSHAsFilename:               concourseSHAsContents,
+		concourseManifestFilename:           concourseManifestContents,
+		concourseCompatibilityFilename:      concourseCompatibility,
+		concourseGrafanaFilename:            concourseGrafana,
+		concourseGitHubAuthFilename:         concourseGitHubAuth,
+		concourseOIDCAuthFilename:           concourseOIDCAuth,
+		concourseGitLabAuthFilename:         concourseGitLabAuth,
+		concourseBitbucketCloudAuthFilename: concourseBitbucketCloudAuth,
+		concourseLDAPAuthFilename:           concourseLDAPAuth,
+		concourseStemcellOSFilename:         concourseStemcellOS,
+		concourseNoCredhubFilename:          concourseNoCredhub,
+		concourseVaultFilename:              concourseVault,
+		concourseVaultAppRoleAuthFilename:   concourseVaultAppRoleAuth,
+		concourseVaultCertAuthFilename:      concourseVaultCertAuth,
+		concourseAWSSecretsManagerFilename:  concourseAWSSecretsManager,
+		concourseAWSSSMFilename:             concourseAWSSSM,
+		concoursePrometheusFilename:         concoursePrometheus,
+		concourseNoGrafanaFilename:          concourseNoGrafana,
+		concourseSyslogFilename:             concourseSyslog,
+		concourseWebHAFilename:              concourseWebHA,
+		credsFilename:                       creds,
+		extraTagsFilename:                   extraTags,
 	}
 
 	for filename, contents := range filesToSave {
@@ -149,3 +168,23 @@ func saveFilesToWorkingDir(workingdir workingdir.IClient, provider iaas.Provider
 	}
 	return nil
 }
+
+// directorOpsFiles returns the ops files that create-env applies to the director manifest on top of the IAAS
+// ones: operation, which maintenance passes in, followed by those for syslog, the proxy and the user's own
+func directorOpsFiles(c config.ConfigView, operation string) ([]string, error) {
+	syslogOps, err := directorSyslogOps(c)
+	if err != nil {
+		return nil, err
+	}
+	proxyOps, err := directorProxyOps(c)
+	if err != nil {
+		return nil, err
+	}
+
+	customOps, err := joinCustomOps(c.GetDirectorOpsFiles())
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{operation, syslogOps, proxyOps, customOps}, nil
+}
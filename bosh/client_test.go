@@ -1,8 +1,13 @@
 package bosh_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 
 	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli/boshclifakes"
@@ -11,6 +16,7 @@ import (
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
 	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+	"github.com/EngineerBetter/control-tower/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -117,6 +123,35 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 	})
 
 	Describe("New", func() {
+		var boshCLIServer *httptest.Server
+		var boshCLIBinary = []byte("#!/bin/sh\necho bosh")
+
+		var sum = func(b []byte) string {
+			s := sha256.Sum256(b)
+			return hex.EncodeToString(s[:])
+		}
+
+		var boshCLIVersionFile = func(sha256Sum string) []byte {
+			return []byte(fmt.Sprintf(`{
+				"bosh-cli": {
+					"mac": "%[1]s/bosh-cli-darwin-amd64",
+					"mac_sha256": "%[2]s",
+					"linux": "%[1]s/bosh-cli-linux-amd64",
+					"linux_sha256": "%[2]s"
+				}
+			}`, boshCLIServer.URL, sha256Sum))
+		}
+
+		BeforeEach(func() {
+			boshCLIServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write(boshCLIBinary)
+			}))
+		})
+
+		AfterEach(func() {
+			boshCLIServer.Close()
+		})
+
 		Context("When provider is AWS", func() {
 			JustBeforeEach(func() {
 				boshCLI = &boshclifakes.FakeICLI{}
@@ -130,12 +165,7 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 
 			Context("When Bosh CLI url is in versionFile", func() {
 				JustBeforeEach(func() {
-					versionFile = []byte(`{
-						"bosh-cli": {
-							"mac": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-darwin-amd64",
-							"linux": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-linux-amd64"
-						}
-					}`)
+					versionFile = boshCLIVersionFile(sum(boshCLIBinary))
 				})
 
 				It("returns an AWSClient", func() {
@@ -155,6 +185,37 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 					Expect(err.Error()).To(HavePrefix("failed to determine BOSH CLI path:"))
 				})
 			})
+
+			Context("When Bosh CLI does not match its pinned SHA256", func() {
+				JustBeforeEach(func() {
+					versionFile = boshCLIVersionFile(sum([]byte("genuine")))
+				})
+
+				It("returns an appropriate error", func() {
+					_, err := bosh.New(configInput, terraformOutputs, stdout, stderr, provider, versionFile)
+					Expect(err).To(MatchError(ContainSubstring("but " + sum([]byte("genuine")) + " is pinned")))
+				})
+			})
+
+			Context("When no SHA256 is pinned for Bosh CLI", func() {
+				JustBeforeEach(func() {
+					versionFile = boshCLIVersionFile("")
+				})
+
+				It("refuses to download it", func() {
+					_, err := bosh.New(configInput, terraformOutputs, stdout, stderr, provider, versionFile)
+					Expect(err).To(MatchError(ContainSubstring("no SHA256 is pinned for the bosh-cli binary")))
+				})
+
+				It("downloads it unverified with --allow-unverified-binaries and returns an AWSClient", func() {
+					util.AllowUnverifiedBinaries = true
+					defer func() { util.AllowUnverifiedBinaries = false }()
+
+					client, err := bosh.New(configInput, terraformOutputs, stdout, stderr, provider, versionFile)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(client).To(BeAssignableToTypeOf(&bosh.AWSClient{}))
+				})
+			})
 		})
 		Context("When provider is GCP", func() {
 			JustBeforeEach(func() {
@@ -169,12 +230,7 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 
 			Context("When Bosh CLI url is in versionFile", func() {
 				JustBeforeEach(func() {
-					versionFile = []byte(`{
-						"bosh-cli": {
-							"mac": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-darwin-amd64",
-							"linux": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-linux-amd64"
-						}
-					}`)
+					versionFile = boshCLIVersionFile(sum(boshCLIBinary))
 				})
 
 				It("returns an AWSClient", func() {
@@ -201,12 +257,7 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 				directorClient = &workingdirfakes.FakeIClient{}
 				terraformOutputs = &terraformfakes.FakeOutputs{}
 				provider = setupUnknownProvider()
-				versionFile = []byte(`{
-					"bosh-cli": {
-						"mac": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-darwin-amd64",
-						"linux": "https://s3.amazonaws.com/bosh-cli-artifacts/bosh-cli-5.0.1-linux-amd64"
-					}
-				}`)
+				versionFile = boshCLIVersionFile(sum(boshCLIBinary))
 
 				stdout = gbytes.NewBuffer()
 				stderr = gbytes.NewBuffer()
@@ -0,0 +1,67 @@
+package bosh
+
+import (
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"gopkg.in/yaml.v2"
+)
+
+// concoursePropertiesOpsFiles writes an ops file that sets the configured Concourse properties on the
+// web and worker jobs, and returns the --ops-file flag for it. Properties left at their zero value are
+// not set, so Concourse's defaults apply
+func concoursePropertiesOpsFiles(c config.ConfigView, workingdir workingdir.IClient) ([]string, error) {
+	p := c.GetConcourseProperties()
+
+	var ops []opsFileEntry
+	set := func(job, property string, value interface{}) {
+		ops = append(ops, opsFileEntry{
+			Type:  "replace",
+			Path:  "/instance_groups/name=" + job + "/jobs/name=" + job + "/properties/" + strings.Replace(property, ".", "?/", -1) + "?",
+			Value: value,
+		})
+	}
+
+	if p.BuildLogsToRetain != 0 {
+		set("web", "default_build_logs_to_retain", p.BuildLogsToRetain)
+	}
+	if p.ContainerPlacementStrategy != "" {
+		set("web", "container_placement_strategy", p.ContainerPlacementStrategy)
+	}
+	if p.DefaultTaskCPULimit != 0 {
+		set("web", "default_task_cpu_limit", p.DefaultTaskCPULimit)
+	}
+	if p.DefaultTaskMemoryLimit != "" {
+		set("web", "default_task_memory_limit", p.DefaultTaskMemoryLimit)
+	}
+	for _, flag := range p.FeatureFlags {
+		set("web", "enable_"+strings.Replace(flag, "-", "_", -1), true)
+	}
+	if p.GCInterval != "" {
+		set("web", "gc.interval", p.GCInterval)
+	}
+	if p.ResourceCheckingInterval != "" {
+		set("web", "resource_checking_interval", p.ResourceCheckingInterval)
+	}
+	if p.ResourceWebhookCheckingInterval != "" {
+		set("web", "resource_with_webhook_checking_interval", p.ResourceWebhookCheckingInterval)
+	}
+	if p.MaxContainersPerWorker != 0 {
+		set("worker", "garden.max_containers", p.MaxContainersPerWorker)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(concoursePropertiesFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
@@ -0,0 +1,50 @@
+package bosh
+
+import (
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+	"gopkg.in/yaml.v2"
+)
+
+// concourseVersionOpsFiles writes an ops file that replaces the pinned Concourse release with the configured
+// version from the compatibility table, and returns the --ops-file flag for it
+func concourseVersionOpsFiles(c config.ConfigView, workingdir workingdir.IClient, releaseVersions []byte) ([]string, error) {
+	if c.GetConcourseVersion() == "" {
+		return nil, nil
+	}
+
+	version, err := resource.FindConcourseVersion(resource.ConcourseVersions, resource.DefaultConcourseVersion(string(releaseVersions)), c.GetConcourseVersion(), c.GetStemcellOS())
+	if err != nil {
+		return nil, err
+	}
+	if version.URL == "" {
+		return nil, nil
+	}
+
+	url, err := mirror.URL(c.GetArtefactMirror(), version.URL)
+	if err != nil {
+		return nil, err
+	}
+	ops := []opsFileEntry{
+		{Type: "replace", Path: "/releases/name=concourse/version", Value: version.Version},
+		{Type: "replace", Path: "/releases/name=concourse/url?", Value: url},
+	}
+	// The sha1 pinned for the default release does not match another version, so it goes if there is no other
+	if version.SHA1 != "" {
+		ops = append(ops, opsFileEntry{Type: "replace", Path: "/releases/name=concourse/sha1?", Value: version.SHA1})
+	} else {
+		ops = append(ops, opsFileEntry{Type: "remove", Path: "/releases/name=concourse/sha1?"})
+	}
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(concourseVersionFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
@@ -0,0 +1,70 @@
+package bosh
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir/workingdirfakes"
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+func Test_concourseVersionOpsFiles(t *testing.T) {
+	releaseVersions := []byte(`[{"type": "replace", "path": "/releases/name=concourse/version", "value": "6.7.2"}]`)
+	tests := []struct {
+		name           string
+		version        string
+		artefactMirror string
+		want           string
+	}{
+		{
+			name:    "default version",
+			version: "6.7.2",
+		},
+		{
+			name:    "version from the compatibility table",
+			version: "6.6.0",
+			want: `- type: replace
+  path: /releases/name=concourse/version
+  value: 6.6.0
+- type: replace
+  path: /releases/name=concourse/url?
+  value: https://bosh.io/d/github.com/concourse/concourse-bosh-release?v=6.6.0
+- type: remove
+  path: /releases/name=concourse/sha1?
+`,
+		},
+		{
+			name:           "version from the compatibility table with an artefact mirror",
+			version:        "6.5.1",
+			artefactMirror: "https://artefacts.example.com",
+			want: `- type: replace
+  path: /releases/name=concourse/version
+  value: 6.5.1
+- type: replace
+  path: /releases/name=concourse/url?
+  value: https://artefacts.example.com/bosh.io/d/github.com/concourse/concourse-bosh-release/v=6.5.1
+- type: remove
+  path: /releases/name=concourse/sha1?
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingdir := &workingdirfakes.FakeIClient{}
+			c := config.Config{ConcourseVersion: tt.version, StemcellOS: "xenial", ArtefactMirror: tt.artefactMirror}
+			flags, err := concourseVersionOpsFiles(c, workingdir, releaseVersions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if flags != nil || workingdir.SaveFileToWorkingDirCallCount() != 0 {
+					t.Errorf("concourseVersionOpsFiles() = %v, want no ops file for the default version", flags)
+				}
+				return
+			}
+			filename, contents := workingdir.SaveFileToWorkingDirArgsForCall(0)
+			if filename != concourseVersionFilename || string(contents) != tt.want {
+				t.Errorf("concourseVersionOpsFiles() wrote %s:\n%s\nwant:\n%s", filename, contents, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package bosh
+
+import (
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+const defaultVaultPathPrefix = "/concourse"
+
+// credentialManagerOpsFiles adds the vars for the configured credential manager to vmap
+// and returns the --ops-file flags that replace CredHub and UAA with it. CredHub needs none
+func credentialManagerOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}) []string {
+	if c.IsCredhubCredentialManager() {
+		return nil
+	}
+
+	flagFiles := []string{"--ops-file", workingdir.PathInWorkingDir(concourseNoCredhubFilename)}
+
+	switch c.GetCredentialManager() {
+	case config.CredentialManagerVault:
+		flagFiles = append(flagFiles, vaultOpsFiles(c, workingdir, vmap)...)
+	case config.CredentialManagerAWSSecretsManager:
+		vmap["aws_region"] = c.GetRegion()
+		vmap["aws_credential_path_prefix"] = c.GetAWSCredentialPathPrefix()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseAWSSecretsManagerFilename))
+	case config.CredentialManagerAWSSSM:
+		vmap["aws_region"] = c.GetRegion()
+		vmap["aws_credential_path_prefix"] = c.GetAWSCredentialPathPrefix()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseAWSSSMFilename))
+	}
+
+	return flagFiles
+}
+
+func vaultOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}) []string {
+	vmap["vault_url"] = c.GetVaultURL()
+	vmap["vault_auth_backend"] = c.GetVaultAuthBackend()
+	vmap["vault_ca_cert"] = c.GetVaultCACert()
+	vmap["vault_path_prefix"] = defaultVaultPathPrefix
+	if c.GetVaultPathPrefix() != "" {
+		vmap["vault_path_prefix"] = c.GetVaultPathPrefix()
+	}
+	flagFiles := []string{"--ops-file", workingdir.PathInWorkingDir(concourseVaultFilename)}
+
+	switch c.GetVaultAuthBackend() {
+	case "approle":
+		vmap["vault_role_id"] = c.GetVaultRoleID()
+		vmap["vault_secret_id"] = c.GetVaultSecretID()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseVaultAppRoleAuthFilename))
+	case "cert":
+		vmap["vault_client_cert"] = c.GetVaultClientCert()
+		vmap["vault_client_key"] = c.GetVaultClientKey()
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseVaultCertAuthFilename))
+	}
+
+	return flagFiles
+}
@@ -0,0 +1,66 @@
+package bosh
+
+import (
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"gopkg.in/yaml.v2"
+)
+
+// joinCustomOps returns the operations of user-supplied ops files as a single ops file, ready to be
+// applied after control-tower's own operations
+func joinCustomOps(files []config.CustomFile) (string, error) {
+	var ops []opsFileEntry
+	for _, file := range files {
+		var fileOps []opsFileEntry
+		if err := yaml.Unmarshal([]byte(file.Contents), &fileOps); err != nil {
+			return "", fmt.Errorf("failed to parse ops file %s: [%v]", file.Name, err)
+		}
+		ops = append(ops, fileOps...)
+	}
+	if len(ops) == 0 {
+		return "", nil
+	}
+
+	contents, err := yaml.Marshal(ops)
+	return string(contents), err
+}
+
+// customOpsFiles writes the user-supplied Concourse ops and vars files to the working directory, and
+// returns the --ops-file and --vars-file flags for them
+func customOpsFiles(c config.ConfigView, workingdir workingdir.IClient) ([]string, error) {
+	var flags []string
+	for i, file := range c.GetConcourseOpsFiles() {
+		path, err := workingdir.SaveFileToWorkingDir(fmt.Sprintf("custom-ops-%d-%s", i+1, file.Name), []byte(file.Contents))
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, "--ops-file", path)
+	}
+	for i, file := range c.GetVarsFiles() {
+		path, err := workingdir.SaveFileToWorkingDir(fmt.Sprintf("custom-vars-%d-%s", i+1, file.Name), []byte(file.Contents))
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, "--vars-file", path)
+	}
+	return flags, nil
+}
+
+// DirectorManifest returns the director manifest for an IAAS, which director ops files must apply to
+func DirectorManifest(name iaas.Name) (string, error) {
+	return boshcli.DirectorManifest(name)
+}
+
+// CloudConfig returns the cloud config for an IAAS, which cloud config ops files must apply to
+func CloudConfig(name iaas.Name) (string, error) {
+	return boshcli.CloudConfig(name)
+}
+
+// ConcourseManifest returns the Concourse manifest, which Concourse ops files must apply to
+func ConcourseManifest() (string, error) {
+	return string(concourseManifestContents), nil
+}
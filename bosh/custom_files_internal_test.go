@@ -0,0 +1,56 @@
+package bosh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+func Test_joinCustomOps(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []config.CustomFile
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no files",
+		},
+		{
+			name: "files with document separators, comments and no trailing newline",
+			files: []config.CustomFile{
+				{Name: "first.yml", Contents: "---\n# scale the workers\n- type: replace\n  path: /instance_groups/name=worker/instances\n  value: 3"},
+				{Name: "second.yml", Contents: "- type: remove\n  path: /instance_groups/name=web/jobs/name=node_exporter?\n- type: replace\n  path: /instance_groups/name=web/jobs/name=web/properties/enable_global_resources?\n  value: false\n"},
+			},
+			want: `- type: replace
+  path: /instance_groups/name=worker/instances
+  value: 3
+- type: remove
+  path: /instance_groups/name=web/jobs/name=node_exporter?
+- type: replace
+  path: /instance_groups/name=web/jobs/name=web/properties/enable_global_resources?
+  value: false
+`,
+		},
+		{
+			name:    "file that is not a list of operations",
+			files:   []config.CustomFile{{Name: "broken.yml", Contents: "type: remove"}},
+			wantErr: "failed to parse ops file broken.yml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := joinCustomOps(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Errorf("joinCustomOps() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("joinCustomOps() = %v, %v, want:\n%s", got, err, tt.want)
+			}
+		})
+	}
+}
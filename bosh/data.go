@@ -8,6 +8,29 @@ const concourseSHAsFilename = "shas.json"
 const concourseGrafanaFilename = "grafana_dashboard.yml"
 const concourseCompatibilityFilename = "cup_compatibility.yml"
 const concourseGitHubAuthFilename = "github-auth.yml"
+const concourseOIDCAuthFilename = "oidc-auth.yml"
+const concourseGitLabAuthFilename = "gitlab-auth.yml"
+const concourseBitbucketCloudAuthFilename = "bitbucket-cloud-auth.yml"
+const concourseLDAPAuthFilename = "ldap-auth.yml"
+const concourseStemcellOSFilename = "stemcell-os.yml"
+const concourseNoCredhubFilename = "no-credhub.yml"
+const concourseVaultFilename = "vault.yml"
+const concourseVaultAppRoleAuthFilename = "vault-approle-auth.yml"
+const concourseVaultCertAuthFilename = "vault-cert-auth.yml"
+const concourseAWSSecretsManagerFilename = "aws-secretsmanager.yml"
+const concourseAWSSSMFilename = "aws-ssm.yml"
+const concoursePrometheusFilename = "prometheus.yml"
+const concourseNoGrafanaFilename = "no-grafana.yml"
+const concourseCustomGrafanaFilename = "custom-grafana.yml"
+const concourseSyslogFilename = "syslog.yml"
+const concourseWebHAFilename = "web-ha.yml"
+const concourseWorkerPoolsFilename = "worker-pools.yml"
+const concourseExternalWorkersFilename = "external-workers.yml"
+const concoursePropertiesFilename = "concourse-properties.yml"
+const runtimeConfigFilename = "runtime-config.yml"
+const concourseVersionFilename = "concourse-version.yml"
+const artefactMirrorFilename = "artefact-mirror.yml"
+const proxyFilename = "proxy.yml"
 const extraTagsFilename = "extra_tags.yml"
 const uaaCertFilename = "uaa-cert.yml"
 
@@ -15,6 +38,22 @@ const uaaCertFilename = "uaa-cert.yml"
 var concourseGrafana = MustAsset("assets/grafana_dashboard.yml")
 var concourseCompatibility = MustAsset("assets/ops/cup_compatibility.yml")
 var concourseGitHubAuth = MustAsset("assets/ops/github-auth.yml")
+var concourseOIDCAuth = MustAsset("assets/ops/oidc-auth.yml")
+var concourseGitLabAuth = MustAsset("assets/ops/gitlab-auth.yml")
+var concourseBitbucketCloudAuth = MustAsset("assets/ops/bitbucket-cloud-auth.yml")
+var concourseLDAPAuth = MustAsset("assets/ops/ldap-auth.yml")
+var concourseStemcellOS = MustAsset("assets/ops/stemcell-os.yml")
+var concourseNoCredhub = MustAsset("assets/ops/no-credhub.yml")
+var concourseVault = MustAsset("assets/ops/vault.yml")
+var concourseVaultAppRoleAuth = MustAsset("assets/ops/vault-approle-auth.yml")
+var concourseVaultCertAuth = MustAsset("assets/ops/vault-cert-auth.yml")
+var concourseAWSSecretsManager = MustAsset("assets/ops/aws-secretsmanager.yml")
+var concourseAWSSSM = MustAsset("assets/ops/aws-ssm.yml")
+var concoursePrometheus = MustAsset("assets/ops/prometheus.yml")
+var concourseNoGrafana = MustAsset("assets/ops/no-grafana.yml")
+var concourseSyslog = MustAsset("assets/ops/syslog.yml")
+var directorSyslog = MustAsset("assets/ops/director-syslog.yml")
+var concourseWebHA = MustAsset("assets/ops/web-ha.yml")
 var extraTags = MustAsset("assets/ops/extra_tags.yml")
 var concourseManifestContents = MustAsset("../../control-tower-ops/manifest.yml")
 var awsConcourseVersions = MustAsset("../../control-tower-ops/ops/versions-aws.json")
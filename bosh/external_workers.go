@@ -0,0 +1,40 @@
+package bosh
+
+import (
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"gopkg.in/yaml.v2"
+)
+
+// externalWorkersOpsFiles writes an ops file that authorises the public keys of external workers
+// with the TSA, and returns the --ops-file flag for it. Keys of workers assigned to a team are
+// only accepted for that team
+func externalWorkersOpsFiles(c config.ConfigView, workingdir workingdir.IClient) ([]string, error) {
+	if len(c.GetExternalWorkers()) == 0 {
+		return nil, nil
+	}
+
+	var ops []opsFileEntry
+	for _, worker := range c.GetExternalWorkers() {
+		path := "/instance_groups/name=web/jobs/name=web/properties/worker_gateway?/authorized_keys?/-"
+		if worker.Team != "" {
+			path = "/instance_groups/name=web/jobs/name=web/properties/worker_gateway?/team_authorized_keys?/" + worker.Team + "?/-"
+		}
+		ops = append(ops, opsFileEntry{
+			Type:  "replace",
+			Path:  path,
+			Value: worker.PublicKey,
+		})
+	}
+
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(concourseExternalWorkersFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
@@ -22,6 +22,9 @@ func (client *GCPClient) Deploy(state, creds []byte, detach bool) (newState, new
 	if err = client.updateCloudConfig(client.boshCLI); err != nil {
 		return state, creds, err
 	}
+	if err = client.updateRuntimeConfig(); err != nil {
+		return state, creds, err
+	}
 	if err = client.uploadConcourseStemcell(client.boshCLI); err != nil {
 		return state, creds, err
 	}
@@ -37,6 +40,22 @@ func (client *GCPClient) Deploy(state, creds []byte, detach bool) (newState, new
 	return state, creds, err
 }
 
+// DeployConcourse runs bosh deploy for the Concourse deployment alone, for changes such as the worker
+// count that need neither the director nor the cloud config to be updated
+func (client *GCPClient) DeployConcourse(creds []byte, detach bool) ([]byte, error) {
+	return client.deployConcourse(creds, detach)
+}
+
+// PlanConcourse runs bosh deploy --dry-run for the Concourse deployment, which shows the changes a deploy would
+// make without making them. The stemcell is uploaded first, as BOSH cannot render a manifest without it
+func (client *GCPClient) PlanConcourse(creds []byte) error {
+	if err := client.uploadConcourseStemcell(client.boshCLI); err != nil {
+		return err
+	}
+	_, err := client.deployConcourse(creds, false, "--dry-run")
+	return err
+}
+
 // CreateEnv exposes bosh create-env functionality
 func (client *GCPClient) CreateEnv(state, creds []byte, customOps string) (newState, newCreds []byte, err error) {
 	tags, err := splitTags(client.config.GetTags())
@@ -46,6 +65,11 @@ func (client *GCPClient) CreateEnv(state, creds []byte, customOps string) (newSt
 	tags["control-tower-project"] = client.config.GetProject()
 	tags["control-tower-component"] = "concourse"
 
+	directorOps, err1 := directorOpsFiles(client.config, customOps)
+	if err1 != nil {
+		return state, creds, err1
+	}
+
 	network, err1 := client.outputs.Get("Network")
 	if err1 != nil {
 		return state, creds, err1
@@ -100,7 +124,7 @@ func (client *GCPClient) CreateEnv(state, creds []byte, customOps string) (newSt
 		ExternalIP:         directorPublicIP,
 		Spot:               client.config.IsSpot(),
 		PublicKey:          client.config.GetPublicKey(),
-		CustomOperations:   customOps,
+		CustomOperations:   directorOps,
 		VersionFile:        client.versionFile,
 	}, client.config.GetDirectorPassword(), client.config.GetDirectorCert(), client.config.GetDirectorKey(), client.config.GetDirectorCACert(), tags)
 	if err1 != nil {
@@ -120,6 +144,24 @@ func (client *GCPClient) Recreate() error {
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
 
+// Stop stops the Concourse VMs and deletes them, keeping their persistent disks
+func (client *GCPClient) Stop() error {
+	return client.runConcourseCommand("stop", "--hard")
+}
+
+// Start recreates and starts the Concourse VMs after Stop
+func (client *GCPClient) Start() error {
+	return client.runConcourseCommand("start")
+}
+
+func (client *GCPClient) runConcourseCommand(action string, flags ...string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return err
+	}
+	return client.boshCLI.RunAuthenticatedCommand(action, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert(), false, client.stdout, flags...)
+}
+
 // Locks implements locks for GCP client
 func (client *GCPClient) Locks() ([]byte, error) {
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
@@ -150,6 +192,10 @@ func (client *GCPClient) updateCloudConfig(bosh boshcli.ICLI) error {
 	if err != nil {
 		return err
 	}
+	webTargetPool, err := client.outputs.Get("WebTargetPool")
+	if err != nil {
+		return err
+	}
 	zone := client.provider.Zone("", "")
 
 	publicCIDR := client.config.GetPublicCIDR()
@@ -165,7 +211,7 @@ func (client *GCPClient) updateCloudConfig(bosh boshcli.ICLI) error {
 
 	publicCIDRGateway := pubGateway.String()
 
-	publicCIDRStatic, err := formatIPRange(publicCIDR, ", ", []int{7})
+	publicCIDRStatic, err := formatIPRange(publicCIDR, ", ", webStaticIPHosts(7, client.config.GetConcourseWebCount()))
 	if err != nil {
 		return err
 	}
@@ -192,6 +238,11 @@ func (client *GCPClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		return err
 	}
 
+	cloudConfigOps, err := joinCustomOps(client.config.GetCloudConfigOpsFiles())
+	if err != nil {
+		return err
+	}
+
 	return bosh.UpdateCloudConfig(boshcli.GCPEnvironment{
 		PublicCIDR:          client.config.GetPublicCIDR(),
 		PublicCIDRGateway:   publicCIDRGateway,
@@ -205,14 +256,27 @@ func (client *GCPClient) updateCloudConfig(bosh boshcli.ICLI) error {
 		PrivateSubnetwork:   privateSubnetwork,
 		Zone:                zone,
 		Network:             network,
+		WebTargetPool:       webTargetPool,
+		WorkerPools:         workerPoolVMTypes(client.config),
+		CloudConfigOps:      cloudConfigOps,
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
+func (client *GCPClient) updateRuntimeConfig() error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return err
+	}
+	return updateRuntimeConfig(client.config, client.boshCLI, client.workingdir, directorPublicIP, client.stdout)
+}
+
 func (client *GCPClient) uploadConcourseStemcell(bosh boshcli.ICLI) error {
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
 	if err != nil {
 		return err
 	}
 	return bosh.UploadConcourseStemcell(boshcli.GCPEnvironment{
-		ExternalIP: directorPublicIP,
+		ExternalIP:     directorPublicIP,
+		StemcellOS:     client.config.GetStemcellOS(),
+		ArtefactMirror: client.config.GetArtefactMirror(),
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
@@ -0,0 +1,58 @@
+package bosh
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"gopkg.in/yaml.v2"
+)
+
+type opsFileEntry struct {
+	Type  string      `yaml:"type"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value,omitempty"`
+}
+
+// grafanaOpsFiles writes an ops file that provisions the user's dashboards and alert
+// notification channels alongside the built-in dashboard, and returns the --ops-file flag for it
+func grafanaOpsFiles(c config.ConfigView, workingdir workingdir.IClient) ([]string, error) {
+	if c.GetDisableGrafana() || (len(c.GetGrafanaDashboards()) == 0 && len(c.GetGrafanaNotifiers()) == 0) {
+		return nil, nil
+	}
+
+	var ops []opsFileEntry
+	for i, dashboard := range c.GetGrafanaDashboards() {
+		ops = append(ops, opsFileEntry{
+			Type: "replace",
+			Path: "/instance_groups/name=web/jobs/name=grafana/properties/grafana/dashboards?/-",
+			Value: map[string]string{
+				"name":    fmt.Sprintf("custom-%d", i+1),
+				"content": dashboard.Contents,
+			},
+		})
+	}
+	for _, notifier := range c.GetGrafanaNotifiers() {
+		var value map[string]interface{}
+		if err := json.Unmarshal([]byte(notifier.Contents), &value); err != nil {
+			return nil, fmt.Errorf("failed to parse Grafana notification channel %s: [%v]", notifier.Name, err)
+		}
+		ops = append(ops, opsFileEntry{
+			Type:  "replace",
+			Path:  "/instance_groups/name=web/jobs/name=grafana/properties/grafana/notifiers?/-",
+			Value: value,
+		})
+	}
+
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(concourseCustomGrafanaFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
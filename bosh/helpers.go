@@ -1,6 +1,7 @@
 package bosh
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/apparentlymart/go-cidr/cidr"
 	"net"
@@ -10,7 +11,7 @@ import (
 func vars(vars map[string]interface{}) []string {
 	var x []string
 	for k, v := range vars {
-		switch v.(type) {
+		switch v := v.(type) {
 		case string:
 			if k == "tags" {
 				x = append(x, "--var", fmt.Sprintf("%s=%s", k, v))
@@ -21,6 +22,13 @@ func vars(vars map[string]interface{}) []string {
 			x = append(x, "--var", fmt.Sprintf("%s=%d", k, v))
 		case bool:
 			x = append(x, "--var", fmt.Sprintf("%s=%t", k, v))
+		case []string:
+			if v == nil {
+				v = []string{}
+			}
+			// BOSH parses var values as YAML, so a JSON array becomes a list
+			b, _ := json.Marshal(v)
+			x = append(x, "--var", fmt.Sprintf("%s=%s", k, b))
 		default:
 			panic("unsupported type")
 		}
@@ -9,10 +9,12 @@ import (
 // Environment holds all the parameters AWS IAAS needs
 type AWSEnvironment struct {
 	AccessKeyID           string
+	ArtefactMirror        string
 	ATCSecurityGroup      string
 	AZ                    string
 	BlobstoreBucket       string
-	CustomOperations      string
+	CloudConfigOps        string
+	CustomOperations      []string
 	DBCACert              string
 	DBHost                string
 	DBName                string
@@ -40,11 +42,20 @@ type AWSEnvironment struct {
 	S3AWSSecretAccessKey  string
 	SecretAccessKey       string
 	Spot                  bool
+	StemcellOS            string
 	VersionFile           []byte
 	VMSecurityGroup       string
+	WebInstanceProfile    string
+	WebTargetGroups       string
+	WorkerPools           []WorkerPoolVMType
 	WorkerType            string
 }
 
+// awsDirectorOperations are the ops that turn the director manifest into one for AWS
+func awsDirectorOperations() string {
+	return resource.AWSCPIOps + resource.AWSExternalIPOps + resource.AWSBlobstoreOps + resource.AWSDirectorCustomOps
+}
+
 func (e AWSEnvironment) ExtractBOSHandBPM() (util.Resource, util.Resource, error) {
 	resources := util.ParseVersionResources(e.VersionFile)
 
@@ -62,9 +73,12 @@ func (e AWSEnvironment) ConfigureDirectorManifestCPI() (string, error) {
 	cpiResource := util.GetResource("cpi", resources)
 	stemcellResource := util.GetResource("stemcell", resources)
 
-	var allOperations = resource.AWSCPIOps + resource.AWSExternalIPOps + resource.AWSBlobstoreOps + resource.AWSDirectorCustomOps
+	operations, err := yaml.JoinOps(append([]string{awsDirectorOperations()}, e.CustomOperations...)...)
+	if err != nil {
+		return "", err
+	}
 
-	return yaml.Interpolate(resource.DirectorManifest, allOperations+e.CustomOperations, map[string]interface{}{
+	return yaml.Interpolate(resource.DirectorManifest, operations, map[string]interface{}{
 		"cpi_url":                  cpiResource.URL,
 		"cpi_version":              cpiResource.Version,
 		"cpi_sha1":                 cpiResource.SHA1,
@@ -101,6 +115,8 @@ type awsCloudConfigParams struct {
 	PublicSubnetID      string
 	Spot                bool
 	VMsSecurityGroupID  string
+	WebInstanceProfile  string
+	WebTargetGroups     string
 	WorkerType          string
 	PublicCIDR          string
 	PublicCIDRStatic    string
@@ -113,14 +129,28 @@ type awsCloudConfigParams struct {
 
 // ConfigureDirectorCloudConfig inserts values from the environment into the config template passed as argument
 func (e AWSEnvironment) ConfigureDirectorCloudConfig() (string, error) {
+	cc, err := e.renderCloudConfig(e.Spot)
+	if err != nil {
+		return "", err
+	}
+	cc, err = addWorkerPoolVMTypes(cc, e.WorkerPools, e.renderCloudConfig)
+	if err != nil {
+		return "", err
+	}
+	return applyCloudConfigOps(cc, e.CloudConfigOps)
+}
+
+func (e AWSEnvironment) renderCloudConfig(spot bool) (string, error) {
 	templateParams := awsCloudConfigParams{
 		AvailabilityZone:    e.AZ,
 		VMsSecurityGroupID:  e.VMSecurityGroup,
 		ATCSecurityGroupID:  e.ATCSecurityGroup,
 		PublicSubnetID:      e.PublicSubnetID,
 		PrivateSubnetID:     e.PrivateSubnetID,
-		Spot:                e.Spot,
+		Spot:                spot,
 		WorkerType:          e.WorkerType,
+		WebInstanceProfile:  e.WebInstanceProfile,
+		WebTargetGroups:     e.WebTargetGroups,
 		PublicCIDR:          e.PublicCIDR,
 		PublicCIDRGateway:   e.PublicCIDRGateway,
 		PublicCIDRReserved:  e.PublicCIDRReserved,
@@ -138,5 +168,5 @@ func (e AWSEnvironment) ConfigureDirectorCloudConfig() (string, error) {
 }
 
 func (e AWSEnvironment) ConcourseStemcellURL() (string, error) {
-	return concourseStemcellURL(resource.AWSReleaseVersions, "https://s3.amazonaws.com/bosh-aws-light-stemcells/%s/light-bosh-stemcell-%s-aws-xen-hvm-ubuntu-xenial-go_agent.tgz")
+	return concourseStemcellURL(resource.AWSReleaseVersions, "https://s3.amazonaws.com/bosh-aws-light-stemcells/%[1]s/light-bosh-stemcell-%[1]s-aws-xen-hvm-ubuntu-%[2]s-go_agent.tgz", e.StemcellOS, e.ArtefactMirror)
 }
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"text/template"
 	"text/template/parse"
@@ -113,6 +114,20 @@ func TestAWSEnvironment_ConfigureDirectorCloudConfig(t *testing.T) {
 				return a == b, fmt.Sprintf("m4 worker templating failed")
 			},
 		},
+		{
+			name:    "Success- web load balancer target groups rendered",
+			fields:  fullTemplateParams,
+			want:    "    lb_target_groups: [web-80,web-443]\n",
+			wantErr: false,
+			init: func(e AWSEnvironment) AWSEnvironment {
+				n := e
+				n.WebTargetGroups = "web-80,web-443"
+				return n
+			},
+			validate: func(a, b string) (bool, string) {
+				return strings.Contains(a, b), fmt.Sprintf("web load balancer target groups templating failed")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -193,23 +208,56 @@ func TestAWSEnvironment_ConfigureConcourseStemcell(t *testing.T) {
 		want    string
 		wantErr bool
 		fixture string
+		os      string
+		mirror  string
 	}{
 		{
 			name:    "parse versions and provide a valid stemcell url",
 			want:    "https://s3.amazonaws.com/bosh-aws-light-stemcells/5/light-bosh-stemcell-5-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
 			wantErr: false,
 			fixture: "stemcell_version",
+			os:      "xenial",
+		},
+		{
+			name:    "parse versions and provide a valid stemcell url for another stemcell line",
+			want:    "https://s3.amazonaws.com/bosh-aws-light-stemcells/6/light-bosh-stemcell-6-aws-xen-hvm-ubuntu-bionic-go_agent.tgz",
+			wantErr: false,
+			fixture: "stemcell_version",
+			os:      "bionic",
+		},
+		{
+			name:    "parse versions and provide the stemcell url in an artefact mirror",
+			want:    "https://mirror.example.com/ct/s3.amazonaws.com/bosh-aws-light-stemcells/5/light-bosh-stemcell-5-aws-xen-hvm-ubuntu-xenial-go_agent.tgz",
+			wantErr: false,
+			fixture: "stemcell_version",
+			os:      "xenial",
+			mirror:  "https://mirror.example.com/ct",
 		},
 		{
 			name:    "parse versions and indicate no stemcell was found",
 			want:    "",
 			wantErr: true,
 			fixture: "invalid_stemcell_version",
+			os:      "xenial",
+		},
+		{
+			name:    "parse versions and indicate no stemcell was found for the stemcell line",
+			want:    "",
+			wantErr: true,
+			fixture: "stemcell_version",
+			os:      "trusty",
+		},
+		{
+			name:    "take the version of a line the versions file does not pin from control-tower's table",
+			want:    "https://s3.amazonaws.com/bosh-aws-light-stemcells/1.18/light-bosh-stemcell-1.18-aws-xen-hvm-ubuntu-jammy-go_agent.tgz",
+			wantErr: false,
+			fixture: "stemcell_version",
+			os:      "jammy",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := AWSEnvironment{}
+			e := AWSEnvironment{StemcellOS: tt.os, ArtefactMirror: tt.mirror}
 			resource.AWSReleaseVersions = getStemcellFixture(tt.fixture)
 			got, err := e.ConcourseStemcellURL()
 			if (err != nil) != tt.wantErr {
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,7 +12,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/EngineerBetter/control-tower/resource"
 	"github.com/EngineerBetter/control-tower/util"
+	"github.com/EngineerBetter/control-tower/util/mirror"
 	"github.com/EngineerBetter/control-tower/util/yaml"
 )
 
@@ -53,7 +54,18 @@ type IAASEnvironment interface {
 	ExtractBOSHandBPM() (util.Resource, util.Resource, error)
 }
 
-func concourseStemcellURL(releaseVersionsFile, urlFormat string) (string, error) {
+func concourseStemcellURL(releaseVersionsFile, urlFormat, stemcellOS, artefactMirror string) (string, error) {
+	version, err := StemcellVersion(releaseVersionsFile, stemcellOS)
+	if err != nil {
+		return "", err
+	}
+
+	return mirror.URL(artefactMirror, fmt.Sprintf(urlFormat, version, stemcellOS))
+}
+
+// StemcellVersion finds the version of the given stemcell OS line pinned in a versions ops file, or in
+// control-tower's own table for lines that the ops file does not pin
+func StemcellVersion(releaseVersionsFile, stemcellOS string) (string, error) {
 	var ops []struct {
 		Path  string
 		Value json.RawMessage
@@ -64,7 +76,7 @@ func concourseStemcellURL(releaseVersionsFile, urlFormat string) (string, error)
 	}
 	var version string
 	for _, op := range ops {
-		if op.Path != "/stemcells/alias=xenial/version" {
+		if op.Path != fmt.Sprintf("/stemcells/alias=%s/version", stemcellOS) {
 			continue
 		}
 		err := json.Unmarshal(op.Value, &version)
@@ -73,10 +85,10 @@ func concourseStemcellURL(releaseVersionsFile, urlFormat string) (string, error)
 		}
 	}
 	if version == "" {
-		return "", errors.New("did not find stemcell version in versions.json")
+		return resource.FindStemcellVersion(resource.StemcellVersions, stemcellOS)
 	}
 
-	return fmt.Sprintf(urlFormat, version, version), nil
+	return version, nil
 }
 
 // UpdateCloudConfig generates cloud config from template and use it to update bosh cloud config
@@ -140,7 +152,7 @@ func (c *CLI) UploadConcourseStemcell(config IAASEnvironment, ip, password, ca s
 	}
 	defer os.Remove(caPath)
 	ip = fmt.Sprintf("https://%s", ip)
-	cmd := c.execCmd(c.boshPath, "--non-interactive", "--environment", ip, "--ca-cert", caPath, "--client", "admin", "--client-secret", password, "upload-stemcell", stemcell)
+	cmd := c.execCmd(c.boshPath, "--non-interactive", "--environment", ip, "--ca-cert", caPath, "--client", "admin", "--client-secret", password, "upload-stemcell", mirror.LocalPath(stemcell))
 	cmd.Stderr = os.Stderr
 	cmd.Stdout = os.Stdout
 	return cmd.Run()
@@ -0,0 +1,40 @@
+package boshcli
+
+import (
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util/yaml"
+)
+
+// applyCloudConfigOps returns cloudConfig with the user's ops applied. Variables are left for BOSH to interpolate
+func applyCloudConfigOps(cloudConfig, ops string) (string, error) {
+	if ops == "" {
+		return cloudConfig, nil
+	}
+	return yaml.Interpolate(cloudConfig, ops, nil)
+}
+
+// DirectorManifest returns the director manifest for an IAAS, which the user's director ops files are applied
+// to. Variables are left in place
+func DirectorManifest(iaasName iaas.Name) (string, error) {
+	switch iaasName {
+	case iaas.AWS:
+		return yaml.Interpolate(resource.DirectorManifest, awsDirectorOperations(), nil)
+	case iaas.GCP:
+		return yaml.Interpolate(resource.DirectorManifest, gcpDirectorOperations(), nil)
+	}
+	return "", fmt.Errorf("boshcli: %s is not a valid iaas provider", iaasName)
+}
+
+// CloudConfig returns the cloud config for an IAAS, which the user's cloud config ops files are applied to
+func CloudConfig(iaasName iaas.Name) (string, error) {
+	switch iaasName {
+	case iaas.AWS:
+		return AWSEnvironment{}.ConfigureDirectorCloudConfig()
+	case iaas.GCP:
+		return GCPEnvironment{}.ConfigureDirectorCloudConfig()
+	}
+	return "", fmt.Errorf("boshcli: %s is not a valid iaas provider", iaasName)
+}
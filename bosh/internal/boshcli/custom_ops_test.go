@@ -0,0 +1,50 @@
+package boshcli
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestGCPEnvironment_ConfigureDirectorCloudConfigWithCustomOps(t *testing.T) {
+	e := GCPEnvironment{
+		CloudConfigOps: `
+- type: replace
+  path: /vm_extensions?/-
+  value:
+    name: big-disk
+    cloud_properties: {root_disk_size_gb: ((disk_size))}
+`,
+	}
+
+	got, err := e.ConfigureDirectorCloudConfig()
+	if err != nil {
+		t.Fatalf("ConfigureDirectorCloudConfig() error = %v", err)
+	}
+
+	var cc struct {
+		VMExtensions []map[string]interface{} `yaml:"vm_extensions"`
+	}
+	if err = yaml.Unmarshal([]byte(got), &cc); err != nil {
+		t.Fatalf("rendered cloud config is not valid YAML: %v", err)
+	}
+	last := cc.VMExtensions[len(cc.VMExtensions)-1]
+	if last["name"] != "big-disk" {
+		t.Errorf("last vm_extension = %v, want big-disk", last["name"])
+	}
+	properties := last["cloud_properties"].(map[interface{}]interface{})
+	if properties["root_disk_size_gb"] != "((disk_size))" {
+		t.Errorf("root_disk_size_gb = %v, want the variable left for BOSH", properties["root_disk_size_gb"])
+	}
+}
+
+func TestAWSEnvironment_ConfigureDirectorCloudConfigWithInvalidCustomOps(t *testing.T) {
+	e := AWSEnvironment{
+		WorkerType:     "m4",
+		CloudConfigOps: "- type: remove\n  path: /no_such_key\n",
+	}
+
+	if _, err := e.ConfigureDirectorCloudConfig(); err == nil {
+		t.Error("ConfigureDirectorCloudConfig() expected an error for an op on a missing path")
+	}
+}
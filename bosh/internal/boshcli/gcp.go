@@ -10,7 +10,9 @@ import (
 
 // Environment holds all the parameters GCP IAAS needs
 type GCPEnvironment struct {
-	CustomOperations    string
+	ArtefactMirror      string
+	CloudConfigOps      string
+	CustomOperations    []string
 	DirectorName        string
 	ExternalIP          string
 	GcpCredentialsJSON  string
@@ -30,11 +32,19 @@ type GCPEnvironment struct {
 	PublicKey           string
 	PublicSubnetwork    string
 	Spot                bool
+	StemcellOS          string
 	Tags                string
 	VersionFile         []byte
+	WebTargetPool       string
+	WorkerPools         []WorkerPoolVMType
 	Zone                string
 }
 
+// gcpDirectorOperations are the ops that turn the director manifest into one for GCP
+func gcpDirectorOperations() string {
+	return resource.GCPCPIOps + resource.GCPExternalIPOps + resource.GCPDirectorCustomOps + resource.GCPJumpboxUserOps
+}
+
 func (e GCPEnvironment) ExtractBOSHandBPM() (util.Resource, util.Resource, error) {
 	resources := util.ParseVersionResources(e.VersionFile)
 
@@ -57,9 +67,12 @@ func (e GCPEnvironment) ConfigureDirectorManifestCPI() (string, error) {
 		return "", err
 	}
 
-	var allOperations = resource.GCPCPIOps + resource.GCPExternalIPOps + resource.GCPDirectorCustomOps + resource.GCPJumpboxUserOps
+	operations, err := yaml.JoinOps(append([]string{gcpDirectorOperations()}, e.CustomOperations...)...)
+	if err != nil {
+		return "", err
+	}
 
-	return yaml.Interpolate(resource.DirectorManifest, allOperations+e.CustomOperations, map[string]interface{}{
+	return yaml.Interpolate(resource.DirectorManifest, operations, map[string]interface{}{
 		"cpi_url":              cpiResource.URL,
 		"cpi_version":          cpiResource.Version,
 		"cpi_sha1":             cpiResource.SHA1,
@@ -93,15 +106,28 @@ type gcpCloudConfigParams struct {
 	PrivateCIDR         string
 	PrivateCIDRGateway  string
 	PrivateCIDRReserved string
+	WebTargetPool       string
 }
 
 // ConfigureDirectorCloudConfig inserts values from the environment into the config template passed as argument
 func (e GCPEnvironment) ConfigureDirectorCloudConfig() (string, error) {
+	cc, err := e.renderCloudConfig(e.Spot)
+	if err != nil {
+		return "", err
+	}
+	cc, err = addWorkerPoolVMTypes(cc, e.WorkerPools, e.renderCloudConfig)
+	if err != nil {
+		return "", err
+	}
+	return applyCloudConfigOps(cc, e.CloudConfigOps)
+}
+
+func (e GCPEnvironment) renderCloudConfig(spot bool) (string, error) {
 	templateParams := gcpCloudConfigParams{
 		Zone:                e.Zone,
 		PublicSubnetwork:    e.PublicSubnetwork,
 		PrivateSubnetwork:   e.PrivateSubnetwork,
-		Spot:                e.Spot,
+		Spot:                spot,
 		Network:             e.Network,
 		PublicCIDR:          e.PublicCIDR,
 		PublicCIDRGateway:   e.PublicCIDRGateway,
@@ -110,6 +136,7 @@ func (e GCPEnvironment) ConfigureDirectorCloudConfig() (string, error) {
 		PrivateCIDR:         e.PrivateCIDR,
 		PrivateCIDRGateway:  e.PrivateCIDRGateway,
 		PrivateCIDRReserved: e.PrivateCIDRReserved,
+		WebTargetPool:       e.WebTargetPool,
 	}
 
 	cc, err := util.RenderTemplate("cloud-config", resource.GCPDirectorCloudConfig, templateParams)
@@ -120,5 +147,5 @@ func (e GCPEnvironment) ConfigureDirectorCloudConfig() (string, error) {
 }
 
 func (e GCPEnvironment) ConcourseStemcellURL() (string, error) {
-	return concourseStemcellURL(resource.GCPReleaseVersions, "https://s3.amazonaws.com/bosh-gce-light-stemcells/%s/light-bosh-stemcell-%s-google-kvm-ubuntu-xenial-go_agent.tgz")
+	return concourseStemcellURL(resource.GCPReleaseVersions, "https://s3.amazonaws.com/bosh-gce-light-stemcells/%[1]s/light-bosh-stemcell-%[1]s-google-kvm-ubuntu-%[2]s-go_agent.tgz", e.StemcellOS, e.ArtefactMirror)
 }
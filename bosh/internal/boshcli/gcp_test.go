@@ -73,23 +73,47 @@ func TestGCPEnvironment_ConfigureConcourseStemcell(t *testing.T) {
 		want    string
 		wantErr bool
 		fixture string
+		os      string
 	}{
 		{
 			name:    "parse versions and provide a valid stemcell url",
 			want:    "https://s3.amazonaws.com/bosh-gce-light-stemcells/5/light-bosh-stemcell-5-google-kvm-ubuntu-xenial-go_agent.tgz",
 			wantErr: false,
 			fixture: "stemcell_version",
+			os:      "xenial",
+		},
+		{
+			name:    "parse versions and provide a valid stemcell url for another stemcell line",
+			want:    "https://s3.amazonaws.com/bosh-gce-light-stemcells/6/light-bosh-stemcell-6-google-kvm-ubuntu-bionic-go_agent.tgz",
+			wantErr: false,
+			fixture: "stemcell_version",
+			os:      "bionic",
 		},
 		{
 			name:    "parse versions and indicate no stemcell was found",
 			want:    "",
 			wantErr: true,
 			fixture: "invalid_stemcell_version",
+			os:      "xenial",
+		},
+		{
+			name:    "parse versions and indicate no stemcell was found for the stemcell line",
+			want:    "",
+			wantErr: true,
+			fixture: "stemcell_version",
+			os:      "trusty",
+		},
+		{
+			name:    "take the version of a line the versions file does not pin from control-tower's table",
+			want:    "https://s3.amazonaws.com/bosh-gce-light-stemcells/1.18/light-bosh-stemcell-1.18-google-kvm-ubuntu-jammy-go_agent.tgz",
+			wantErr: false,
+			fixture: "stemcell_version",
+			os:      "jammy",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := GCPEnvironment{}
+			e := GCPEnvironment{StemcellOS: tt.os}
 			resource.GCPReleaseVersions = getStemcellFixture(tt.fixture)
 			got, err := e.ConcourseStemcellURL()
 			if (err != nil) != tt.wantErr {
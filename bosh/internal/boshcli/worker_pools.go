@@ -0,0 +1,82 @@
+package boshcli
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// WorkerPoolVMType describes the vm_type of a pool of workers
+type WorkerPoolVMType struct {
+	Name string
+	Size string
+	Spot bool
+}
+
+// WorkerPoolVMTypeName returns the name of the vm_type for the named worker pool
+func WorkerPoolVMTypeName(pool string) string {
+	return "concourse-pool-" + pool
+}
+
+type cloudConfigVMTypes struct {
+	VMTypes []map[interface{}]interface{} `yaml:"vm_types"`
+}
+
+// addWorkerPoolVMTypes appends a vm_type for each worker pool to cloudConfig. Each is a copy of the worker
+// vm_type of the pool's size, taken from the cloud config rendered with the pool's provisioning type
+func addWorkerPoolVMTypes(cloudConfig string, pools []WorkerPoolVMType, render func(spot bool) (string, error)) (string, error) {
+	if len(pools) == 0 {
+		return cloudConfig, nil
+	}
+
+	var cc yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(cloudConfig), &cc); err != nil {
+		return "", err
+	}
+
+	rendered := map[bool]cloudConfigVMTypes{}
+	for _, spot := range []bool{true, false} {
+		contents, err := render(spot)
+		if err != nil {
+			return "", err
+		}
+		var vmTypes cloudConfigVMTypes
+		if err = yaml.Unmarshal([]byte(contents), &vmTypes); err != nil {
+			return "", err
+		}
+		rendered[spot] = vmTypes
+	}
+
+	var poolVMTypes []interface{}
+	for _, pool := range pools {
+		vmType, err := findVMType(rendered[pool.Spot].VMTypes, "concourse-"+pool.Size)
+		if err != nil {
+			return "", fmt.Errorf("worker pool %s: [%v]", pool.Name, err)
+		}
+		poolVMTypes = append(poolVMTypes, map[interface{}]interface{}{
+			"name":             WorkerPoolVMTypeName(pool.Name),
+			"cloud_properties": vmType["cloud_properties"],
+		})
+	}
+
+	for i, item := range cc {
+		if item.Key == "vm_types" {
+			cc[i].Value = append(item.Value.([]interface{}), poolVMTypes...)
+		}
+	}
+
+	out, err := yaml.Marshal(cc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func findVMType(vmTypes []map[interface{}]interface{}, name string) (map[interface{}]interface{}, error) {
+	for _, vmType := range vmTypes {
+		if vmType["name"] == name {
+			return vmType, nil
+		}
+	}
+	return nil, fmt.Errorf("no vm_type named %s in the cloud config", name)
+}
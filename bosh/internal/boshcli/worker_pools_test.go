@@ -0,0 +1,62 @@
+package boshcli
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestAWSEnvironment_ConfigureDirectorCloudConfigWithWorkerPools(t *testing.T) {
+	e := AWSEnvironment{
+		WorkerType: "m4",
+		WorkerPools: []WorkerPoolVMType{
+			{Name: "deploy", Size: "large", Spot: false},
+			{Name: "builds", Size: "2xlarge", Spot: true},
+		},
+	}
+
+	got, err := e.ConfigureDirectorCloudConfig()
+	if err != nil {
+		t.Fatalf("ConfigureDirectorCloudConfig() error = %v", err)
+	}
+
+	var cc cloudConfigVMTypes
+	if err = yaml.Unmarshal([]byte(got), &cc); err != nil {
+		t.Fatalf("rendered cloud config is not valid YAML: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		spot         bool
+	}{
+		{name: "concourse-pool-deploy", instanceType: "m4.large", spot: false},
+		{name: "concourse-pool-builds", instanceType: "m4.2xlarge", spot: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vmType, err := findVMType(cc.VMTypes, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			properties := vmType["cloud_properties"].(map[interface{}]interface{})
+			if properties["instance_type"] != tt.instanceType {
+				t.Errorf("instance_type = %v, want %v", properties["instance_type"], tt.instanceType)
+			}
+			if _, spot := properties["spot_bid_price"]; spot != tt.spot {
+				t.Errorf("spot_bid_price set = %v, want %v", spot, tt.spot)
+			}
+		})
+	}
+}
+
+func TestAddWorkerPoolVMTypes_UnknownSize(t *testing.T) {
+	e := GCPEnvironment{
+		WorkerPools: []WorkerPoolVMType{{Name: "huge", Size: "24xlarge"}},
+	}
+
+	_, err := e.ConfigureDirectorCloudConfig()
+	if err == nil || err.Error() != "worker pool huge: [no vm_type named concourse-24xlarge in the cloud config]" {
+		t.Errorf("ConfigureDirectorCloudConfig() error = %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package bosh
+
+import (
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+)
+
+const nodeExporterVersion = "4.2.0"
+const nodeExporterURL = "https://bosh.io/d/github.com/bosh-prometheus/node-exporter-boshrelease?v=" + nodeExporterVersion
+
+// nodeExporterSHA1 pins nodeExporterVersion for the director and the mirror command, wherever the
+// release is downloaded from. Until it is set from bosh.io the release is not verified
+const nodeExporterSHA1 = ""
+
+// metricsOpsFiles adds the vars for the configured metrics backend to vmap
+// and returns the --ops-file flags that enable it. InfluxDB and Grafana need none
+func metricsOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}) ([]string, error) {
+	var flagFiles []string
+
+	if c.IsPrometheusMetrics() {
+		vmap["prometheus_port"] = config.PrometheusPort
+		vmap["node_exporter_port"] = config.NodeExporterPort
+		url, err := mirror.URL(c.GetArtefactMirror(), nodeExporterURL)
+		if err != nil {
+			return nil, err
+		}
+		vmap["node_exporter_version"] = nodeExporterVersion
+		vmap["node_exporter_url"] = url
+		vmap["node_exporter_sha1"] = nodeExporterSHA1
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concoursePrometheusFilename))
+	}
+	if c.GetDisableGrafana() {
+		flagFiles = append(flagFiles, "--ops-file", workingdir.PathInWorkingDir(concourseNoGrafanaFilename))
+	}
+
+	return flagFiles, nil
+}
@@ -0,0 +1,65 @@
+package bosh
+
+import (
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"gopkg.in/yaml.v2"
+)
+
+// directorProxyOps returns ops for bosh create-env that set the director's env.http_proxy, env.https_proxy
+// and env.no_proxy, so that it downloads releases and stemcells through the proxy
+func directorProxyOps(c config.ConfigView) (string, error) {
+	p := c.GetProxy()
+	if !p.IsSet() {
+		return "", nil
+	}
+
+	var ops []opsFileEntry
+	set := func(property, value string) {
+		if value != "" {
+			ops = append(ops, opsFileEntry{Type: "replace", Path: "/instance_groups/name=bosh/properties/env?/" + property + "?", Value: value})
+		}
+	}
+	set("http_proxy", p.HTTPProxy)
+	set("https_proxy", p.HTTPSProxy)
+	set("no_proxy", strings.Join(p.NoProxy, ","))
+
+	contents, err := yaml.Marshal(ops)
+	return string(contents), err
+}
+
+// proxyOpsFiles writes an ops file that sets the proxy properties of the worker job, so that containers
+// reach resources through the proxy, and returns the --ops-file flag for it
+func proxyOpsFiles(c config.ConfigView, workingdir workingdir.IClient) ([]string, error) {
+	p := c.GetProxy()
+	if !p.IsSet() {
+		return nil, nil
+	}
+
+	var ops []opsFileEntry
+	set := func(property string, value interface{}) {
+		ops = append(ops, opsFileEntry{Type: "replace", Path: "/instance_groups/name=worker/jobs/name=worker/properties/" + property + "?", Value: value})
+	}
+	if p.HTTPProxy != "" {
+		set("http_proxy_url", p.HTTPProxy)
+	}
+	if p.HTTPSProxy != "" {
+		set("https_proxy_url", p.HTTPSProxy)
+	}
+	if len(p.NoProxy) > 0 {
+		set("no_proxy", p.NoProxy)
+	}
+
+	contents, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(proxyFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
@@ -0,0 +1,112 @@
+package bosh
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir/workingdirfakes"
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+func Test_directorProxyOps(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy config.Proxy
+		want  string
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:  "every setting",
+			proxy: config.Proxy{HTTPProxy: "http://proxy.example.com:3128", HTTPSProxy: "http://proxy.example.com:3129", NoProxy: []string{"10.0.0.0/16", ".internal.example.com"}},
+			want: `- type: replace
+  path: /instance_groups/name=bosh/properties/env?/http_proxy?
+  value: http://proxy.example.com:3128
+- type: replace
+  path: /instance_groups/name=bosh/properties/env?/https_proxy?
+  value: http://proxy.example.com:3129
+- type: replace
+  path: /instance_groups/name=bosh/properties/env?/no_proxy?
+  value: 10.0.0.0/16,.internal.example.com
+`,
+		},
+		{
+			name:  "HTTPS proxy only",
+			proxy: config.Proxy{HTTPSProxy: "http://proxy.example.com:3128"},
+			want: `- type: replace
+  path: /instance_groups/name=bosh/properties/env?/https_proxy?
+  value: http://proxy.example.com:3128
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := directorProxyOps(config.Config{Proxy: tt.proxy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("directorProxyOps() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_proxyOpsFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy config.Proxy
+		want  string
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:  "every setting",
+			proxy: config.Proxy{HTTPProxy: "http://proxy.example.com:3128", HTTPSProxy: "http://proxy.example.com:3129", NoProxy: []string{"10.0.0.0/16", ".internal.example.com"}},
+			want: `- type: replace
+  path: /instance_groups/name=worker/jobs/name=worker/properties/http_proxy_url?
+  value: http://proxy.example.com:3128
+- type: replace
+  path: /instance_groups/name=worker/jobs/name=worker/properties/https_proxy_url?
+  value: http://proxy.example.com:3129
+- type: replace
+  path: /instance_groups/name=worker/jobs/name=worker/properties/no_proxy?
+  value:
+  - 10.0.0.0/16
+  - .internal.example.com
+`,
+		},
+		{
+			name:  "HTTP proxy only",
+			proxy: config.Proxy{HTTPProxy: "http://proxy.example.com:3128"},
+			want: `- type: replace
+  path: /instance_groups/name=worker/jobs/name=worker/properties/http_proxy_url?
+  value: http://proxy.example.com:3128
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingdir := &workingdirfakes.FakeIClient{}
+			workingdir.SaveFileToWorkingDirReturns("/working/"+proxyFilename, nil)
+			flags, err := proxyOpsFiles(config.Config{Proxy: tt.proxy}, workingdir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if flags != nil || workingdir.SaveFileToWorkingDirCallCount() != 0 {
+					t.Errorf("proxyOpsFiles() = %v, want no ops file without a proxy", flags)
+				}
+				return
+			}
+			filename, contents := workingdir.SaveFileToWorkingDirArgsForCall(0)
+			if filename != proxyFilename || string(contents) != tt.want {
+				t.Errorf("proxyOpsFiles() wrote %s:\n%s\nwant:\n%s", filename, contents, tt.want)
+			}
+			if len(flags) != 2 || flags[0] != "--ops-file" || flags[1] != "/working/"+proxyFilename {
+				t.Errorf("proxyOpsFiles() = %v, want the --ops-file flag for the file it wrote", flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package bosh
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+)
+
+// runtimeConfigName names the runtime config control-tower manages, leaving any others on the director alone
+const runtimeConfigName = "control-tower-addons"
+
+// updateRuntimeConfig uploads the addon releases of the configured runtime config and applies it to the
+// director, or deletes it if there is none, so that the next deploy adds or removes the addons on every VM
+func updateRuntimeConfig(c config.ConfigView, boshCLI boshcli.ICLI, workingdir workingdir.IClient, directorIP string, stdout io.Writer) error {
+	run := func(action string, flags ...string) error {
+		return boshCLI.RunAuthenticatedCommand(action, directorIP, c.GetDirectorPassword(), c.GetDirectorCACert(), false, stdout, flags...)
+	}
+
+	if c.GetRuntimeConfig() == "" {
+		return run("delete-config", "--type", "runtime", "--name", runtimeConfigName)
+	}
+
+	releases, err := config.RuntimeConfigReleases(c.GetRuntimeConfig())
+	if err != nil {
+		return err
+	}
+	for _, release := range releases {
+		url, err := mirror.URL(c.GetArtefactMirror(), release.URL)
+		if err != nil {
+			return err
+		}
+		if err = run("upload-release", "--name", release.Name, "--version", release.Version, "--sha1", release.SHA1, url); err != nil {
+			return fmt.Errorf("failed to upload addon release %s: [%v]", release.Name, err)
+		}
+	}
+
+	path, err := workingdir.SaveFileToWorkingDir(runtimeConfigFilename, []byte(c.GetRuntimeConfig()))
+	if err != nil {
+		return err
+	}
+	return run("update-runtime-config", "--name", runtimeConfigName, path)
+}
@@ -0,0 +1,79 @@
+package bosh
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+	"github.com/EngineerBetter/control-tower/util/yaml"
+)
+
+const syslogReleaseVersion = "11.7.0"
+const syslogReleaseURL = "https://bosh.io/d/github.com/cloudfoundry/syslog-release?v=" + syslogReleaseVersion
+
+// syslogOpsFiles adds the vars for forwarding logs to syslog to vmap and returns the
+// --ops-file flags that add the forwarder to every instance group as an addon
+func syslogOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}) ([]string, error) {
+	if !c.IsSyslogSet() {
+		return nil, nil
+	}
+
+	vars, err := syslogVars(c)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range vars {
+		vmap[k] = v
+	}
+
+	return []string{"--ops-file", workingdir.PathInWorkingDir(concourseSyslogFilename)}, nil
+}
+
+// directorSyslogOps returns ops that add the forwarder to the director. They are interpolated
+// up front because create-env only knows about the director's own vars
+func directorSyslogOps(c config.ConfigView) (string, error) {
+	if !c.IsSyslogSet() {
+		return "", nil
+	}
+
+	vars, err := syslogVars(c)
+	if err != nil {
+		return "", err
+	}
+
+	return yaml.Interpolate(string(directorSyslog), "", vars)
+}
+
+func syslogVars(c config.ConfigView) (map[string]interface{}, error) {
+	host, portString, err := net.SplitHostPort(c.GetSyslogAddress())
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseURL, err := mirror.URL(c.GetArtefactMirror(), syslogReleaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	permittedPeer := c.GetSyslogPermittedPeer()
+	if permittedPeer == "" {
+		permittedPeer = host
+	}
+
+	return map[string]interface{}{
+		"syslog_release_version": syslogReleaseVersion,
+		"syslog_release_url":     releaseURL,
+		"syslog_host":            host,
+		"syslog_port":            port,
+		"syslog_transport":       c.GetSyslogTransport(),
+		"syslog_tls_enabled":     c.GetSyslogCACert() != "",
+		"syslog_ca_cert":         c.GetSyslogCACert(),
+		"syslog_permitted_peer":  permittedPeer,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package bosh
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir/workingdirfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	utilyaml "github.com/EngineerBetter/control-tower/util/yaml"
+	"gopkg.in/yaml.v2"
+)
+
+const syslogTestManifest = `
+releases: []
+instance_groups:
+- name: bosh
+  jobs: []
+- name: web
+  jobs: []
+`
+
+type syslogTestProperties struct {
+	Address       string
+	Port          int
+	Transport     string
+	TLSEnabled    bool   `yaml:"tls_enabled"`
+	PermittedPeer string `yaml:"permitted_peer"`
+}
+
+type syslogTestJob struct {
+	Name       string
+	Properties struct {
+		Syslog syslogTestProperties
+	}
+}
+
+type syslogTestRendered struct {
+	Releases []struct {
+		Name    string
+		Version string
+		URL     string
+	}
+	InstanceGroups []struct {
+		Name string
+		Jobs []syslogTestJob
+	} `yaml:"instance_groups"`
+	Addons []struct {
+		Name string
+		Jobs []syslogTestJob
+	}
+}
+
+func renderSyslogTestManifest(t *testing.T, ops string, vars map[string]interface{}) syslogTestRendered {
+	manifest, err := utilyaml.Interpolate(syslogTestManifest, ops, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rendered syslogTestRendered
+	if err := yaml.Unmarshal([]byte(manifest), &rendered); err != nil {
+		t.Fatal(err)
+	}
+	if len(rendered.Releases) != 1 || rendered.Releases[0].Name != "syslog" || rendered.Releases[0].Version != syslogReleaseVersion || rendered.Releases[0].URL != syslogReleaseURL {
+		t.Errorf("rendered releases %+v, want syslog %s from %s", rendered.Releases, syslogReleaseVersion, syslogReleaseURL)
+	}
+	return rendered
+}
+
+func Test_directorSyslogOps(t *testing.T) {
+	ops, err := directorSyslogOps(config.Config{SyslogAddress: "logs.example.com:6514", SyslogTransport: "tcp", SyslogCACert: "----CA----"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered := renderSyslogTestManifest(t, ops, nil)
+
+	bosh := rendered.InstanceGroups[0]
+	if bosh.Name != "bosh" || len(bosh.Jobs) != 1 || bosh.Jobs[0].Name != "syslog_forwarder" {
+		t.Fatalf("directorSyslogOps() rendered the bosh instance group %+v, want the syslog_forwarder job", bosh)
+	}
+	syslog := bosh.Jobs[0].Properties.Syslog
+	if syslog.Address != "logs.example.com" || syslog.Port != 6514 || syslog.Transport != "tcp" || !syslog.TLSEnabled || syslog.PermittedPeer != "logs.example.com" {
+		t.Errorf("directorSyslogOps() rendered the forwarder properties %+v", syslog)
+	}
+}
+
+func Test_directorSyslogOps_NotSet(t *testing.T) {
+	ops, err := directorSyslogOps(config.Config{})
+	if err != nil || ops != "" {
+		t.Errorf("directorSyslogOps() = %v, %v, want no ops without a syslog address", ops, err)
+	}
+}
+
+func Test_syslogOpsFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.Config
+		want   syslogTestProperties
+	}{
+		{
+			name:   "default transport",
+			config: config.Config{SyslogAddress: "logs.example.com:514"},
+			want:   syslogTestProperties{Address: "logs.example.com", Port: 514, Transport: "tcp", PermittedPeer: "logs.example.com"},
+		},
+		{
+			name:   "UDP",
+			config: config.Config{SyslogAddress: "10.0.0.5:514", SyslogTransport: "udp"},
+			want:   syslogTestProperties{Address: "10.0.0.5", Port: 514, Transport: "udp", PermittedPeer: "10.0.0.5"},
+		},
+		{
+			name:   "RELP with TLS to a named peer",
+			config: config.Config{SyslogAddress: "10.0.0.5:2514", SyslogTransport: "relp", SyslogCACert: "----CA----", SyslogPermittedPeer: "*.logs.example.com"},
+			want:   syslogTestProperties{Address: "10.0.0.5", Port: 2514, Transport: "relp", TLSEnabled: true, PermittedPeer: "*.logs.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingdir := &workingdirfakes.FakeIClient{}
+			workingdir.PathInWorkingDirReturns("/working/" + concourseSyslogFilename)
+			vmap := map[string]interface{}{}
+			flags, err := syslogOpsFiles(tt.config, workingdir, vmap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(flags) != 2 || flags[1] != "/working/"+concourseSyslogFilename {
+				t.Errorf("syslogOpsFiles() = %v, want the --ops-file flag for %s", flags, concourseSyslogFilename)
+			}
+
+			rendered := renderSyslogTestManifest(t, string(concourseSyslog), vmap)
+			if len(rendered.Addons) != 1 || len(rendered.Addons[0].Jobs) != 1 || rendered.Addons[0].Jobs[0].Name != "syslog_forwarder" {
+				t.Fatalf("syslogOpsFiles() rendered the addons %+v, want the syslog_forwarder addon", rendered.Addons)
+			}
+			if got := rendered.Addons[0].Jobs[0].Properties.Syslog; got != tt.want {
+				t.Errorf("syslogOpsFiles() rendered the forwarder properties %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syslogOpsFiles_NotSet(t *testing.T) {
+	vmap := map[string]interface{}{}
+	flags, err := syslogOpsFiles(config.Config{}, &workingdirfakes.FakeIClient{}, vmap)
+	if err != nil || flags != nil || len(vmap) != 0 {
+		t.Errorf("syslogOpsFiles() = %v, %v with vars %v, want nothing without a syslog address", flags, err, vmap)
+	}
+}
@@ -0,0 +1,53 @@
+package bosh
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/apparentlymart/go-cidr/cidr"
+)
+
+// webStaticIPHosts returns the host number within the public CIDR of each web instance's static IP
+func webStaticIPHosts(firstHost, webCount int) []int {
+	hosts := []int{firstHost}
+	for i := 1; i < webCount; i++ {
+		hosts = append(hosts, firstHost+i)
+	}
+	return hosts
+}
+
+// webOpsFiles adds the vars for running several web instances behind the IAAS load balancer to vmap
+// and returns the --ops-file flags that scale the web instance group out. The first web instance
+// keeps the ATC public IP and terraform creates one more for each additional instance
+func webOpsFiles(c config.ConfigView, workingdir workingdir.IClient, vmap map[string]interface{}, firstHost int, atcPublicIP, additionalPublicIPs string) ([]string, error) {
+	if !c.IsWebLoadBalanced() {
+		return nil, nil
+	}
+
+	publicIPs := append([]string{atcPublicIP}, strings.Split(additionalPublicIPs, ",")...)
+	if additionalPublicIPs == "" || len(publicIPs) != c.GetConcourseWebCount() {
+		return nil, fmt.Errorf("expected %d web public IPs from terraform, got [%s]", c.GetConcourseWebCount(), strings.Join(publicIPs, ","))
+	}
+
+	_, pubCIDR, err := net.ParseCIDR(c.GetPublicCIDR())
+	if err != nil {
+		return nil, err
+	}
+	var staticIPs []string
+	for _, host := range webStaticIPHosts(firstHost, c.GetConcourseWebCount()) {
+		ip, err := cidr.Host(pubCIDR, host)
+		if err != nil {
+			return nil, err
+		}
+		staticIPs = append(staticIPs, ip.String())
+	}
+
+	vmap["web_count"] = c.GetConcourseWebCount()
+	vmap["web_static_ips"] = staticIPs
+	vmap["web_public_ips"] = publicIPs
+
+	return []string{"--ops-file", workingdir.PathInWorkingDir(concourseWebHAFilename)}, nil
+}
@@ -0,0 +1,134 @@
+package bosh
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir/workingdirfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	utilyaml "github.com/EngineerBetter/control-tower/util/yaml"
+	"gopkg.in/yaml.v2"
+)
+
+const webTestManifest = `
+instance_groups:
+- name: web
+  instances: 1
+  networks:
+  - name: public
+    static_ips: [10.0.0.7]
+  - name: vip
+    static_ips: [203.0.113.1]
+`
+
+func TestWebStaticIPHosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstHost int
+		webCount  int
+		want      []int
+	}{
+		{name: "a single web instance keeps the first host", firstHost: 7, webCount: 1, want: []int{7}},
+		{name: "additional web instances take the following hosts", firstHost: 8, webCount: 3, want: []int{8, 9, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webStaticIPHosts(tt.firstHost, tt.webCount); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webStaticIPHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebOpsFiles(t *testing.T) {
+	tests := []struct {
+		name                string
+		webCount            int
+		additionalPublicIPs string
+		wantStaticIPs       []string
+		wantPublicIPs       []string
+		expectedErr         string
+	}{
+		{
+			name:     "a single web instance needs no ops file",
+			webCount: 1,
+		},
+		{
+			name:                "several web instances get a static and a public IP each",
+			webCount:            3,
+			additionalPublicIPs: "203.0.113.2,203.0.113.3",
+			wantStaticIPs:       []string{"10.0.0.7", "10.0.0.8", "10.0.0.9"},
+			wantPublicIPs:       []string{"203.0.113.1", "203.0.113.2", "203.0.113.3"},
+		},
+		{
+			name:                "terraform must give a public IP for each additional instance",
+			webCount:            3,
+			additionalPublicIPs: "203.0.113.2",
+			expectedErr:         "expected 3 web public IPs from terraform, got [203.0.113.1,203.0.113.2]",
+		},
+		{
+			name:        "terraform must give additional public IPs",
+			webCount:    2,
+			expectedErr: "expected 2 web public IPs from terraform, got [203.0.113.1,]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingdir := &workingdirfakes.FakeIClient{}
+			workingdir.PathInWorkingDirReturns("/working/" + concourseWebHAFilename)
+			vmap := map[string]interface{}{}
+			conf := config.Config{ConcourseWebCount: tt.webCount, PublicCIDR: "10.0.0.0/24"}
+
+			flags, err := webOpsFiles(conf, workingdir, vmap, 7, "203.0.113.1", tt.additionalPublicIPs)
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("webOpsFiles() error = %v, want %s", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantStaticIPs == nil {
+				if flags != nil || len(vmap) != 0 {
+					t.Errorf("webOpsFiles() = %v with vars %v, want nothing for a single web instance", flags, vmap)
+				}
+				return
+			}
+			if len(flags) != 2 || flags[0] != "--ops-file" || flags[1] != "/working/"+concourseWebHAFilename {
+				t.Errorf("webOpsFiles() = %v, want the --ops-file flag for %s", flags, concourseWebHAFilename)
+			}
+
+			manifest, err := utilyaml.Interpolate(webTestManifest, string(concourseWebHA), vmap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rendered struct {
+				InstanceGroups []struct {
+					Instances int
+					Networks  []struct {
+						Name      string
+						StaticIPs []string `yaml:"static_ips"`
+					}
+				} `yaml:"instance_groups"`
+			}
+			if err = yaml.Unmarshal([]byte(manifest), &rendered); err != nil {
+				t.Fatal(err)
+			}
+			web := rendered.InstanceGroups[0]
+			if web.Instances != tt.webCount {
+				t.Errorf("rendered %d web instances, want %d", web.Instances, tt.webCount)
+			}
+			if got := web.Networks[0].StaticIPs; !reflect.DeepEqual(got, tt.wantStaticIPs) {
+				t.Errorf("rendered static IPs %v, want %v", got, tt.wantStaticIPs)
+			}
+			if got := web.Networks[1].StaticIPs; !reflect.DeepEqual(got, tt.wantPublicIPs) {
+				t.Errorf("rendered public IPs %v, want %v", got, tt.wantPublicIPs)
+			}
+			if !strings.Contains(manifest, "max_in_flight: 1") {
+				t.Errorf("rendered manifest does not update web instances one at a time:\n%s", manifest)
+			}
+		})
+	}
+}
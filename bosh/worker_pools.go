@@ -0,0 +1,133 @@
+package bosh
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
+	"github.com/EngineerBetter/control-tower/config"
+	utilyaml "github.com/EngineerBetter/control-tower/util/yaml"
+	"gopkg.in/yaml.v2"
+)
+
+// workerPoolInstanceGroupPrefix is prepended to a worker pool's name to name its instance group
+const workerPoolInstanceGroupPrefix = "worker-"
+
+// workerPoolVMTypes returns the vm_types the cloud config needs for the configured worker pools
+func workerPoolVMTypes(c config.ConfigView) []boshcli.WorkerPoolVMType {
+	var vmTypes []boshcli.WorkerPoolVMType
+	for _, pool := range c.GetWorkerPools() {
+		vmTypes = append(vmTypes, boshcli.WorkerPoolVMType{
+			Name: pool.Name,
+			Size: pool.Size,
+			Spot: pool.IsSpot(),
+		})
+	}
+	return vmTypes
+}
+
+type manifestInstanceGroups struct {
+	InstanceGroups []map[interface{}]interface{} `yaml:"instance_groups"`
+}
+
+// workerPoolsOpsFiles writes an ops file that adds an instance group for each worker pool, and returns
+// the --ops-file flag for it. Each instance group is a copy of the worker instance group once the
+// manifest and ops files in flagFiles have been applied, so pools get the same jobs as the default workers
+func workerPoolsOpsFiles(c config.ConfigView, workingdir workingdir.IClient, flagFiles []string) ([]string, error) {
+	if len(c.GetWorkerPools()) == 0 {
+		return nil, nil
+	}
+
+	manifest, err := ioutil.ReadFile(flagFiles[0])
+	if err != nil {
+		return nil, err
+	}
+	var ops []string
+	for i := 1; i < len(flagFiles)-1; i++ {
+		if flagFiles[i] != "--ops-file" {
+			continue
+		}
+		contents, err1 := ioutil.ReadFile(flagFiles[i+1])
+		if err1 != nil {
+			return nil, err1
+		}
+		ops = append(ops, strings.TrimSpace(string(contents)))
+	}
+	// Vars are left as they are, to be interpolated by bosh deploy
+	interpolated, err := utilyaml.Interpolate(string(manifest), strings.Join(ops, "\n"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifestInstanceGroups
+	if err = yaml.Unmarshal([]byte(interpolated), &m); err != nil {
+		return nil, err
+	}
+	var worker []byte
+	for _, ig := range m.InstanceGroups {
+		if ig["name"] == "worker" {
+			worker, err = yaml.Marshal(ig)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if worker == nil {
+		return nil, errors.New("manifest has no worker instance group to base worker pools on")
+	}
+
+	var entries []opsFileEntry
+	for _, pool := range c.GetWorkerPools() {
+		var ig map[interface{}]interface{}
+		if err = yaml.Unmarshal(worker, &ig); err != nil {
+			return nil, err
+		}
+		ig["name"] = workerPoolInstanceGroupPrefix + pool.Name
+		ig["instances"] = pool.Count
+		ig["vm_type"] = boshcli.WorkerPoolVMTypeName(pool.Name)
+		setWorkerPoolJobProperties(ig, pool)
+
+		entries = append(entries, opsFileEntry{
+			Type:  "replace",
+			Path:  "/instance_groups/-",
+			Value: ig,
+		})
+	}
+
+	contents, err := yaml.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	path, err := workingdir.SaveFileToWorkingDir(concourseWorkerPoolsFilename, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"--ops-file", path}, nil
+}
+
+// setWorkerPoolJobProperties sets the Concourse worker tags and team of the pool on its worker job
+func setWorkerPoolJobProperties(ig map[interface{}]interface{}, pool config.WorkerPool) {
+	jobs, _ := ig["jobs"].([]interface{})
+	for _, j := range jobs {
+		job, ok := j.(map[interface{}]interface{})
+		if !ok || job["name"] != "worker" {
+			continue
+		}
+		properties, ok := job["properties"].(map[interface{}]interface{})
+		if !ok {
+			properties = map[interface{}]interface{}{}
+			job["properties"] = properties
+		}
+		delete(properties, "tags")
+		delete(properties, "team")
+		if len(pool.Tags) > 0 {
+			properties["tags"] = pool.Tags
+		}
+		if pool.Team != "" {
+			properties["team"] = pool.Team
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package certs
 
 import (
+	"net/http"
 	"os"
 
+	"github.com/EngineerBetter/control-tower/util/proxy"
 	"github.com/xenolf/lego/lego"
 )
 
@@ -16,6 +18,7 @@ func NewAcmeClient(u *User) (*lego.Client, error) {
 
 	c = lego.NewConfig(u)
 	c.CADirURL = acmeURL()
+	c.HTTPClient.Transport.(*http.Transport).Proxy = proxy.Func
 
 	cl, err := lego.NewClient(c)
 	if err != nil {
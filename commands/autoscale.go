@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var initialAutoscaleArgs maintain.Args
+
+var autoscaleFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialAutoscaleArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialAutoscaleArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialAutoscaleArgs.Namespace,
+	},
+}
+
+func autoscaleAction(c *cli.Context, autoscaleArgs maintain.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower autoscale <name>`")
+	}
+
+	client, err := buildMaintainClient(name, c.App.Version, autoscaleArgs, provider)
+	if err != nil {
+		return err
+	}
+	return client.Autoscale()
+}
+
+var autoscaleCmd = cli.Command{
+	Name:      "autoscale",
+	Usage:     "Resizes the workers of a deployment deployed with --autoscale-max-workers according to their load",
+	ArgsUsage: "<name>",
+	Flags:     autoscaleFlags,
+	Action: func(c *cli.Context) error {
+		autoscaleArgs, err := validateMaintainArgs(c, initialAutoscaleArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on autoscale: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(autoscaleArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on autoscale: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, autoscaleArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on autoscale: [%v]", err)
+		}
+		return autoscaleAction(c, autoscaleArgs, provider)
+	},
+}
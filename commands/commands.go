@@ -1,18 +1,41 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util"
+	"github.com/EngineerBetter/control-tower/util/mirror"
+	"github.com/EngineerBetter/control-tower/util/proxy"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
 // Commands is a list of all supported CLI commands
 var Commands = []cli.Command{
+	autoscaleCmd,
 	deployCmd,
 	destroyCmd,
 	infoCmd,
 	maintainCmd,
+	mirrorCmd,
+	scheduleCmd,
+	secretsCmd,
+	workersCmd,
 }
 
 var nonInteractive bool
+var allowUnverifiedBinaries bool
+var artefactMirror string
+var artefactMirrorIsSet bool
+var httpProxy string
+var httpProxyIsSet bool
+var httpsProxy string
+var httpsProxyIsSet bool
+var noProxy cli.StringSlice
+var noProxyIsSet bool
 
 // GlobalFlags are the global CLIflags
 var GlobalFlags = []cli.Flag{
@@ -22,9 +45,119 @@ var GlobalFlags = []cli.Flag{
 		Usage:       "Non interactive",
 		Destination: &nonInteractive,
 	},
+	cli.StringFlag{
+		Name:        "artefact-mirror",
+		EnvVar:      "ARTEFACT_MIRROR",
+		Usage:       "(optional) Download CLIs, releases, stemcells and terraform providers from a mirror populated by `control-tower mirror`, given as a URL or a local directory",
+		Destination: &artefactMirror,
+	},
+	cli.BoolFlag{
+		Name:        "allow-unverified-binaries",
+		EnvVar:      "ALLOW_UNVERIFIED_BINARIES",
+		Usage:       "(optional) Download the terraform, bosh and fly CLIs even if the versions file pins no SHA256 for them",
+		Destination: &allowUnverifiedBinaries,
+	},
+	cli.StringFlag{
+		Name:        "http-proxy",
+		Usage:       "(optional) Proxy for HTTP requests made by Control Tower, the BOSH director and Concourse workers",
+		Destination: &httpProxy,
+	},
+	cli.StringFlag{
+		Name:        "https-proxy",
+		Usage:       "(optional) Proxy for HTTPS requests made by Control Tower, the BOSH director and Concourse workers",
+		Destination: &httpsProxy,
+	},
+	cli.StringSliceFlag{
+		Name:  "no-proxy",
+		Usage: "(optional) Host, domain or address to reach without the proxy. Can be given multiple times",
+		Value: &noProxy,
+	},
+}
+
+// Before applies the proxy flags to the environment, so that Control Tower's own HTTP clients and the
+// terraform, bosh and fly subprocesses it runs all use the proxy, and records which global flags were given
+func Before(c *cli.Context) error {
+	proxy.UseInDefaultTransport()
+	util.AllowUnverifiedBinaries = allowUnverifiedBinaries
+
+	artefactMirrorIsSet = c.IsSet("artefact-mirror")
+	httpProxyIsSet = c.IsSet("http-proxy")
+	httpsProxyIsSet = c.IsSet("https-proxy")
+	noProxyIsSet = c.IsSet("no-proxy")
+
+	flags := config.Proxy{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    noProxy,
+	}
+	if err := flags.Validate(); err != nil {
+		return fmt.Errorf("failed to validate proxy flags: [%v]", err)
+	}
+	return setProxyEnvironment(flags)
+}
+
+// setProxyEnvironment puts p in the environment for subprocesses, and has Control Tower's own HTTP clients
+// read it again, as they may already have made requests
+func setProxyEnvironment(p config.Proxy) error {
+	for name, value := range p.Environment() {
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+	}
+	proxy.Reload()
+	return nil
 }
 
 // NonInteractiveModeEnabled returns true if --non-interactive true has been passed in
 func NonInteractiveModeEnabled() bool {
 	return nonInteractive
 }
+
+// chooseVersionFile returns the versions file of CLIs and director dependencies for the provider's IAAS,
+// with its download URLs moved to the artefact mirror if one is used
+func chooseVersionFile(provider iaas.Provider) ([]byte, error) {
+	versionFile, _ := provider.Choose(iaas.Choice{
+		AWS: resource.AWSVersionFile,
+		GCP: resource.GCPVersionFile,
+	}).([]byte)
+
+	return mirror.RewriteVersionFile(versionFile, artefactMirror)
+}
+
+// useSavedSettings falls back to the settings saved with an existing deployment for the global flags
+// that were not given on this run, so that later runs, including those of the self-update pipeline,
+// download from the same artefact mirror through the same proxy
+func useSavedSettings(configClient *config.Client) error {
+	if configClient.BucketError != nil || !configClient.BucketExists {
+		return nil
+	}
+	exists, err := configClient.ConfigExists()
+	if err != nil || !exists {
+		return err
+	}
+	conf, err := configClient.Load()
+	if err != nil {
+		return fmt.Errorf("error loading saved settings: [%v]", err)
+	}
+
+	return applySavedSettings(conf)
+}
+
+// applySavedSettings uses the artefact mirror and proxy saved in conf for the global flags that were not given
+func applySavedSettings(conf config.ConfigView) error {
+	if !artefactMirrorIsSet {
+		artefactMirror = conf.GetArtefactMirror()
+	}
+
+	var saved config.Proxy
+	if !httpProxyIsSet {
+		saved.HTTPProxy = conf.GetProxy().HTTPProxy
+	}
+	if !httpsProxyIsSet {
+		saved.HTTPSProxy = conf.GetProxy().HTTPSProxy
+	}
+	if !noProxyIsSet {
+		saved.NoProxy = conf.GetProxy().NoProxy
+	}
+	return setProxyEnvironment(saved)
+}
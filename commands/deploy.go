@@ -18,7 +18,6 @@ import (
 	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
 	"github.com/EngineerBetter/control-tower/util"
 
 	cli "gopkg.in/urfave/cli.v1"
@@ -74,6 +73,13 @@ var deployFlags = []cli.Flag{
 		Value:       "m4",
 		Destination: &initialDeployArgs.WorkerType,
 	},
+	cli.IntFlag{
+		Name:        "web-count",
+		Usage:       "(optional) Number of Concourse web instances to deploy. More than 1 places them behind a load balancer and requires --domain",
+		EnvVar:      "WEB_COUNT",
+		Value:       1,
+		Destination: &initialDeployArgs.WebCount,
+	},
 	cli.StringFlag{
 		Name:        "web-size",
 		Usage:       "(optional) Size of Concourse web node. Can be small, medium, large, xlarge, 2xlarge",
@@ -94,6 +100,12 @@ var deployFlags = []cli.Flag{
 		Hidden:      true,
 		Destination: &initialDeployArgs.SelfUpdate,
 	},
+	cli.BoolFlag{
+		Name:        "dry-run",
+		Usage:       "(optional) Show the changes a deploy would make to the Concourse deployment without making any. May only be used with an existing deployment",
+		EnvVar:      "DRY_RUN",
+		Destination: &initialDeployArgs.DryRun,
+	},
 	cli.BoolFlag{
 		Name:        "enable-global-resources",
 		Usage:       "(optional) Enables Concourse global resources. Can be true/false (default: false)",
@@ -138,6 +150,174 @@ var deployFlags = []cli.Flag{
 		EnvVar:      "GITHUB_AUTH_CLIENT_SECRET",
 		Destination: &initialDeployArgs.GithubAuthClientSecret,
 	},
+	cli.StringSliceFlag{
+		Name:   "main-team-github-orgs",
+		Usage:  "(optional) GitHub organisation whose members belong to the main team - Multiple orgs can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_GITHUB_ORGS",
+		Value:  &initialDeployArgs.MainTeamGithubOrgs,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-github-teams",
+		Usage:  "(optional) GitHub team, in the format org:team, whose members belong to the main team - Multiple teams can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_GITHUB_TEAMS",
+		Value:  &initialDeployArgs.MainTeamGithubTeams,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-github-users",
+		Usage:  "(optional) GitHub user who belongs to the main team - Multiple users can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_GITHUB_USERS",
+		Value:  &initialDeployArgs.MainTeamGithubUsers,
+	},
+	cli.StringFlag{
+		Name:        "oidc-auth-issuer",
+		Usage:       "(optional) Issuer URL of a generic OIDC provider - Used for OIDC Auth",
+		EnvVar:      "OIDC_AUTH_ISSUER",
+		Destination: &initialDeployArgs.OIDCAuthIssuer,
+	},
+	cli.StringFlag{
+		Name:        "oidc-auth-client-id",
+		Usage:       "(optional) Client ID registered with the OIDC provider - Used for OIDC Auth",
+		EnvVar:      "OIDC_AUTH_CLIENT_ID",
+		Destination: &initialDeployArgs.OIDCAuthClientID,
+	},
+	cli.StringFlag{
+		Name:        "oidc-auth-client-secret",
+		Usage:       "(optional) Client Secret registered with the OIDC provider - Used for OIDC Auth",
+		EnvVar:      "OIDC_AUTH_CLIENT_SECRET",
+		Destination: &initialDeployArgs.OIDCAuthClientSecret,
+	},
+	cli.StringSliceFlag{
+		Name:   "oidc-auth-scopes",
+		Usage:  "(optional) Scope to request from the OIDC provider - Multiple scopes can be requested with multiple uses of this flag (default: openid, profile, email, groups)",
+		EnvVar: "OIDC_AUTH_SCOPES",
+		Value:  &initialDeployArgs.OIDCAuthScopes,
+	},
+	cli.StringFlag{
+		Name:        "oidc-auth-groups-claim",
+		Usage:       "(optional) Name of the OIDC claim holding the user's groups (default: groups)",
+		EnvVar:      "OIDC_AUTH_GROUPS_CLAIM",
+		Destination: &initialDeployArgs.OIDCAuthGroupsClaim,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-oidc-groups",
+		Usage:  "(optional) OIDC group whose members belong to the main team - Multiple groups can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_OIDC_GROUPS",
+		Value:  &initialDeployArgs.MainTeamOIDCGroups,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-oidc-users",
+		Usage:  "(optional) OIDC user who belongs to the main team - Multiple users can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_OIDC_USERS",
+		Value:  &initialDeployArgs.MainTeamOIDCUsers,
+	},
+	cli.StringFlag{
+		Name:        "gitlab-auth-client-id",
+		Usage:       "(optional) Application ID for a GitLab OAuth application - Used for GitLab Auth",
+		EnvVar:      "GITLAB_AUTH_CLIENT_ID",
+		Destination: &initialDeployArgs.GitLabAuthClientID,
+	},
+	cli.StringFlag{
+		Name:        "gitlab-auth-client-secret",
+		Usage:       "(optional) Secret for a GitLab OAuth application - Used for GitLab Auth",
+		EnvVar:      "GITLAB_AUTH_CLIENT_SECRET",
+		Destination: &initialDeployArgs.GitLabAuthClientSecret,
+	},
+	cli.StringFlag{
+		Name:        "gitlab-auth-host",
+		Usage:       "(optional) URL of a self-hosted GitLab instance (default: https://gitlab.com)",
+		EnvVar:      "GITLAB_AUTH_HOST",
+		Destination: &initialDeployArgs.GitLabAuthHost,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-gitlab-groups",
+		Usage:  "(optional) GitLab group whose members belong to the main team - Multiple groups can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_GITLAB_GROUPS",
+		Value:  &initialDeployArgs.MainTeamGitLabGroups,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-gitlab-users",
+		Usage:  "(optional) GitLab user who belongs to the main team - Multiple users can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_GITLAB_USERS",
+		Value:  &initialDeployArgs.MainTeamGitLabUsers,
+	},
+	cli.StringFlag{
+		Name:        "bitbucket-cloud-auth-client-id",
+		Usage:       "(optional) Key for a Bitbucket Cloud OAuth consumer - Used for Bitbucket Cloud Auth",
+		EnvVar:      "BITBUCKET_CLOUD_AUTH_CLIENT_ID",
+		Destination: &initialDeployArgs.BitbucketCloudAuthClientID,
+	},
+	cli.StringFlag{
+		Name:        "bitbucket-cloud-auth-client-secret",
+		Usage:       "(optional) Secret for a Bitbucket Cloud OAuth consumer - Used for Bitbucket Cloud Auth",
+		EnvVar:      "BITBUCKET_CLOUD_AUTH_CLIENT_SECRET",
+		Destination: &initialDeployArgs.BitbucketCloudAuthClientSecret,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-bitbucket-cloud-teams",
+		Usage:  "(optional) Bitbucket Cloud team whose members belong to the main team - Multiple teams can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_BITBUCKET_CLOUD_TEAMS",
+		Value:  &initialDeployArgs.MainTeamBitbucketCloudTeams,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-bitbucket-cloud-users",
+		Usage:  "(optional) Bitbucket Cloud user who belongs to the main team - Multiple users can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_BITBUCKET_CLOUD_USERS",
+		Value:  &initialDeployArgs.MainTeamBitbucketCloudUsers,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-host",
+		Usage:       "(optional) host:port of the LDAP server - Used for LDAP Auth",
+		EnvVar:      "LDAP_AUTH_HOST",
+		Destination: &initialDeployArgs.LDAPAuthHost,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-bind-dn",
+		Usage:       "(optional) DN to bind to the LDAP server with - Used for LDAP Auth",
+		EnvVar:      "LDAP_AUTH_BIND_DN",
+		Destination: &initialDeployArgs.LDAPAuthBindDN,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-bind-password",
+		Usage:       "(optional) Password for the LDAP bind DN - Used for LDAP Auth",
+		EnvVar:      "LDAP_AUTH_BIND_PASSWORD",
+		Destination: &initialDeployArgs.LDAPAuthBindPassword,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-user-search-base-dn",
+		Usage:       "(optional) Base DN to search for users under - Used for LDAP Auth",
+		EnvVar:      "LDAP_AUTH_USER_SEARCH_BASE_DN",
+		Destination: &initialDeployArgs.LDAPAuthUserSearchBaseDN,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-user-search-username",
+		Usage:       "(optional) LDAP attribute matched against the username at login (default: uid)",
+		EnvVar:      "LDAP_AUTH_USER_SEARCH_USERNAME",
+		Destination: &initialDeployArgs.LDAPAuthUserSearchUsername,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-group-search-base-dn",
+		Usage:       "(optional) Base DN to search for groups under",
+		EnvVar:      "LDAP_AUTH_GROUP_SEARCH_BASE_DN",
+		Destination: &initialDeployArgs.LDAPAuthGroupSearchBaseDN,
+	},
+	cli.StringFlag{
+		Name:        "ldap-auth-ca-cert",
+		Usage:       "(optional) CA certificate used to verify the LDAP server",
+		EnvVar:      "LDAP_AUTH_CA_CERT",
+		Destination: &initialDeployArgs.LDAPAuthCACert,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-ldap-groups",
+		Usage:  "(optional) LDAP group whose members belong to the main team - Multiple groups can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_LDAP_GROUPS",
+		Value:  &initialDeployArgs.MainTeamLDAPGroups,
+	},
+	cli.StringSliceFlag{
+		Name:   "main-team-ldap-users",
+		Usage:  "(optional) LDAP user who belongs to the main team - Multiple users can be given with multiple uses of this flag",
+		EnvVar: "MAIN_TEAM_LDAP_USERS",
+		Value:  &initialDeployArgs.MainTeamLDAPUsers,
+	},
 	cli.StringSliceFlag{
 		Name:  "add-tag",
 		Usage: "(optional) Key=Value pair to tag EC2 instances with - Multiple tags can be applied with multiple uses of this flag",
@@ -185,6 +365,444 @@ var deployFlags = []cli.Flag{
 		EnvVar:      "RDS_SUBNET_RANGE2",
 		Destination: &initialDeployArgs.RDS2CIDR,
 	},
+	cli.StringFlag{
+		Name:        "stemcell-os",
+		Usage:       "(optional) Stemcell OS line for Concourse VMs. Can be xenial, bionic or jammy. Changing it recreates every Concourse VM",
+		EnvVar:      "STEMCELL_OS",
+		Value:       "xenial",
+		Destination: &initialDeployArgs.StemcellOS,
+	},
+	cli.StringFlag{
+		Name:        "concourse-version",
+		Usage:       "(optional) Concourse version to deploy instead of the one this version of control-tower pins. Give an empty value to go back to the pinned version",
+		EnvVar:      "CONCOURSE_VERSION",
+		Destination: &initialDeployArgs.ConcourseVersion,
+	},
+	cli.StringFlag{
+		Name:        "teams-file",
+		Usage:       "(optional) Path to a YAML file describing the Concourse teams, other than main, to create. Teams missing from the file are destroyed",
+		EnvVar:      "TEAMS_FILE",
+		Destination: &initialDeployArgs.TeamsFile,
+	},
+	cli.BoolFlag{
+		Name:        "unmanage-teams",
+		Usage:       "(optional) Stop applying the stored teams file. Teams already in Concourse are left as they are",
+		EnvVar:      "UNMANAGE_TEAMS",
+		Destination: &initialDeployArgs.UnmanageTeams,
+	},
+	cli.BoolFlag{
+		Name:        "prune-all-teams",
+		Usage:       "(optional) Confirm a teams file with no teams, which destroys every team other than main",
+		EnvVar:      "PRUNE_ALL_TEAMS",
+		Destination: &initialDeployArgs.PruneAllTeams,
+	},
+	cli.StringFlag{
+		Name:        "credential-manager",
+		Usage:       "(optional) Credential manager used by Concourse. Can be credhub, vault, aws-secretsmanager or aws-ssm (AWS only)",
+		EnvVar:      "CREDENTIAL_MANAGER",
+		Value:       "credhub",
+		Destination: &initialDeployArgs.CredentialManager,
+	},
+	cli.StringFlag{
+		Name:        "vault-url",
+		Usage:       "(optional) Address of the Vault server Concourse reads credentials from (eg: https://vault.example.com:8200)",
+		EnvVar:      "VAULT_URL",
+		Destination: &initialDeployArgs.VaultURL,
+	},
+	cli.StringFlag{
+		Name:        "vault-auth-backend",
+		Usage:       "(optional) Vault auth backend Concourse logs in with. Can be approle or cert",
+		EnvVar:      "VAULT_AUTH_BACKEND",
+		Destination: &initialDeployArgs.VaultAuthBackend,
+	},
+	cli.StringFlag{
+		Name:        "vault-approle-role-id",
+		Usage:       "(optional) Role ID used with the approle auth backend",
+		EnvVar:      "VAULT_APPROLE_ROLE_ID",
+		Destination: &initialDeployArgs.VaultRoleID,
+	},
+	cli.StringFlag{
+		Name:        "vault-approle-secret-id",
+		Usage:       "(optional) Secret ID used with the approle auth backend",
+		EnvVar:      "VAULT_APPROLE_SECRET_ID",
+		Destination: &initialDeployArgs.VaultSecretID,
+	},
+	cli.StringFlag{
+		Name:        "vault-client-cert",
+		Usage:       "(optional) Client certificate used with the cert auth backend",
+		EnvVar:      "VAULT_CLIENT_CERT",
+		Destination: &initialDeployArgs.VaultClientCert,
+	},
+	cli.StringFlag{
+		Name:        "vault-client-key",
+		Usage:       "(optional) Client private key used with the cert auth backend",
+		EnvVar:      "VAULT_CLIENT_KEY",
+		Destination: &initialDeployArgs.VaultClientKey,
+	},
+	cli.StringFlag{
+		Name:        "vault-path-prefix",
+		Usage:       "(optional) Vault path under which Concourse looks up credentials (default: /concourse)",
+		EnvVar:      "VAULT_PATH_PREFIX",
+		Destination: &initialDeployArgs.VaultPathPrefix,
+	},
+	cli.StringFlag{
+		Name:        "vault-ca-cert",
+		Usage:       "(optional) CA certificate used to verify the Vault server",
+		EnvVar:      "VAULT_CA_CERT",
+		Destination: &initialDeployArgs.VaultCACert,
+	},
+	cli.StringFlag{
+		Name:        "aws-credential-path-prefix",
+		Usage:       "(optional) Path prefix that the aws-secretsmanager and aws-ssm credential managers are allowed to read under (default: /concourse)",
+		EnvVar:      "AWS_CREDENTIAL_PATH_PREFIX",
+		Destination: &initialDeployArgs.AWSCredentialPathPrefix,
+	},
+	cli.StringFlag{
+		Name:        "metrics",
+		Usage:       "(optional) Metrics backend. Can be influxdb, which serves Grafana dashboards, or prometheus, which exposes scrape targets",
+		EnvVar:      "METRICS",
+		Value:       "influxdb",
+		Destination: &initialDeployArgs.Metrics,
+	},
+	cli.StringFlag{
+		Name:        "metrics-allow-ips",
+		Usage:       "(optional) Comma separated list of IP addresses or CIDR ranges allowed to scrape Prometheus metrics. Required with --metrics prometheus",
+		EnvVar:      "METRICS_ALLOW_IPS",
+		Destination: &initialDeployArgs.MetricsAllowIPs,
+	},
+	cli.BoolFlag{
+		Name:        "disable-grafana",
+		Usage:       "(optional) Remove InfluxDB and Grafana when using --metrics prometheus",
+		EnvVar:      "DISABLE_GRAFANA",
+		Destination: &initialDeployArgs.DisableGrafana,
+	},
+	cli.StringFlag{
+		Name:        "grafana-dashboards-dir",
+		Usage:       "(optional) Directory of Grafana dashboard JSON files, with alert notification channels under notifiers/, to provision alongside the built-in dashboard",
+		EnvVar:      "GRAFANA_DASHBOARDS_DIR",
+		Destination: &initialDeployArgs.GrafanaDashboardsDir,
+	},
+	cli.StringFlag{
+		Name:        "syslog-address",
+		Usage:       "(optional) host:port of an RFC5424 syslog receiver to forward Concourse, director and VM logs to",
+		EnvVar:      "SYSLOG_ADDRESS",
+		Destination: &initialDeployArgs.SyslogAddress,
+	},
+	cli.StringFlag{
+		Name:        "syslog-transport",
+		Usage:       "(optional) Protocol used to forward logs to syslog. Can be tcp, udp or relp",
+		EnvVar:      "SYSLOG_TRANSPORT",
+		Value:       "tcp",
+		Destination: &initialDeployArgs.SyslogTransport,
+	},
+	cli.StringFlag{
+		Name:        "syslog-ca-cert",
+		Usage:       "(optional) CA certificate used to verify the syslog receiver. Enables TLS",
+		EnvVar:      "SYSLOG_CA_CERT",
+		Destination: &initialDeployArgs.SyslogCACert,
+	},
+	cli.StringFlag{
+		Name:        "syslog-permitted-peer",
+		Usage:       "(optional) Name the syslog receiver's certificate must be issued to (default: the host of --syslog-address)",
+		EnvVar:      "SYSLOG_PERMITTED_PEER",
+		Destination: &initialDeployArgs.SyslogPermittedPeer,
+	},
+	cli.StringSliceFlag{
+		Name:   "worker-pool",
+		Usage:  "(optional) Additional pool of workers, in the format name:count:size followed by any of :spot, :on-demand, :tag=TAG and :team=TEAM - Multiple pools can be given with multiple uses of this flag, and replace any previously deployed pools",
+		EnvVar: "WORKER_POOLS",
+		Value:  &initialDeployArgs.WorkerPools,
+	},
+	cli.StringFlag{
+		Name:        "tsa-allow-ips",
+		Usage:       "(optional) Comma separated list of IP addresses or CIDR ranges allowed to reach the TSA on port 2222, for external workers added with `control-tower workers add-external`",
+		EnvVar:      "TSA_ALLOW_IPS",
+		Destination: &initialDeployArgs.TSAAllowIPs,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-min-workers",
+		Usage:       "(optional) Fewest workers the self-update pipeline may scale down to when autoscaling",
+		EnvVar:      "AUTOSCALE_MIN_WORKERS",
+		Value:       1,
+		Destination: &initialDeployArgs.AutoscaleMinWorkers,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-max-workers",
+		Usage:       "(optional) Most workers the self-update pipeline may scale up to. Setting this enables autoscaling, and 0 disables it",
+		EnvVar:      "AUTOSCALE_MAX_WORKERS",
+		Destination: &initialDeployArgs.AutoscaleMaxWorkers,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-high-containers",
+		Usage:       "(optional) Average containers per worker above which a worker is added",
+		EnvVar:      "AUTOSCALE_HIGH_CONTAINERS",
+		Value:       100,
+		Destination: &initialDeployArgs.AutoscaleHighContainers,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-low-containers",
+		Usage:       "(optional) Average containers per worker below which a worker is removed",
+		EnvVar:      "AUTOSCALE_LOW_CONTAINERS",
+		Value:       30,
+		Destination: &initialDeployArgs.AutoscaleLowContainers,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-high-volumes",
+		Usage:       "(optional) Average volumes per worker above which a worker is added",
+		EnvVar:      "AUTOSCALE_HIGH_VOLUMES",
+		Value:       1000,
+		Destination: &initialDeployArgs.AutoscaleHighVolumes,
+	},
+	cli.IntFlag{
+		Name:        "autoscale-low-volumes",
+		Usage:       "(optional) Average volumes per worker below which a worker may be removed",
+		EnvVar:      "AUTOSCALE_LOW_VOLUMES",
+		Value:       300,
+		Destination: &initialDeployArgs.AutoscaleLowVolumes,
+	},
+	cli.StringFlag{
+		Name:        "autoscale-cooldown",
+		Usage:       "(optional) Time to wait after scaling before the workers are scaled again, eg: 15m",
+		EnvVar:      "AUTOSCALE_COOLDOWN",
+		Value:       "15m",
+		Destination: &initialDeployArgs.AutoscaleCooldown,
+	},
+	cli.StringFlag{
+		Name:        "schedule-down",
+		Usage:       "(optional) Cron expression for when to scale the workers down, eg: \"0 20 * * 1-5\". Requires --schedule-up",
+		EnvVar:      "SCHEDULE_DOWN",
+		Destination: &initialDeployArgs.ScheduleDown,
+	},
+	cli.StringFlag{
+		Name:        "schedule-up",
+		Usage:       "(optional) Cron expression for when to scale the workers back up, eg: \"0 7 * * 1-5\". Requires --schedule-down",
+		EnvVar:      "SCHEDULE_UP",
+		Destination: &initialDeployArgs.ScheduleUp,
+	},
+	cli.StringFlag{
+		Name:        "schedule-timezone",
+		Usage:       "(optional) Timezone the schedule's cron expressions are in, eg: Europe/London",
+		EnvVar:      "SCHEDULE_TIMEZONE",
+		Value:       "UTC",
+		Destination: &initialDeployArgs.ScheduleTimezone,
+	},
+	cli.IntFlag{
+		Name:        "schedule-workers",
+		Usage:       "(optional) Number of workers to keep between the scheduled down and up times",
+		EnvVar:      "SCHEDULE_WORKERS",
+		Destination: &initialDeployArgs.ScheduleWorkers,
+	},
+	cli.BoolFlag{
+		Name:        "schedule-stop",
+		Usage:       "(optional) Stop the web node and database as well as the workers between the scheduled down and up times. `control-tower schedule` must then be run from outside the deployment",
+		EnvVar:      "SCHEDULE_STOP",
+		Destination: &initialDeployArgs.ScheduleStop,
+	},
+	cli.IntFlag{
+		Name:        "build-logs-to-retain",
+		Usage:       "(optional) Default number of build logs to keep for each job. 0 keeps them all",
+		EnvVar:      "BUILD_LOGS_TO_RETAIN",
+		Destination: &initialDeployArgs.BuildLogsToRetain,
+	},
+	cli.StringFlag{
+		Name:        "container-placement-strategy",
+		Usage:       fmt.Sprintf("(optional) Strategy for choosing a worker for each container, one of %v", config.ContainerPlacementStrategies),
+		EnvVar:      "CONTAINER_PLACEMENT_STRATEGY",
+		Destination: &initialDeployArgs.ContainerPlacementStrategy,
+	},
+	cli.IntFlag{
+		Name:        "default-task-cpu-limit",
+		Usage:       "(optional) Default CPU shares for task containers. 0 leaves them unlimited",
+		EnvVar:      "DEFAULT_TASK_CPU_LIMIT",
+		Destination: &initialDeployArgs.DefaultTaskCPULimit,
+	},
+	cli.StringFlag{
+		Name:        "default-task-memory-limit",
+		Usage:       "(optional) Default memory limit for task containers, eg: 4GB",
+		EnvVar:      "DEFAULT_TASK_MEMORY_LIMIT",
+		Destination: &initialDeployArgs.DefaultTaskMemoryLimit,
+	},
+	cli.StringSliceFlag{
+		Name:   "feature-flag",
+		Usage:  fmt.Sprintf("(optional) Concourse feature to enable, one of %v - Multiple features can be enabled with multiple uses of this flag, and replace any previously enabled features", config.FeatureFlags),
+		EnvVar: "FEATURE_FLAGS",
+		Value:  &initialDeployArgs.FeatureFlags,
+	},
+	cli.StringFlag{
+		Name:        "gc-interval",
+		Usage:       "(optional) Interval between garbage collections of containers and volumes, eg: 30s",
+		EnvVar:      "GC_INTERVAL",
+		Destination: &initialDeployArgs.GCInterval,
+	},
+	cli.IntFlag{
+		Name:        "max-containers-per-worker",
+		Usage:       "(optional) Maximum number of containers each worker will run",
+		EnvVar:      "MAX_CONTAINERS_PER_WORKER",
+		Destination: &initialDeployArgs.MaxContainersPerWorker,
+	},
+	cli.StringFlag{
+		Name:        "resource-checking-interval",
+		Usage:       "(optional) Default interval between checks of each resource, eg: 1m",
+		EnvVar:      "RESOURCE_CHECKING_INTERVAL",
+		Destination: &initialDeployArgs.ResourceCheckingInterval,
+	},
+	cli.StringFlag{
+		Name:        "resource-webhook-checking-interval",
+		Usage:       "(optional) Interval between checks of resources that have a webhook token, eg: 1h",
+		EnvVar:      "RESOURCE_WEBHOOK_CHECKING_INTERVAL",
+		Destination: &initialDeployArgs.ResourceWebhookCheckingInterval,
+	},
+	cli.StringSliceFlag{
+		Name:   "director-ops-file",
+		Usage:  "(optional) BOSH ops file to apply to the director manifest - Multiple files can be given with multiple uses of this flag, and replace any previously deployed files",
+		EnvVar: "DIRECTOR_OPS_FILES",
+		Value:  &initialDeployArgs.DirectorOpsFiles,
+	},
+	cli.StringSliceFlag{
+		Name:   "cloud-config-ops-file",
+		Usage:  "(optional) BOSH ops file to apply to the cloud config - Multiple files can be given with multiple uses of this flag, and replace any previously deployed files",
+		EnvVar: "CLOUD_CONFIG_OPS_FILES",
+		Value:  &initialDeployArgs.CloudConfigOpsFiles,
+	},
+	cli.StringSliceFlag{
+		Name:   "concourse-ops-file",
+		Usage:  "(optional) BOSH ops file to apply to the Concourse manifest - Multiple files can be given with multiple uses of this flag, and replace any previously deployed files",
+		EnvVar: "CONCOURSE_OPS_FILES",
+		Value:  &initialDeployArgs.ConcourseOpsFiles,
+	},
+	cli.StringSliceFlag{
+		Name:   "vars-file",
+		Usage:  "(optional) BOSH vars file for the Concourse manifest - Multiple files can be given with multiple uses of this flag, and replace any previously deployed files",
+		EnvVar: "VARS_FILES",
+		Value:  &initialDeployArgs.VarsFiles,
+	},
+	cli.StringFlag{
+		Name:        "runtime-config",
+		Usage:       "(optional) BOSH runtime config file declaring addon releases and jobs to run on every VM. Give an empty value to remove a previously deployed runtime config",
+		EnvVar:      "RUNTIME_CONFIG",
+		Destination: &initialDeployArgs.RuntimeConfig,
+	},
+	cli.StringFlag{
+		Name:        "self-update-repository",
+		Usage:       "(optional) GitHub repository, as owner/name, that the self-update pipeline takes releases from (default: engineerbetter/control-tower)",
+		EnvVar:      "SELF_UPDATE_REPOSITORY",
+		Destination: &initialDeployArgs.SelfUpdateRepository,
+	},
+	cli.StringFlag{
+		Name:        "self-update-github-api-url",
+		Usage:       "(optional) API URL of a GitHub Enterprise instance hosting --self-update-repository, eg: https://github.example.com/api/v3/",
+		EnvVar:      "SELF_UPDATE_GITHUB_API_URL",
+		Destination: &initialDeployArgs.SelfUpdateGitHubAPIURL,
+	},
+	cli.StringFlag{
+		Name:        "self-update-github-token",
+		Usage:       "(optional) GitHub access token the self-update pipeline uses to read releases",
+		EnvVar:      "SELF_UPDATE_GITHUB_TOKEN",
+		Destination: &initialDeployArgs.SelfUpdateGitHubToken,
+	},
+	cli.StringFlag{
+		Name:        "self-update-s3-bucket",
+		Usage:       "(optional) S3 bucket mirroring releases as <version>/control-tower-linux-amd64, used instead of GitHub",
+		EnvVar:      "SELF_UPDATE_S3_BUCKET",
+		Destination: &initialDeployArgs.SelfUpdateS3Bucket,
+	},
+	cli.StringFlag{
+		Name:        "self-update-s3-region",
+		Usage:       "(optional) AWS region of --self-update-s3-bucket",
+		EnvVar:      "SELF_UPDATE_S3_REGION",
+		Destination: &initialDeployArgs.SelfUpdateS3Region,
+	},
+	cli.StringFlag{
+		Name:        "self-update-version-constraint",
+		Usage:       "(optional) Only update to versions matching this constraint, eg: 1.4, ~1.4.2 or ^1",
+		EnvVar:      "SELF_UPDATE_VERSION_CONSTRAINT",
+		Destination: &initialDeployArgs.SelfUpdateVersionConstraint,
+	},
+	cli.BoolFlag{
+		Name:        "self-update-stable-only",
+		Usage:       "(optional) Ignore pre-releases when self-updating. Can be true/false (default: false)",
+		EnvVar:      "SELF_UPDATE_STABLE_ONLY",
+		Destination: &initialDeployArgs.SelfUpdateStableOnly,
+	},
+	cli.BoolFlag{
+		Name:        "self-update-approval",
+		Usage:       "(optional) Stop each self-update after a plan job until the self-update job is triggered by hand. Can be true/false (default: false)",
+		EnvVar:      "SELF_UPDATE_APPROVAL",
+		Destination: &initialDeployArgs.SelfUpdateApproval,
+	},
+	cli.StringFlag{
+		Name:        "maintenance-window-start",
+		Usage:       "(optional) Time of day from which the self-update pipeline may redeploy, eg: \"1:00 AM\". Requires --maintenance-window-stop",
+		EnvVar:      "MAINTENANCE_WINDOW_START",
+		Destination: &initialDeployArgs.MaintenanceWindowStart,
+	},
+	cli.StringFlag{
+		Name:        "maintenance-window-stop",
+		Usage:       "(optional) Time of day until which the self-update pipeline may redeploy, eg: \"4:00 AM\". Requires --maintenance-window-start",
+		EnvVar:      "MAINTENANCE_WINDOW_STOP",
+		Destination: &initialDeployArgs.MaintenanceWindowStop,
+	},
+	cli.StringSliceFlag{
+		Name:   "maintenance-window-day",
+		Usage:  fmt.Sprintf("(optional) Day of the week the maintenance window applies on, one of %v. Can be given multiple times (default: every day)", config.MaintenanceWindowDays),
+		EnvVar: "MAINTENANCE_WINDOW_DAYS",
+		Value:  &initialDeployArgs.MaintenanceWindowDays,
+	},
+	cli.StringFlag{
+		Name:        "maintenance-window-location",
+		Usage:       "(optional) Timezone of the maintenance window's start and stop times, eg: Europe/London",
+		EnvVar:      "MAINTENANCE_WINDOW_LOCATION",
+		Value:       "UTC",
+		Destination: &initialDeployArgs.MaintenanceWindowLocation,
+	},
+	cli.StringFlag{
+		Name:        "notify-webhook-url",
+		Usage:       "(optional) URL the self-update pipeline posts a JSON notification to when its jobs finish",
+		EnvVar:      "NOTIFY_WEBHOOK_URL",
+		Destination: &initialDeployArgs.NotifyWebhookURL,
+	},
+	cli.StringFlag{
+		Name:        "notify-slack-webhook-url",
+		Usage:       "(optional) Slack incoming webhook URL the self-update pipeline notifies when its jobs finish",
+		EnvVar:      "NOTIFY_SLACK_WEBHOOK_URL",
+		Destination: &initialDeployArgs.NotifySlackWebhookURL,
+	},
+	cli.StringFlag{
+		Name:        "notify-smtp-host",
+		Usage:       "(optional) host:port of a STARTTLS mail server the self-update pipeline emails when its jobs finish. Requires --notify-smtp-from and --notify-smtp-to",
+		EnvVar:      "NOTIFY_SMTP_HOST",
+		Destination: &initialDeployArgs.NotifySMTPHost,
+	},
+	cli.StringFlag{
+		Name:        "notify-smtp-username",
+		Usage:       "(optional) Username to authenticate with --notify-smtp-host",
+		EnvVar:      "NOTIFY_SMTP_USERNAME",
+		Destination: &initialDeployArgs.NotifySMTPUsername,
+	},
+	cli.StringFlag{
+		Name:        "notify-smtp-password",
+		Usage:       "(optional) Password to authenticate with --notify-smtp-host",
+		EnvVar:      "NOTIFY_SMTP_PASSWORD",
+		Destination: &initialDeployArgs.NotifySMTPPassword,
+	},
+	cli.StringFlag{
+		Name:        "notify-smtp-from",
+		Usage:       "(optional) Address notification emails are sent from",
+		EnvVar:      "NOTIFY_SMTP_FROM",
+		Destination: &initialDeployArgs.NotifySMTPFrom,
+	},
+	cli.StringSliceFlag{
+		Name:   "notify-smtp-to",
+		Usage:  "(optional) Address notification emails are sent to. Can be given multiple times",
+		EnvVar: "NOTIFY_SMTP_TO",
+		Value:  &initialDeployArgs.NotifySMTPTo,
+	},
+	cli.StringSliceFlag{
+		Name:   "notify-on",
+		Usage:  fmt.Sprintf("(optional) Job outcome to send notifications on, one of %v. Can be given multiple times (default: %v)", config.NotificationEvents, config.DefaultNotificationEvents),
+		EnvVar: "NOTIFY_ON",
+		Value:  &initialDeployArgs.NotifyOn,
+	},
 }
 
 func deployAction(c *cli.Context, deployArgs deploy.Args, provider iaas.Provider) error {
@@ -205,11 +823,18 @@ func deployAction(c *cli.Context, deployArgs deploy.Args, provider iaas.Provider
 		return err
 	}
 
+	err = validateCredentialManager(deployArgs.CredentialManager, provider.IAAS())
+	if err != nil {
+		return err
+	}
+
 	err = validateCidrRanges(provider, deployArgs.NetworkCIDR, deployArgs.PublicCIDR, deployArgs.PrivateCIDR, deployArgs.RDS1CIDR, deployArgs.RDS2CIDR)
 	if err != nil {
 		return err
 	}
 
+	deployArgs.ArtefactMirror, deployArgs.ArtefactMirrorIsSet = artefactMirror, artefactMirrorIsSet
+
 	client, err := buildClient(name, version, deployArgs, provider)
 	if err != nil {
 		return err
@@ -224,6 +849,10 @@ func validateDeployArgs(c *cli.Context, deployArgs deploy.Args) (deploy.Args, er
 		return deployArgs, fmt.Errorf("failed to mark set Deploy flags: [%v]", err)
 	}
 
+	deployArgs.HTTPProxy, deployArgs.HTTPProxyIsSet = httpProxy, c.GlobalIsSet("http-proxy")
+	deployArgs.HTTPSProxy, deployArgs.HTTPSProxyIsSet = httpsProxy, c.GlobalIsSet("https-proxy")
+	deployArgs.NoProxy, deployArgs.NoProxyIsSet = noProxy, c.GlobalIsSet("no-proxy")
+
 	if err = deployArgs.Validate(); err != nil {
 		return deployArgs, fmt.Errorf("failed to validate Deploy flags: [%v]", err)
 	}
@@ -279,6 +908,14 @@ func validateNameLength(name string, providerName iaas.Name) error {
 	return nil
 }
 
+func validateCredentialManager(credentialManager string, providerName iaas.Name) error {
+	if providerName != iaas.AWS && strings.HasPrefix(credentialManager, "aws-") {
+		return fmt.Errorf("credential manager %s is only available on AWS", credentialManager)
+	}
+
+	return nil
+}
+
 func validateCidrRanges(provider iaas.Provider, networkCIDR, publicCIDR, privateCIDR, RDS1CIDR, RDS2CIDR string) error {
 	var parsedNetworkCidr, parsedPublicCidr, parsedPrivateCidr, parsedRDS1CIDR, parsedRDS2CIDR *net.IPNet
 	var err error
@@ -383,12 +1020,17 @@ func validateRDSSubnetSize(cidr *net.IPNet) bool {
 }
 
 func buildClient(name, version string, deployArgs deploy.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
+	configClient := config.New(provider, name, deployArgs.Namespace)
+	if err := useSavedSettings(configClient); err != nil {
+		return nil, err
+	}
+
+	versionFile, err := chooseVersionFile(provider)
+	if err != nil {
+		return nil, err
+	}
 
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
+	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile), terraform.ArtefactMirror(artefactMirror))
 	if err != nil {
 		return nil, err
 	}
@@ -405,7 +1047,7 @@ func buildClient(name, version string, deployArgs deploy.Args, provider iaas.Pro
 		bosh.New,
 		fly.New,
 		certs.Generate,
-		config.New(provider, name, deployArgs.Namespace),
+		configClient,
 		&deployArgs,
 		os.Stdout,
 		os.Stderr,
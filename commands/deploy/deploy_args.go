@@ -3,32 +3,51 @@ package deploy
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util/cron"
 	"gopkg.in/urfave/cli.v1"
 )
 
 // Args are arguments passed to the deploy command
 type Args struct {
-	IAAS             string
-	IAASIsSet        bool
-	Region           string
-	RegionIsSet      bool
-	Domain           string
-	DomainIsSet      bool
-	TLSCert          string
-	TLSCertIsSet     bool
-	TLSKey           string
-	TLSKeyIsSet      bool
-	WorkerCount      int
-	WorkerCountIsSet bool
-	WorkerSize       string
-	WorkerSizeIsSet  bool
-	WebSize          string
-	WebSizeIsSet     bool
-	SelfUpdate       bool
-	SelfUpdateIsSet  bool
-	DBSize           string
+	// ArtefactMirror and the proxy settings come from global flags rather than deploy flags
+	ArtefactMirror      string
+	ArtefactMirrorIsSet bool
+	HTTPProxy           string
+	HTTPProxyIsSet      bool
+	HTTPSProxy          string
+	HTTPSProxyIsSet     bool
+	NoProxy             []string
+	NoProxyIsSet        bool
+	IAAS                string
+	IAASIsSet           bool
+	Region              string
+	RegionIsSet         bool
+	Domain              string
+	DomainIsSet         bool
+	TLSCert             string
+	TLSCertIsSet        bool
+	TLSKey              string
+	TLSKeyIsSet         bool
+	WorkerCount         int
+	WorkerCountIsSet    bool
+	WorkerSize          string
+	WorkerSizeIsSet     bool
+	WebCount            int
+	WebCountIsSet       bool
+	WebSize             string
+	WebSizeIsSet        bool
+	SelfUpdate          bool
+	SelfUpdateIsSet     bool
+	// DryRun shows the changes a deploy would make to the Concourse deployment without making them
+	DryRun bool
+	DBSize string
 	// DBSizeIsSet is true if the user has manually specified the db-size (ie, it's not the default)
 	DBSizeIsSet                 bool
 	EnableGlobalResources       bool
@@ -62,6 +81,218 @@ type Args struct {
 	RDS1CIDRIsSet    bool
 	RDS2CIDR         string
 	RDS2CIDRIsSet    bool
+	StemcellOS       string
+	StemcellOSIsSet  bool
+	// ConcourseVersion pins Concourse to a version from the compatibility table, or to the default if empty
+	ConcourseVersion      string
+	ConcourseVersionIsSet bool
+	// OIDCAuthIssuer is the URL of a generic OpenID Connect provider, eg: Okta
+	OIDCAuthIssuer       string
+	OIDCAuthClientID     string
+	OIDCAuthClientSecret string
+	// OIDCAuthIsSet is true if the user has specified the --oidc-auth-issuer, --oidc-auth-client-id and --oidc-auth-client-secret flags
+	OIDCAuthIsSet            bool
+	OIDCAuthScopes           cli.StringSlice
+	OIDCAuthScopesIsSet      bool
+	OIDCAuthGroupsClaim      string
+	OIDCAuthGroupsClaimIsSet bool
+	GitLabAuthClientID       string
+	GitLabAuthClientSecret   string
+	// GitLabAuthIsSet is true if the user has specified both the --gitlab-auth-client-id and --gitlab-auth-client-secret flags
+	GitLabAuthIsSet                bool
+	GitLabAuthHost                 string
+	GitLabAuthHostIsSet            bool
+	BitbucketCloudAuthClientID     string
+	BitbucketCloudAuthClientSecret string
+	// BitbucketCloudAuthIsSet is true if the user has specified both the --bitbucket-cloud-auth-client-id and --bitbucket-cloud-auth-client-secret flags
+	BitbucketCloudAuthIsSet  bool
+	LDAPAuthHost             string
+	LDAPAuthBindDN           string
+	LDAPAuthBindPassword     string
+	LDAPAuthUserSearchBaseDN string
+	// LDAPAuthIsSet is true if the user has specified the --ldap-auth-host, --ldap-auth-bind-dn, --ldap-auth-bind-password and --ldap-auth-user-search-base-dn flags
+	LDAPAuthIsSet                    bool
+	LDAPAuthUserSearchUsername       string
+	LDAPAuthUserSearchUsernameIsSet  bool
+	LDAPAuthGroupSearchBaseDN        string
+	LDAPAuthGroupSearchBaseDNIsSet   bool
+	LDAPAuthCACert                   string
+	LDAPAuthCACertIsSet              bool
+	MainTeamOIDCGroups               cli.StringSlice
+	MainTeamOIDCGroupsIsSet          bool
+	MainTeamGitLabGroups             cli.StringSlice
+	MainTeamGitLabGroupsIsSet        bool
+	MainTeamBitbucketCloudTeams      cli.StringSlice
+	MainTeamBitbucketCloudTeamsIsSet bool
+	MainTeamLDAPGroups               cli.StringSlice
+	MainTeamLDAPGroupsIsSet          bool
+	MainTeamGithubOrgs               cli.StringSlice
+	MainTeamGithubOrgsIsSet          bool
+	MainTeamGithubTeams              cli.StringSlice
+	MainTeamGithubTeamsIsSet         bool
+	MainTeamGithubUsers              cli.StringSlice
+	MainTeamGithubUsersIsSet         bool
+	MainTeamOIDCUsers                cli.StringSlice
+	MainTeamOIDCUsersIsSet           bool
+	MainTeamGitLabUsers              cli.StringSlice
+	MainTeamGitLabUsersIsSet         bool
+	MainTeamBitbucketCloudUsers      cli.StringSlice
+	MainTeamBitbucketCloudUsersIsSet bool
+	MainTeamLDAPUsers                cli.StringSlice
+	MainTeamLDAPUsersIsSet           bool
+	TeamsFile                        string
+	TeamsFileIsSet                   bool
+	// UnmanageTeams stops applying the stored teams file, leaving the teams in Concourse as they are
+	UnmanageTeams      bool
+	UnmanageTeamsIsSet bool
+	// PruneAllTeams confirms a teams file without any teams, which destroys every team other than main
+	PruneAllTeams bool
+	// CredentialManager is one of CredentialManagers
+	CredentialManager      string
+	CredentialManagerIsSet bool
+	VaultURL               string
+	VaultURLIsSet          bool
+	VaultAuthBackend       string
+	VaultAuthBackendIsSet  bool
+	VaultRoleID            string
+	VaultRoleIDIsSet       bool
+	VaultSecretID          string
+	VaultSecretIDIsSet     bool
+	VaultClientCert        string
+	VaultClientCertIsSet   bool
+	VaultClientKey         string
+	VaultClientKeyIsSet    bool
+	VaultPathPrefix        string
+	VaultPathPrefixIsSet   bool
+	VaultCACert            string
+	VaultCACertIsSet       bool
+	// AWSCredentialPathPrefix scopes what the aws-secretsmanager and aws-ssm credential managers can read
+	AWSCredentialPathPrefix      string
+	AWSCredentialPathPrefixIsSet bool
+	// Metrics is one of MetricsBackends
+	Metrics              string
+	MetricsIsSet         bool
+	MetricsAllowIPs      string
+	MetricsAllowIPsIsSet bool
+	DisableGrafana       bool
+	DisableGrafanaIsSet  bool
+	// GrafanaDashboardsDir holds dashboard JSON files, and alert notification channels under notifiers/
+	GrafanaDashboardsDir      string
+	GrafanaDashboardsDirIsSet bool
+	// SyslogAddress is the host:port of an RFC5424 receiver that every VM and the director forward logs to
+	SyslogAddress            string
+	SyslogAddressIsSet       bool
+	SyslogTransport          string
+	SyslogTransportIsSet     bool
+	SyslogCACert             string
+	SyslogCACertIsSet        bool
+	SyslogPermittedPeer      string
+	SyslogPermittedPeerIsSet bool
+	// WorkerPools are given as name:count:size[:option]... and replace any previously deployed pools
+	WorkerPools      cli.StringSlice
+	WorkerPoolsIsSet bool
+	TSAAllowIPs      string
+	TSAAllowIPsIsSet bool
+	// Autoscale settings are used by the autoscale job of the self-update pipeline
+	AutoscaleMinWorkers          int
+	AutoscaleMinWorkersIsSet     bool
+	AutoscaleMaxWorkers          int
+	AutoscaleMaxWorkersIsSet     bool
+	AutoscaleHighContainers      int
+	AutoscaleHighContainersIsSet bool
+	AutoscaleLowContainers       int
+	AutoscaleLowContainersIsSet  bool
+	AutoscaleHighVolumes         int
+	AutoscaleHighVolumesIsSet    bool
+	AutoscaleLowVolumes          int
+	AutoscaleLowVolumesIsSet     bool
+	AutoscaleCooldown            string
+	AutoscaleCooldownIsSet       bool
+	// Schedule settings are used by `control-tower schedule`
+	ScheduleDown          string
+	ScheduleDownIsSet     bool
+	ScheduleUp            string
+	ScheduleUpIsSet       bool
+	ScheduleTimezone      string
+	ScheduleTimezoneIsSet bool
+	ScheduleWorkers       int
+	ScheduleWorkersIsSet  bool
+	ScheduleStop          bool
+	ScheduleStopIsSet     bool
+	// Concourse properties are set on the web and worker jobs. Zero values restore Concourse's defaults
+	BuildLogsToRetain                    int
+	BuildLogsToRetainIsSet               bool
+	ContainerPlacementStrategy           string
+	ContainerPlacementStrategyIsSet      bool
+	DefaultTaskCPULimit                  int
+	DefaultTaskCPULimitIsSet             bool
+	DefaultTaskMemoryLimit               string
+	DefaultTaskMemoryLimitIsSet          bool
+	FeatureFlags                         cli.StringSlice
+	FeatureFlagsIsSet                    bool
+	GCInterval                           string
+	GCIntervalIsSet                      bool
+	MaxContainersPerWorker               int
+	MaxContainersPerWorkerIsSet          bool
+	ResourceCheckingInterval             string
+	ResourceCheckingIntervalIsSet        bool
+	ResourceWebhookCheckingInterval      string
+	ResourceWebhookCheckingIntervalIsSet bool
+	// Custom ops and vars files are paths to local files, and replace any previously deployed files
+	DirectorOpsFiles         cli.StringSlice
+	DirectorOpsFilesIsSet    bool
+	CloudConfigOpsFiles      cli.StringSlice
+	CloudConfigOpsFilesIsSet bool
+	ConcourseOpsFiles        cli.StringSlice
+	ConcourseOpsFilesIsSet   bool
+	VarsFiles                cli.StringSlice
+	VarsFilesIsSet           bool
+	// RuntimeConfig is the path to a BOSH runtime config of addons for every VM
+	RuntimeConfig      string
+	RuntimeConfigIsSet bool
+	// Self-update settings choose where the self-update pipeline finds releases and how it applies them
+	SelfUpdateRepository             string
+	SelfUpdateRepositoryIsSet        bool
+	SelfUpdateGitHubAPIURL           string
+	SelfUpdateGitHubAPIURLIsSet      bool
+	SelfUpdateGitHubToken            string
+	SelfUpdateGitHubTokenIsSet       bool
+	SelfUpdateS3Bucket               string
+	SelfUpdateS3BucketIsSet          bool
+	SelfUpdateS3Region               string
+	SelfUpdateS3RegionIsSet          bool
+	SelfUpdateVersionConstraint      string
+	SelfUpdateVersionConstraintIsSet bool
+	SelfUpdateStableOnly             bool
+	SelfUpdateStableOnlyIsSet        bool
+	SelfUpdateApproval               bool
+	SelfUpdateApprovalIsSet          bool
+	// Maintenance window settings gate the redeploys of the self-update pipeline
+	MaintenanceWindowStart         string
+	MaintenanceWindowStartIsSet    bool
+	MaintenanceWindowStop          string
+	MaintenanceWindowStopIsSet     bool
+	MaintenanceWindowDays          cli.StringSlice
+	MaintenanceWindowDaysIsSet     bool
+	MaintenanceWindowLocation      string
+	MaintenanceWindowLocationIsSet bool
+	// Notify settings choose where the self-update pipeline reports the outcome of its jobs
+	NotifyWebhookURL           string
+	NotifyWebhookURLIsSet      bool
+	NotifySlackWebhookURL      string
+	NotifySlackWebhookURLIsSet bool
+	NotifySMTPHost             string
+	NotifySMTPHostIsSet        bool
+	NotifySMTPUsername         string
+	NotifySMTPUsernameIsSet    bool
+	NotifySMTPPassword         string
+	NotifySMTPPasswordIsSet    bool
+	NotifySMTPFrom             string
+	NotifySMTPFromIsSet        bool
+	NotifySMTPTo               cli.StringSlice
+	NotifySMTPToIsSet          bool
+	NotifyOn                   cli.StringSlice
+	NotifyOnIsSet              bool
 }
 
 // MarkSetFlags is marking the IsSet DeployArgs
@@ -83,12 +314,16 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.WorkerCountIsSet = true
 			case "worker-size":
 				a.WorkerSizeIsSet = true
+			case "web-count":
+				a.WebCountIsSet = true
 			case "web-size":
 				a.WebSizeIsSet = true
 			case "iaas":
 				a.IAASIsSet = true
 			case "self-update":
 				a.SelfUpdateIsSet = true
+			case "dry-run":
+				// Only changes what this deploy does, so nothing needs marking
 			case "db-size":
 				a.DBSizeIsSet = true
 			case "spot", "preemptible":
@@ -117,12 +352,200 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.RDS1CIDRIsSet = true
 			case "rds-subnet-range2":
 				a.RDS2CIDRIsSet = true
+			case "stemcell-os":
+				a.StemcellOSIsSet = true
+			case "concourse-version":
+				a.ConcourseVersionIsSet = true
+			case "oidc-auth-issuer", "oidc-auth-client-id", "oidc-auth-client-secret":
+				// Tracked as a group by OIDCAuthIsSet
+			case "oidc-auth-scopes":
+				a.OIDCAuthScopesIsSet = true
+			case "oidc-auth-groups-claim":
+				a.OIDCAuthGroupsClaimIsSet = true
+			case "gitlab-auth-client-id", "gitlab-auth-client-secret":
+				// Tracked as a group by GitLabAuthIsSet
+			case "gitlab-auth-host":
+				a.GitLabAuthHostIsSet = true
+			case "bitbucket-cloud-auth-client-id", "bitbucket-cloud-auth-client-secret":
+				// Tracked as a group by BitbucketCloudAuthIsSet
+			case "ldap-auth-host", "ldap-auth-bind-dn", "ldap-auth-bind-password", "ldap-auth-user-search-base-dn":
+				// Tracked as a group by LDAPAuthIsSet
+			case "ldap-auth-user-search-username":
+				a.LDAPAuthUserSearchUsernameIsSet = true
+			case "ldap-auth-group-search-base-dn":
+				a.LDAPAuthGroupSearchBaseDNIsSet = true
+			case "ldap-auth-ca-cert":
+				a.LDAPAuthCACertIsSet = true
+			case "main-team-oidc-groups":
+				a.MainTeamOIDCGroupsIsSet = true
+			case "main-team-gitlab-groups":
+				a.MainTeamGitLabGroupsIsSet = true
+			case "main-team-bitbucket-cloud-teams":
+				a.MainTeamBitbucketCloudTeamsIsSet = true
+			case "main-team-ldap-groups":
+				a.MainTeamLDAPGroupsIsSet = true
+			case "main-team-github-orgs":
+				a.MainTeamGithubOrgsIsSet = true
+			case "main-team-github-teams":
+				a.MainTeamGithubTeamsIsSet = true
+			case "main-team-github-users":
+				a.MainTeamGithubUsersIsSet = true
+			case "main-team-oidc-users":
+				a.MainTeamOIDCUsersIsSet = true
+			case "main-team-gitlab-users":
+				a.MainTeamGitLabUsersIsSet = true
+			case "main-team-bitbucket-cloud-users":
+				a.MainTeamBitbucketCloudUsersIsSet = true
+			case "main-team-ldap-users":
+				a.MainTeamLDAPUsersIsSet = true
+			case "teams-file":
+				a.TeamsFileIsSet = true
+			case "unmanage-teams":
+				a.UnmanageTeamsIsSet = true
+			case "prune-all-teams":
+				// Only read alongside --teams-file, so nothing needs marking
+			case "credential-manager":
+				a.CredentialManagerIsSet = true
+			case "vault-url":
+				a.VaultURLIsSet = true
+			case "vault-auth-backend":
+				a.VaultAuthBackendIsSet = true
+			case "vault-approle-role-id":
+				a.VaultRoleIDIsSet = true
+			case "vault-approle-secret-id":
+				a.VaultSecretIDIsSet = true
+			case "vault-client-cert":
+				a.VaultClientCertIsSet = true
+			case "vault-client-key":
+				a.VaultClientKeyIsSet = true
+			case "vault-path-prefix":
+				a.VaultPathPrefixIsSet = true
+			case "vault-ca-cert":
+				a.VaultCACertIsSet = true
+			case "aws-credential-path-prefix":
+				a.AWSCredentialPathPrefixIsSet = true
+			case "metrics":
+				a.MetricsIsSet = true
+			case "metrics-allow-ips":
+				a.MetricsAllowIPsIsSet = true
+			case "disable-grafana":
+				a.DisableGrafanaIsSet = true
+			case "grafana-dashboards-dir":
+				a.GrafanaDashboardsDirIsSet = true
+			case "syslog-address":
+				a.SyslogAddressIsSet = true
+			case "syslog-transport":
+				a.SyslogTransportIsSet = true
+			case "syslog-ca-cert":
+				a.SyslogCACertIsSet = true
+			case "syslog-permitted-peer":
+				a.SyslogPermittedPeerIsSet = true
+			case "worker-pool":
+				a.WorkerPoolsIsSet = true
+			case "tsa-allow-ips":
+				a.TSAAllowIPsIsSet = true
+			case "autoscale-min-workers":
+				a.AutoscaleMinWorkersIsSet = true
+			case "autoscale-max-workers":
+				a.AutoscaleMaxWorkersIsSet = true
+			case "autoscale-high-containers":
+				a.AutoscaleHighContainersIsSet = true
+			case "autoscale-low-containers":
+				a.AutoscaleLowContainersIsSet = true
+			case "autoscale-high-volumes":
+				a.AutoscaleHighVolumesIsSet = true
+			case "autoscale-low-volumes":
+				a.AutoscaleLowVolumesIsSet = true
+			case "autoscale-cooldown":
+				a.AutoscaleCooldownIsSet = true
+			case "schedule-down":
+				a.ScheduleDownIsSet = true
+			case "schedule-up":
+				a.ScheduleUpIsSet = true
+			case "schedule-timezone":
+				a.ScheduleTimezoneIsSet = true
+			case "schedule-workers":
+				a.ScheduleWorkersIsSet = true
+			case "schedule-stop":
+				a.ScheduleStopIsSet = true
+			case "build-logs-to-retain":
+				a.BuildLogsToRetainIsSet = true
+			case "container-placement-strategy":
+				a.ContainerPlacementStrategyIsSet = true
+			case "default-task-cpu-limit":
+				a.DefaultTaskCPULimitIsSet = true
+			case "default-task-memory-limit":
+				a.DefaultTaskMemoryLimitIsSet = true
+			case "feature-flag":
+				a.FeatureFlagsIsSet = true
+			case "gc-interval":
+				a.GCIntervalIsSet = true
+			case "max-containers-per-worker":
+				a.MaxContainersPerWorkerIsSet = true
+			case "resource-checking-interval":
+				a.ResourceCheckingIntervalIsSet = true
+			case "resource-webhook-checking-interval":
+				a.ResourceWebhookCheckingIntervalIsSet = true
+			case "director-ops-file":
+				a.DirectorOpsFilesIsSet = true
+			case "cloud-config-ops-file":
+				a.CloudConfigOpsFilesIsSet = true
+			case "concourse-ops-file":
+				a.ConcourseOpsFilesIsSet = true
+			case "vars-file":
+				a.VarsFilesIsSet = true
+			case "runtime-config":
+				a.RuntimeConfigIsSet = true
+			case "self-update-repository":
+				a.SelfUpdateRepositoryIsSet = true
+			case "self-update-github-api-url":
+				a.SelfUpdateGitHubAPIURLIsSet = true
+			case "self-update-github-token":
+				a.SelfUpdateGitHubTokenIsSet = true
+			case "self-update-s3-bucket":
+				a.SelfUpdateS3BucketIsSet = true
+			case "self-update-s3-region":
+				a.SelfUpdateS3RegionIsSet = true
+			case "self-update-version-constraint":
+				a.SelfUpdateVersionConstraintIsSet = true
+			case "self-update-stable-only":
+				a.SelfUpdateStableOnlyIsSet = true
+			case "self-update-approval":
+				a.SelfUpdateApprovalIsSet = true
+			case "maintenance-window-start":
+				a.MaintenanceWindowStartIsSet = true
+			case "maintenance-window-stop":
+				a.MaintenanceWindowStopIsSet = true
+			case "maintenance-window-day":
+				a.MaintenanceWindowDaysIsSet = true
+			case "maintenance-window-location":
+				a.MaintenanceWindowLocationIsSet = true
+			case "notify-webhook-url":
+				a.NotifyWebhookURLIsSet = true
+			case "notify-slack-webhook-url":
+				a.NotifySlackWebhookURLIsSet = true
+			case "notify-smtp-host":
+				a.NotifySMTPHostIsSet = true
+			case "notify-smtp-username":
+				a.NotifySMTPUsernameIsSet = true
+			case "notify-smtp-password":
+				a.NotifySMTPPasswordIsSet = true
+			case "notify-smtp-from":
+				a.NotifySMTPFromIsSet = true
+			case "notify-smtp-to":
+				a.NotifySMTPToIsSet = true
+			case "notify-on":
+				a.NotifyOnIsSet = true
 			default:
 				return fmt.Errorf("flag %q is not supported by deployment flags", f)
 			}
 		}
 	}
 	a.GithubAuthIsSet = c.IsSet("github-auth-client-id") && c.IsSet("github-auth-client-secret")
+	a.OIDCAuthIsSet = c.IsSet("oidc-auth-issuer") && c.IsSet("oidc-auth-client-id") && c.IsSet("oidc-auth-client-secret")
+	a.GitLabAuthIsSet = c.IsSet("gitlab-auth-client-id") && c.IsSet("gitlab-auth-client-secret")
+	a.BitbucketCloudAuthIsSet = c.IsSet("bitbucket-cloud-auth-client-id") && c.IsSet("bitbucket-cloud-auth-client-secret")
+	a.LDAPAuthIsSet = c.IsSet("ldap-auth-host") && c.IsSet("ldap-auth-bind-dn") && c.IsSet("ldap-auth-bind-password") && c.IsSet("ldap-auth-user-search-base-dn")
 
 	return nil
 }
@@ -136,6 +559,21 @@ var WebSizes = []string{"small", "medium", "large", "xlarge", "2xlarge"}
 // AllowedDBSizes contains the valid values for --db-size flag
 var AllowedDBSizes = []string{"small", "medium", "large", "xlarge", "2xlarge", "4xlarge"}
 
+// StemcellOSes are the permitted stemcell OS lines
+var StemcellOSes = []string{"xenial", "bionic", "jammy"}
+
+// CredentialManagers are the permitted Concourse credential managers
+var CredentialManagers = []string{"credhub", "vault", "aws-secretsmanager", "aws-ssm"}
+
+// VaultAuthBackends are the permitted Vault auth backends
+var VaultAuthBackends = []string{"approle", "cert"}
+
+// MetricsBackends are the permitted metrics backends
+var MetricsBackends = []string{"influxdb", "prometheus"}
+
+// SyslogTransports are the permitted protocols for forwarding logs to syslog
+var SyslogTransports = []string{"tcp", "udp", "relp"}
+
 // Validate validates that flag interdependencies
 func (a Args) Validate() error {
 	if !a.IAASIsSet {
@@ -162,6 +600,34 @@ func (a Args) Validate() error {
 		return err
 	}
 
+	if err := a.validateMainTeamGithubTeams(); err != nil {
+		return err
+	}
+
+	if err := a.validateOIDCFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateGitLabFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateBitbucketCloudFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateLDAPFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateMainTeamConnectors(); err != nil {
+		return err
+	}
+
+	if err := a.validateTeamsFields(); err != nil {
+		return err
+	}
+
 	if err := a.validateNetworkRanges(); err != nil {
 		return err
 	}
@@ -170,6 +636,58 @@ func (a Args) Validate() error {
 		return err
 	}
 
+	if err := a.validateStemcellOS(); err != nil {
+		return err
+	}
+
+	if err := a.validateVaultFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateAWSCredentialFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateMetricsFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateSyslogFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateWorkerPoolFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateAutoscaleFields(); err != nil {
+		return err
+	}
+
+	if err := a.validateScheduleFields(); err != nil {
+		return err
+	}
+
+	if err := a.ApplyConcourseProperties(config.ConcourseProperties{}).Validate(); err != nil {
+		return err
+	}
+
+	if err := a.ApplySelfUpdate(config.SelfUpdate{}).Validate(); err != nil {
+		return err
+	}
+
+	if err := a.ApplyMaintenanceWindow(config.MaintenanceWindow{}).Validate(); err != nil {
+		return err
+	}
+
+	if err := a.ApplyNotifications(config.Notifications{}).Validate(); err != nil {
+		return err
+	}
+
+	if err := a.ApplyProxy(config.Proxy{}).Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -201,6 +719,10 @@ func (a Args) validateWorkerFields() error {
 }
 
 func (a Args) validateWebFields() error {
+	if a.WebCount < 1 {
+		return errors.New("minimum number of web nodes is 1")
+	}
+
 	for _, size := range WebSizes {
 		if size == a.WebSize {
 			return nil
@@ -229,6 +751,121 @@ func (a Args) validateGithubFields() error {
 	return nil
 }
 
+func (a Args) validateMainTeamGithubTeams() error {
+	for _, team := range a.MainTeamGithubTeams {
+		m, err := regexp.MatchString(`^[^:]+:[^:]+$`, team)
+		if err != nil {
+			return err
+		}
+		if !m {
+			return fmt.Errorf("`%v` is not in the format `org:team`", team)
+		}
+	}
+	return nil
+}
+
+func (a Args) validateOIDCFields() error {
+	if a.OIDCAuthIssuer != "" || a.OIDCAuthClientID != "" || a.OIDCAuthClientSecret != "" {
+		if a.OIDCAuthIssuer == "" || a.OIDCAuthClientID == "" || a.OIDCAuthClientSecret == "" {
+			return errors.New("--oidc-auth-issuer, --oidc-auth-client-id and --oidc-auth-client-secret are all required when any is provided")
+		}
+	}
+
+	return nil
+}
+
+func (a Args) validateGitLabFields() error {
+	if a.GitLabAuthClientID != "" && a.GitLabAuthClientSecret == "" {
+		return errors.New("--gitlab-auth-client-id requires --gitlab-auth-client-secret to also be provided")
+	}
+	if a.GitLabAuthClientID == "" && a.GitLabAuthClientSecret != "" {
+		return errors.New("--gitlab-auth-client-secret requires --gitlab-auth-client-id to also be provided")
+	}
+
+	return nil
+}
+
+func (a Args) validateBitbucketCloudFields() error {
+	if a.BitbucketCloudAuthClientID != "" && a.BitbucketCloudAuthClientSecret == "" {
+		return errors.New("--bitbucket-cloud-auth-client-id requires --bitbucket-cloud-auth-client-secret to also be provided")
+	}
+	if a.BitbucketCloudAuthClientID == "" && a.BitbucketCloudAuthClientSecret != "" {
+		return errors.New("--bitbucket-cloud-auth-client-secret requires --bitbucket-cloud-auth-client-id to also be provided")
+	}
+
+	return nil
+}
+
+func (a Args) validateLDAPFields() error {
+	if a.LDAPAuthHost != "" || a.LDAPAuthBindDN != "" || a.LDAPAuthBindPassword != "" || a.LDAPAuthUserSearchBaseDN != "" {
+		if a.LDAPAuthHost == "" || a.LDAPAuthBindDN == "" || a.LDAPAuthBindPassword == "" || a.LDAPAuthUserSearchBaseDN == "" {
+			return errors.New("--ldap-auth-host, --ldap-auth-bind-dn, --ldap-auth-bind-password and --ldap-auth-user-search-base-dn are all required when any is provided")
+		}
+	}
+
+	return nil
+}
+
+// validateMainTeamConnectors rejects main team members for a connector that is not configured in the same deploy
+func (a Args) validateMainTeamConnectors() error {
+	type memberFlag struct {
+		name    string
+		members []string
+	}
+	connectors := []struct {
+		isSet   bool
+		flags   string
+		members []memberFlag
+	}{
+		{a.GithubAuthIsSet, "--github-auth-client-id and --github-auth-client-secret", []memberFlag{
+			{"--main-team-github-orgs", a.MainTeamGithubOrgs},
+			{"--main-team-github-teams", a.MainTeamGithubTeams},
+			{"--main-team-github-users", a.MainTeamGithubUsers},
+		}},
+		{a.OIDCAuthIsSet, "--oidc-auth-issuer, --oidc-auth-client-id and --oidc-auth-client-secret", []memberFlag{
+			{"--main-team-oidc-groups", a.MainTeamOIDCGroups},
+			{"--main-team-oidc-users", a.MainTeamOIDCUsers},
+		}},
+		{a.GitLabAuthIsSet, "--gitlab-auth-client-id and --gitlab-auth-client-secret", []memberFlag{
+			{"--main-team-gitlab-groups", a.MainTeamGitLabGroups},
+			{"--main-team-gitlab-users", a.MainTeamGitLabUsers},
+		}},
+		{a.BitbucketCloudAuthIsSet, "--bitbucket-cloud-auth-client-id and --bitbucket-cloud-auth-client-secret", []memberFlag{
+			{"--main-team-bitbucket-cloud-teams", a.MainTeamBitbucketCloudTeams},
+			{"--main-team-bitbucket-cloud-users", a.MainTeamBitbucketCloudUsers},
+		}},
+		{a.LDAPAuthIsSet, "--ldap-auth-host, --ldap-auth-bind-dn, --ldap-auth-bind-password and --ldap-auth-user-search-base-dn", []memberFlag{
+			{"--main-team-ldap-groups", a.MainTeamLDAPGroups},
+			{"--main-team-ldap-users", a.MainTeamLDAPUsers},
+		}},
+	}
+	for _, connector := range connectors {
+		if connector.isSet {
+			continue
+		}
+		for _, flag := range connector.members {
+			for _, member := range flag.members {
+				// An empty member only clears the list, which needs no connector
+				if member != "" {
+					return fmt.Errorf("%s requires %s to also be provided", flag.name, connector.flags)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a Args) validateTeamsFields() error {
+	if a.TeamsFileIsSet && a.UnmanageTeams {
+		return errors.New("--teams-file and --unmanage-teams cannot be used together")
+	}
+	if a.PruneAllTeams && !a.TeamsFileIsSet {
+		return errors.New("--prune-all-teams requires --teams-file to also be provided")
+	}
+
+	return nil
+}
+
 func (a Args) validateNetworkRanges() error {
 	if a.PublicCIDR != "" || a.PrivateCIDR != "" {
 		if a.PublicCIDR == "" || a.PrivateCIDR == "" {
@@ -252,6 +889,110 @@ func (a Args) validateTags() error {
 	return nil
 }
 
+func (a Args) validateStemcellOS() error {
+	for _, os := range StemcellOSes {
+		if os != a.StemcellOS {
+			continue
+		}
+		// control-tower-ops pins the default line, and control-tower pins the others
+		if os == config.DefaultStemcellOS {
+			return nil
+		}
+		_, err := resource.FindStemcellVersion(resource.StemcellVersions, os)
+		return err
+	}
+	return fmt.Errorf("unknown stemcell OS: `%s`. Valid stemcell OSes are: %v", a.StemcellOS, StemcellOSes)
+}
+
+func (a Args) validateVaultFields() error {
+	if !isOneOf(a.CredentialManager, CredentialManagers) {
+		return fmt.Errorf("unknown credential manager: `%s`. Valid credential managers are: %v", a.CredentialManager, CredentialManagers)
+	}
+	if a.VaultAuthBackend != "" && !isOneOf(a.VaultAuthBackend, VaultAuthBackends) {
+		return fmt.Errorf("unknown Vault auth backend: `%s`. Valid auth backends are: %v", a.VaultAuthBackend, VaultAuthBackends)
+	}
+
+	vaultFlagsSet := a.VaultURL != "" || a.VaultAuthBackend != "" || a.VaultRoleID != "" || a.VaultSecretID != "" ||
+		a.VaultClientCert != "" || a.VaultClientKey != "" || a.VaultPathPrefix != "" || a.VaultCACert != ""
+	if vaultFlagsSet && a.CredentialManagerIsSet && a.CredentialManager != "vault" {
+		return errors.New("--vault-* flags can only be used with --credential-manager vault")
+	}
+
+	if (a.VaultRoleID == "") != (a.VaultSecretID == "") {
+		return errors.New("--vault-approle-role-id and --vault-approle-secret-id are both required when either is provided")
+	}
+	if (a.VaultClientCert == "") != (a.VaultClientKey == "") {
+		return errors.New("--vault-client-cert and --vault-client-key are both required when either is provided")
+	}
+
+	return nil
+}
+
+func (a Args) validateAWSCredentialFields() error {
+	if a.AWSCredentialPathPrefix == "" {
+		return nil
+	}
+	if a.CredentialManagerIsSet && a.CredentialManager != "aws-secretsmanager" && a.CredentialManager != "aws-ssm" {
+		return errors.New("--aws-credential-path-prefix can only be used with --credential-manager aws-secretsmanager or aws-ssm")
+	}
+	if !regexp.MustCompile(`^(/[\w.-]+)+$`).MatchString(a.AWSCredentialPathPrefix) {
+		return fmt.Errorf("`%s` is not a valid credential path prefix. It must start with / and must not end with /", a.AWSCredentialPathPrefix)
+	}
+
+	return nil
+}
+
+func (a Args) validateMetricsFields() error {
+	if !isOneOf(a.Metrics, MetricsBackends) {
+		return fmt.Errorf("unknown metrics backend: `%s`. Valid metrics backends are: %v", a.Metrics, MetricsBackends)
+	}
+	if a.MetricsIsSet && a.Metrics != "prometheus" && (a.MetricsAllowIPsIsSet || a.DisableGrafana) {
+		return errors.New("--metrics-allow-ips and --disable-grafana can only be used with --metrics prometheus")
+	}
+	if a.GrafanaDashboardsDirIsSet && a.DisableGrafana {
+		return errors.New("--grafana-dashboards-dir cannot be used with --disable-grafana")
+	}
+
+	return nil
+}
+
+func (a Args) validateSyslogFields() error {
+	if !isOneOf(a.SyslogTransport, SyslogTransports) {
+		return fmt.Errorf("unknown syslog transport: `%s`. Valid transports are: %v", a.SyslogTransport, SyslogTransports)
+	}
+	if a.SyslogAddress == "" {
+		if a.SyslogTransportIsSet || a.SyslogCACert != "" || a.SyslogPermittedPeer != "" {
+			return errors.New("--syslog-transport, --syslog-ca-cert and --syslog-permitted-peer require --syslog-address")
+		}
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(a.SyslogAddress)
+	if err != nil || host == "" {
+		return fmt.Errorf("`%s` is not a valid syslog address. It must be in the form host:port", a.SyslogAddress)
+	}
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("`%s` is not a valid syslog port", port)
+	}
+	if a.SyslogCACert != "" && a.SyslogTransport == "udp" {
+		return errors.New("--syslog-ca-cert cannot be used with --syslog-transport udp")
+	}
+	if a.SyslogPermittedPeer != "" && a.SyslogCACert == "" {
+		return errors.New("--syslog-permitted-peer requires --syslog-ca-cert")
+	}
+
+	return nil
+}
+
+func isOneOf(value string, permitted []string) bool {
+	for _, p := range permitted {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
+
 // FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
 type FlagSetChecker interface {
 	IsSet(name string) bool
@@ -272,3 +1013,200 @@ func (t *ContextWrapper) IsSet(name string) bool {
 func (t *ContextWrapper) FlagNames() (names []string) {
 	return t.c.FlagNames()
 }
+
+func (a Args) validateWorkerPoolFields() error {
+	names := map[string]bool{}
+	for _, spec := range a.WorkerPools {
+		pool, err := config.ParseWorkerPool(spec)
+		if err != nil {
+			return err
+		}
+		if !isOneOf(pool.Size, WorkerSizes) {
+			return fmt.Errorf("unknown worker size `%s` for worker pool `%s`. Valid sizes are: %v", pool.Size, pool.Name, WorkerSizes)
+		}
+		if names[pool.Name] {
+			return fmt.Errorf("worker pool `%s` is given more than once", pool.Name)
+		}
+		names[pool.Name] = true
+	}
+	return nil
+}
+
+func (a Args) validateAutoscaleFields() error {
+	if a.AutoscaleMinWorkersIsSet && a.AutoscaleMinWorkers < 1 {
+		return errors.New("--autoscale-min-workers must be at least 1")
+	}
+	if a.AutoscaleMaxWorkersIsSet && a.AutoscaleMaxWorkers < 0 {
+		return errors.New("--autoscale-max-workers cannot be negative")
+	}
+	if (a.AutoscaleHighContainersIsSet && a.AutoscaleHighContainers < 1) || (a.AutoscaleLowContainersIsSet && a.AutoscaleLowContainers < 0) {
+		return errors.New("--autoscale-high-containers must be at least 1 and --autoscale-low-containers cannot be negative")
+	}
+	if (a.AutoscaleHighVolumesIsSet && a.AutoscaleHighVolumes < 1) || (a.AutoscaleLowVolumesIsSet && a.AutoscaleLowVolumes < 0) {
+		return errors.New("--autoscale-high-volumes must be at least 1 and --autoscale-low-volumes cannot be negative")
+	}
+	if a.AutoscaleCooldownIsSet {
+		if cooldown, err := time.ParseDuration(a.AutoscaleCooldown); err != nil || cooldown < 0 {
+			return fmt.Errorf("--autoscale-cooldown `%s` is not a duration such as 15m", a.AutoscaleCooldown)
+		}
+	}
+	return nil
+}
+
+func (a Args) validateScheduleFields() error {
+	// An empty expression removes the schedule
+	if a.ScheduleDownIsSet && a.ScheduleDown != "" {
+		if _, err := cron.Parse(a.ScheduleDown); err != nil {
+			return fmt.Errorf("--schedule-down: [%v]", err)
+		}
+	}
+	if a.ScheduleUpIsSet && a.ScheduleUp != "" {
+		if _, err := cron.Parse(a.ScheduleUp); err != nil {
+			return fmt.Errorf("--schedule-up: [%v]", err)
+		}
+	}
+	if a.ScheduleTimezoneIsSet {
+		if _, err := time.LoadLocation(a.ScheduleTimezone); err != nil {
+			return fmt.Errorf("--schedule-timezone `%s` is not a known timezone such as Europe/London", a.ScheduleTimezone)
+		}
+	}
+	if a.ScheduleWorkersIsSet && a.ScheduleWorkers < 0 {
+		return errors.New("--schedule-workers cannot be negative")
+	}
+	return nil
+}
+
+// ApplyConcourseProperties returns p with the Concourse properties given as flags replacing its own.
+// Empty feature flags are dropped, so that `--feature-flag ""` disables them all
+func (a Args) ApplyConcourseProperties(p config.ConcourseProperties) config.ConcourseProperties {
+	if a.BuildLogsToRetainIsSet {
+		p.BuildLogsToRetain = a.BuildLogsToRetain
+	}
+	if a.ContainerPlacementStrategyIsSet {
+		p.ContainerPlacementStrategy = a.ContainerPlacementStrategy
+	}
+	if a.DefaultTaskCPULimitIsSet {
+		p.DefaultTaskCPULimit = a.DefaultTaskCPULimit
+	}
+	if a.DefaultTaskMemoryLimitIsSet {
+		p.DefaultTaskMemoryLimit = a.DefaultTaskMemoryLimit
+	}
+	if a.FeatureFlagsIsSet {
+		p.FeatureFlags = nonEmpty(a.FeatureFlags)
+	}
+	if a.GCIntervalIsSet {
+		p.GCInterval = a.GCInterval
+	}
+	if a.MaxContainersPerWorkerIsSet {
+		p.MaxContainersPerWorker = a.MaxContainersPerWorker
+	}
+	if a.ResourceCheckingIntervalIsSet {
+		p.ResourceCheckingInterval = a.ResourceCheckingInterval
+	}
+	if a.ResourceWebhookCheckingIntervalIsSet {
+		p.ResourceWebhookCheckingInterval = a.ResourceWebhookCheckingInterval
+	}
+	return p
+}
+
+// ApplySelfUpdate returns s with the self-update settings given as flags replacing its own
+func (a Args) ApplySelfUpdate(s config.SelfUpdate) config.SelfUpdate {
+	if a.SelfUpdateRepositoryIsSet {
+		s.Repository = a.SelfUpdateRepository
+	}
+	if a.SelfUpdateGitHubAPIURLIsSet {
+		s.GitHubAPIURL = a.SelfUpdateGitHubAPIURL
+	}
+	if a.SelfUpdateGitHubTokenIsSet {
+		s.GitHubAccessToken = a.SelfUpdateGitHubToken
+		s.GitHubAccessTokenInCredhub = a.SelfUpdateGitHubToken != ""
+	}
+	if a.SelfUpdateS3BucketIsSet {
+		s.S3Bucket = a.SelfUpdateS3Bucket
+	}
+	if a.SelfUpdateS3RegionIsSet {
+		s.S3Region = a.SelfUpdateS3Region
+	}
+	if a.SelfUpdateVersionConstraintIsSet {
+		s.VersionConstraint = a.SelfUpdateVersionConstraint
+	}
+	if a.SelfUpdateStableOnlyIsSet {
+		s.StableOnly = a.SelfUpdateStableOnly
+	}
+	if a.SelfUpdateApprovalIsSet {
+		s.Approval = a.SelfUpdateApproval
+	}
+	return s
+}
+
+// ApplyMaintenanceWindow returns w with the maintenance window settings given as flags replacing its own.
+// Empty days are dropped, so that `--maintenance-window-day ""` opens the window on every day
+func (a Args) ApplyMaintenanceWindow(w config.MaintenanceWindow) config.MaintenanceWindow {
+	if a.MaintenanceWindowStartIsSet {
+		w.Start = a.MaintenanceWindowStart
+	}
+	if a.MaintenanceWindowStopIsSet {
+		w.Stop = a.MaintenanceWindowStop
+	}
+	if a.MaintenanceWindowDaysIsSet {
+		w.Days = nonEmpty(a.MaintenanceWindowDays)
+	}
+	if a.MaintenanceWindowLocationIsSet || w.Location == "" {
+		w.Location = a.MaintenanceWindowLocation
+	}
+	return w
+}
+
+// ApplyNotifications returns n with the notification settings given as flags replacing its own.
+// Empty addresses and events are dropped, so that giving them as "" removes them
+func (a Args) ApplyNotifications(n config.Notifications) config.Notifications {
+	if a.NotifyWebhookURLIsSet {
+		n.WebhookURL = a.NotifyWebhookURL
+	}
+	if a.NotifySlackWebhookURLIsSet {
+		n.SlackWebhookURL = a.NotifySlackWebhookURL
+	}
+	if a.NotifySMTPHostIsSet {
+		n.SMTPHost = a.NotifySMTPHost
+	}
+	if a.NotifySMTPUsernameIsSet {
+		n.SMTPUsername = a.NotifySMTPUsername
+	}
+	if a.NotifySMTPPasswordIsSet {
+		n.SMTPPassword = a.NotifySMTPPassword
+	}
+	if a.NotifySMTPFromIsSet {
+		n.SMTPFrom = a.NotifySMTPFrom
+	}
+	if a.NotifySMTPToIsSet {
+		n.SMTPTo = nonEmpty(a.NotifySMTPTo)
+	}
+	if a.NotifyOnIsSet {
+		n.Events = nonEmpty(a.NotifyOn)
+	}
+	return n
+}
+
+// ApplyProxy returns p with the proxy settings given as flags replacing its own, so that giving them as "" removes them
+func (a Args) ApplyProxy(p config.Proxy) config.Proxy {
+	if a.HTTPProxyIsSet {
+		p.HTTPProxy = a.HTTPProxy
+	}
+	if a.HTTPSProxyIsSet {
+		p.HTTPSProxy = a.HTTPSProxy
+	}
+	if a.NoProxyIsSet {
+		p.NoProxy = nonEmpty(a.NoProxy)
+	}
+	return p
+}
+
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
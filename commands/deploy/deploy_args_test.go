@@ -2,15 +2,18 @@ package deploy_test
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	. "github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/config"
 )
 
 func TestDeployArgs_Validate(t *testing.T) {
 	defaultFields := Args{
 		AllowIPs:               "0.0.0.0",
+		CredentialManager:      "credhub",
 		Region:                 "eu-west-1",
 		DBSize:                 "small",
 		DBSizeIsSet:            false,
@@ -19,9 +22,13 @@ func TestDeployArgs_Validate(t *testing.T) {
 		GithubAuthClientSecret: "",
 		IAAS:                   "AWS",
 		IAASIsSet:              true,
+		Metrics:                "influxdb",
 		SelfUpdate:             false,
+		StemcellOS:             "xenial",
+		SyslogTransport:        "tcp",
 		TLSCert:                "",
 		TLSKey:                 "",
+		WebCount:               1,
 		WebSize:                "small",
 		WorkerCount:            1,
 		WorkerSize:             "xlarge",
@@ -114,6 +121,16 @@ func TestDeployArgs_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: fmt.Sprintf("unknown worker size: `bananas`. Valid sizes are: %v", WorkerSizes),
 		},
+		{
+			name: "Web count must be positive",
+			modification: func() Args {
+				args := defaultFields
+				args.WebCount = 0
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "minimum number of web nodes is 1",
+		},
 		{
 			name: "Web size must be a known value",
 			modification: func() Args {
@@ -154,6 +171,128 @@ func TestDeployArgs_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "--github-auth-client-secret requires --github-auth-client-id to also be provided",
 		},
+		{
+			name: "Main team GitHub teams should be in the format 'org:team'",
+			modification: func() Args {
+				args := defaultFields
+				args.GithubAuthIsSet = true
+				args.MainTeamGithubTeams = []string{"EngineerBetter:ops", "EngineerBetter:dev"}
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Main team GitHub teams without an org should throw a helpful error",
+			modification: func() Args {
+				args := defaultFields
+				args.MainTeamGithubTeams = []string{"ops"}
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "`ops` is not in the format `org:team`",
+		},
+		{
+			name: "Main team GitHub members require the GitHub connector",
+			modification: func() Args {
+				args := defaultFields
+				args.MainTeamGithubUsers = []string{"octocat"}
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--main-team-github-users requires --github-auth-client-id and --github-auth-client-secret to also be provided",
+		},
+		{
+			name: "Main team LDAP members require the LDAP connector",
+			modification: func() Args {
+				args := defaultFields
+				args.MainTeamLDAPGroups = []string{"cn=admins,dc=example,dc=com"}
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--main-team-ldap-groups requires --ldap-auth-host, --ldap-auth-bind-dn, --ldap-auth-bind-password and --ldap-auth-user-search-base-dn to also be provided",
+		},
+		{
+			name: "Main team members can be cleared without their connector",
+			modification: func() Args {
+				args := defaultFields
+				args.MainTeamOIDCGroups = []string{""}
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Main team members are accepted with their connector",
+			modification: func() Args {
+				args := defaultFields
+				args.OIDCAuthIsSet = true
+				args.MainTeamOIDCGroups = []string{"admins"}
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "OIDC issuer requires OIDC client ID and secret",
+			modification: func() Args {
+				args := defaultFields
+				args.OIDCAuthIssuer = "https://example.okta.com"
+				args.OIDCAuthClientID = "an id"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--oidc-auth-issuer, --oidc-auth-client-id and --oidc-auth-client-secret are all required when any is provided",
+		},
+		{
+			name: "GitLab ID requires GitLab Secret",
+			modification: func() Args {
+				args := defaultFields
+				args.GitLabAuthClientID = "an id"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--gitlab-auth-client-id requires --gitlab-auth-client-secret to also be provided",
+		},
+		{
+			name: "Bitbucket Cloud Secret requires Bitbucket Cloud ID",
+			modification: func() Args {
+				args := defaultFields
+				args.BitbucketCloudAuthClientSecret = "super secret"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--bitbucket-cloud-auth-client-secret requires --bitbucket-cloud-auth-client-id to also be provided",
+		},
+		{
+			name: "LDAP host requires the bind and user search fields",
+			modification: func() Args {
+				args := defaultFields
+				args.LDAPAuthHost = "ldap.example.com:636"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--ldap-auth-host, --ldap-auth-bind-dn, --ldap-auth-bind-password and --ldap-auth-user-search-base-dn are all required when any is provided",
+		},
+		{
+			name: "Teams file cannot be given when unmanaging teams",
+			modification: func() Args {
+				args := defaultFields
+				args.TeamsFile = "teams.yml"
+				args.TeamsFileIsSet = true
+				args.UnmanageTeams = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--teams-file and --unmanage-teams cannot be used together",
+		},
+		{
+			name: "Pruning all teams requires a teams file",
+			modification: func() Args {
+				args := defaultFields
+				args.PruneAllTeams = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--prune-all-teams requires --teams-file to also be provided",
+		},
 		{
 			name: "Tags should be in the format 'key=value'",
 			modification: func() Args {
@@ -182,6 +321,439 @@ func TestDeployArgs_Validate(t *testing.T) {
 			},
 			wantErr:     true,
 			expectedErr: "both --public-subnet-range and --private-subnet-range are required when either is provided",
+		},
+		{
+			name: "Stemcell OS must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.StemcellOS = "trusty"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown stemcell OS: `trusty`. Valid stemcell OSes are: %v", StemcellOSes),
+		},
+		{
+			name: "Stemcell OS can be a pinned non-default line",
+			modification: func() Args {
+				args := defaultFields
+				args.StemcellOS = "bionic"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Vault with approle auth",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManager = "vault"
+				args.CredentialManagerIsSet = true
+				args.VaultURL = "https://vault.example.com:8200"
+				args.VaultAuthBackend = "approle"
+				args.VaultRoleID = "a role"
+				args.VaultSecretID = "a secret"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Credential manager must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManager = "keywhiz"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown credential manager: `keywhiz`. Valid credential managers are: %v", CredentialManagers),
+		},
+		{
+			name: "Vault auth backend must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.VaultAuthBackend = "token"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown Vault auth backend: `token`. Valid auth backends are: %v", VaultAuthBackends),
+		},
+		{
+			name: "Vault flags cannot be used with credhub",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManagerIsSet = true
+				args.VaultURL = "https://vault.example.com:8200"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--vault-* flags can only be used with --credential-manager vault",
+		},
+		{
+			name: "Vault client cert cannot be set without client key",
+			modification: func() Args {
+				args := defaultFields
+				args.VaultClientCert = "a cool cert"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--vault-client-cert and --vault-client-key are both required when either is provided",
+		},
+		{
+			name: "AWS credential path prefix with aws-ssm",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManager = "aws-ssm"
+				args.CredentialManagerIsSet = true
+				args.AWSCredentialPathPrefix = "/ci/secrets"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "AWS credential path prefix must start with a slash",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManager = "aws-secretsmanager"
+				args.CredentialManagerIsSet = true
+				args.AWSCredentialPathPrefix = "concourse/"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "`concourse/` is not a valid credential path prefix. It must start with / and must not end with /",
+		},
+		{
+			name: "AWS credential path prefix cannot be used with credhub",
+			modification: func() Args {
+				args := defaultFields
+				args.CredentialManagerIsSet = true
+				args.AWSCredentialPathPrefix = "/concourse"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--aws-credential-path-prefix can only be used with --credential-manager aws-secretsmanager or aws-ssm",
+		},
+		{
+			name: "Prometheus metrics with scrape IPs",
+			modification: func() Args {
+				args := defaultFields
+				args.Metrics = "prometheus"
+				args.MetricsIsSet = true
+				args.MetricsAllowIPs = "10.0.0.0/8"
+				args.MetricsAllowIPsIsSet = true
+				args.DisableGrafana = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Metrics backend must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.Metrics = "statsd"
+				args.MetricsIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown metrics backend: `statsd`. Valid metrics backends are: %v", MetricsBackends),
+		},
+		{
+			name: "Disabling Grafana cannot be used with influxdb",
+			modification: func() Args {
+				args := defaultFields
+				args.MetricsIsSet = true
+				args.DisableGrafana = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--metrics-allow-ips and --disable-grafana can only be used with --metrics prometheus",
+		},
+		{
+			name: "Grafana dashboards cannot be used when Grafana is disabled",
+			modification: func() Args {
+				args := defaultFields
+				args.Metrics = "prometheus"
+				args.MetricsIsSet = true
+				args.DisableGrafana = true
+				args.GrafanaDashboardsDir = "dashboards"
+				args.GrafanaDashboardsDirIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--grafana-dashboards-dir cannot be used with --disable-grafana",
+		},
+		{
+			name: "Syslog over TLS",
+			modification: func() Args {
+				args := defaultFields
+				args.SyslogAddress = "logs.example.com:6514"
+				args.SyslogCACert = "a cool cert"
+				args.SyslogPermittedPeer = "*.example.com"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Syslog address must include a port",
+			modification: func() Args {
+				args := defaultFields
+				args.SyslogAddress = "logs.example.com"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "`logs.example.com` is not a valid syslog address. It must be in the form host:port",
+		},
+		{
+			name: "Syslog transport must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.SyslogAddress = "logs.example.com:514"
+				args.SyslogTransport = "http"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown syslog transport: `http`. Valid transports are: %v", SyslogTransports),
+		},
+		{
+			name: "Syslog TLS cannot be used over udp",
+			modification: func() Args {
+				args := defaultFields
+				args.SyslogAddress = "logs.example.com:514"
+				args.SyslogTransport = "udp"
+				args.SyslogCACert = "a cool cert"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--syslog-ca-cert cannot be used with --syslog-transport udp",
+		},
+		{
+			name: "Syslog CA cert requires an address",
+			modification: func() Args {
+				args := defaultFields
+				args.SyslogCACert = "a cool cert"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--syslog-transport, --syslog-ca-cert and --syslog-permitted-peer require --syslog-address",
+		},
+		{
+			name: "Worker pools",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerPools = []string{"deploy:1:large:on-demand:tag=deploy:team=ops", "builds:4:2xlarge:spot"}
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Worker pool size must be a known value",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerPools = []string{"builds:4:bananas"}
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown worker size `bananas` for worker pool `builds`. Valid sizes are: %v", WorkerSizes),
+		},
+		{
+			name: "Worker pool names must be unique",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerPools = []string{"builds:4:large", "builds:2:xlarge"}
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "worker pool `builds` is given more than once",
+		},
+		{
+			name: "Autoscaling",
+			modification: func() Args {
+				args := defaultFields
+				args.AutoscaleMaxWorkers = 10
+				args.AutoscaleMaxWorkersIsSet = true
+				args.AutoscaleCooldown = "30m"
+				args.AutoscaleCooldownIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Autoscale min workers must be at least 1",
+			modification: func() Args {
+				args := defaultFields
+				args.AutoscaleMinWorkers = 0
+				args.AutoscaleMinWorkersIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--autoscale-min-workers must be at least 1",
+		},
+		{
+			name: "Autoscale cooldown must be a duration",
+			modification: func() Args {
+				args := defaultFields
+				args.AutoscaleCooldown = "soon"
+				args.AutoscaleCooldownIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--autoscale-cooldown `soon` is not a duration such as 15m",
+		},
+		{
+			name: "Schedule can be set",
+			modification: func() Args {
+				args := defaultFields
+				args.ScheduleDown = "0 20 * * 1-5"
+				args.ScheduleDownIsSet = true
+				args.ScheduleUp = "0 7 * * mon-fri"
+				args.ScheduleUpIsSet = true
+				args.ScheduleTimezone = "Europe/London"
+				args.ScheduleTimezoneIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Schedule down must be a cron expression",
+			modification: func() Args {
+				args := defaultFields
+				args.ScheduleDown = "8pm"
+				args.ScheduleDownIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--schedule-down: [cron expression `8pm` must have 5 fields: minute hour day-of-month month day-of-week]",
+		},
+		{
+			name: "Schedule timezone must be known",
+			modification: func() Args {
+				args := defaultFields
+				args.ScheduleTimezone = "Mars/Olympus"
+				args.ScheduleTimezoneIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--schedule-timezone `Mars/Olympus` is not a known timezone such as Europe/London",
+		},
+		{
+			name: "Concourse properties can be set",
+			modification: func() Args {
+				args := defaultFields
+				args.ContainerPlacementStrategy = "fewest-build-containers"
+				args.ContainerPlacementStrategyIsSet = true
+				args.FeatureFlags = []string{"across-step"}
+				args.FeatureFlagsIsSet = true
+				args.GCInterval = "1m"
+				args.GCIntervalIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Feature flags must be known",
+			modification: func() Args {
+				args := defaultFields
+				args.FeatureFlags = []string{"time-travel"}
+				args.FeatureFlagsIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "unknown feature flag `time-travel`",
+		},
+		{
+			name: "Max containers per worker cannot be negative",
+			modification: func() Args {
+				args := defaultFields
+				args.MaxContainersPerWorker = -5
+				args.MaxContainersPerWorkerIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "max containers per worker cannot be negative",
+		},
+		{
+			name: "Self-update settings can be set",
+			modification: func() Args {
+				args := defaultFields
+				args.SelfUpdateRepository = "platform/control-tower"
+				args.SelfUpdateRepositoryIsSet = true
+				args.SelfUpdateVersionConstraint = "~1.4"
+				args.SelfUpdateVersionConstraintIsSet = true
+				args.SelfUpdateApproval = true
+				args.SelfUpdateApprovalIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Self-update version constraint must be a version",
+			modification: func() Args {
+				args := defaultFields
+				args.SelfUpdateVersionConstraint = "latest"
+				args.SelfUpdateVersionConstraintIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "self-update version constraint `latest` must be a version such as 1.4, ~1.4.2 or ^1",
+		},
+		{
+			name: "Self-update releases cannot come from both S3 and GitHub",
+			modification: func() Args {
+				args := defaultFields
+				args.SelfUpdateS3Bucket = "releases"
+				args.SelfUpdateS3BucketIsSet = true
+				args.SelfUpdateGitHubToken = "token"
+				args.SelfUpdateGitHubTokenIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "self-update releases can come from an S3 bucket or a GitHub repository, but not both",
+		},
+		{
+			name: "Maintenance window can be set",
+			modification: func() Args {
+				args := defaultFields
+				args.MaintenanceWindowStart = "1:00 AM"
+				args.MaintenanceWindowStartIsSet = true
+				args.MaintenanceWindowStop = "4:00 AM"
+				args.MaintenanceWindowStopIsSet = true
+				args.MaintenanceWindowDays = []string{"Saturday"}
+				args.MaintenanceWindowDaysIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Maintenance window days must be known",
+			modification: func() Args {
+				args := defaultFields
+				args.MaintenanceWindowDays = []string{"Caturday"}
+				args.MaintenanceWindowDaysIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "unknown maintenance window day `Caturday`",
+		},
+		{
+			name: "Notification events must be known",
+			modification: func() Args {
+				args := defaultFields
+				args.NotifySlackWebhookURL = "https://hooks.slack.com/services/T000/B000/XXXX"
+				args.NotifySlackWebhookURLIsSet = true
+				args.NotifyOn = []string{"failure", "timeout"}
+				args.NotifyOnIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "unknown notification event `timeout`",
+		},
+		{
+			name: "Proxy must be a URL",
+			modification: func() Args {
+				args := defaultFields
+				args.HTTPSProxy = "proxy.example.com:3128"
+				args.HTTPSProxyIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "HTTPS proxy `proxy.example.com:3128` must be an http or https URL",
 		}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -203,6 +775,115 @@ func TestDeployArgs_Validate(t *testing.T) {
 	}
 }
 
+func TestDeployArgs_ApplyConcourseProperties(t *testing.T) {
+	existing := config.ConcourseProperties{
+		BuildLogsToRetain: 20,
+		FeatureFlags:      []string{"redact-secrets"},
+		GCInterval:        "30s",
+	}
+	// GCInterval is not marked as set, so the existing value is kept
+	args := Args{
+		BuildLogsToRetain:           0,
+		BuildLogsToRetainIsSet:      true,
+		FeatureFlags:                []string{""},
+		FeatureFlagsIsSet:           true,
+		MaxContainersPerWorker:      100,
+		MaxContainersPerWorkerIsSet: true,
+		GCInterval:                  "1m",
+	}
+
+	want := config.ConcourseProperties{
+		GCInterval:             "30s",
+		MaxContainersPerWorker: 100,
+	}
+	if got := args.ApplyConcourseProperties(existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeployArgs.ApplyConcourseProperties() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDeployArgs_ApplySelfUpdate(t *testing.T) {
+	existing := config.SelfUpdate{
+		Approval:          true,
+		Repository:        "platform/control-tower",
+		VersionConstraint: "~1.4",
+	}
+	// SelfUpdateRepository is not marked as set, so the existing value is kept
+	args := Args{
+		SelfUpdateApproval:               false,
+		SelfUpdateApprovalIsSet:          true,
+		SelfUpdateGitHubToken:            "token",
+		SelfUpdateGitHubTokenIsSet:       true,
+		SelfUpdateRepository:             "someone/else",
+		SelfUpdateStableOnly:             true,
+		SelfUpdateStableOnlyIsSet:        true,
+		SelfUpdateVersionConstraint:      "",
+		SelfUpdateVersionConstraintIsSet: true,
+	}
+
+	want := config.SelfUpdate{
+		GitHubAccessToken:          "token",
+		GitHubAccessTokenInCredhub: true,
+		Repository:                 "platform/control-tower",
+		StableOnly:                 true,
+	}
+	if got := args.ApplySelfUpdate(existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeployArgs.ApplySelfUpdate() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDeployArgs_ApplyNotifications(t *testing.T) {
+	existing := config.Notifications{
+		Events:     []string{"success"},
+		SMTPHost:   "smtp.example.com:587",
+		SMTPFrom:   "concourse@example.com",
+		SMTPTo:     []string{"ops@example.com"},
+		WebhookURL: "https://alerts.example.com",
+	}
+	// NotifySMTPFrom is not marked as set, so the existing value is kept
+	args := Args{
+		NotifyOn:              []string{""},
+		NotifyOnIsSet:         true,
+		NotifySMTPFrom:        "someone@example.com",
+		NotifySMTPTo:          []string{"ops@example.com", "oncall@example.com"},
+		NotifySMTPToIsSet:     true,
+		NotifyWebhookURL:      "",
+		NotifyWebhookURLIsSet: true,
+	}
+
+	want := config.Notifications{
+		SMTPHost: "smtp.example.com:587",
+		SMTPFrom: "concourse@example.com",
+		SMTPTo:   []string{"ops@example.com", "oncall@example.com"},
+	}
+	if got := args.ApplyNotifications(existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeployArgs.ApplyNotifications() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDeployArgs_ApplyProxy(t *testing.T) {
+	existing := config.Proxy{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    []string{"localhost"},
+	}
+	// HTTPProxy is not marked as set, so the existing value is kept
+	args := Args{
+		HTTPProxy:       "http://other.example.com:3128",
+		HTTPSProxy:      "",
+		HTTPSProxyIsSet: true,
+		NoProxy:         []string{"localhost", ".internal"},
+		NoProxyIsSet:    true,
+	}
+
+	want := config.Proxy{
+		HTTPProxy: "http://proxy.example.com:3128",
+		NoProxy:   []string{"localhost", ".internal"},
+	}
+	if got := args.ApplyProxy(existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeployArgs.ApplyProxy() = %#v, want %#v", got, want)
+	}
+}
+
 func TestDeployArgs_MarkSetFlags(t *testing.T) {
 	tests := []struct {
 		name                    string
@@ -199,6 +199,41 @@ func Test_validateNameLength(t *testing.T) {
 	}
 }
 
+func Test_validateCredentialManager(t *testing.T) {
+	tests := []struct {
+		name              string
+		credentialManager string
+		providerName      iaas.Name
+		wantErr           bool
+	}{
+		{
+			name:              "It is AWS with an AWS credential manager",
+			credentialManager: "aws-ssm",
+			providerName:      iaas.AWS,
+			wantErr:           false,
+		},
+		{
+			name:              "It is GCP with an AWS credential manager",
+			credentialManager: "aws-secretsmanager",
+			providerName:      iaas.GCP,
+			wantErr:           true,
+		},
+		{
+			name:              "It is GCP with credhub",
+			credentialManager: "credhub",
+			providerName:      iaas.GCP,
+			wantErr:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCredentialManager(tt.credentialManager, tt.providerName); (err != nil) != tt.wantErr {
+				t.Errorf("validateCredentialManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_validateCidrRanges(t *testing.T) {
 	testsupport.SetupFakeCredsForGCPProvider(t)
 	gcpProvider, err := iaas.New(iaas.GCP, "europe-west1")
@@ -12,7 +12,6 @@ import (
 	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
 	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/EngineerBetter/control-tower/util"
 
@@ -84,12 +83,17 @@ func validateDestroyArgs(c *cli.Context, destroyArgs destroy.Args) (destroy.Args
 }
 
 func buildDestroyClient(name, version string, destroyArgs destroy.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
+	configClient := config.New(provider, name, destroyArgs.Namespace)
+	if err := useSavedSettings(configClient); err != nil {
+		return nil, err
+	}
+
+	versionFile, err := chooseVersionFile(provider)
+	if err != nil {
+		return nil, err
+	}
 
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
+	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile), terraform.ArtefactMirror(artefactMirror))
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +110,7 @@ func buildDestroyClient(name, version string, destroyArgs destroy.Args, provider
 		bosh.New,
 		fly.New,
 		certs.Generate,
-		config.New(provider, name, destroyArgs.Namespace),
+		configClient,
 		nil,
 		os.Stdout,
 		os.Stderr,
@@ -13,7 +13,6 @@ import (
 	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
 	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/EngineerBetter/control-tower/util"
 	"gopkg.in/urfave/cli.v1"
@@ -107,12 +106,17 @@ func validateInfoArgs(c *cli.Context, infoArgs info.Args) (info.Args, error) {
 }
 
 func buildInfoClient(name, version string, infoArgs info.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
+	configClient := config.New(provider, name, infoArgs.Namespace)
+	if err := useSavedSettings(configClient); err != nil {
+		return nil, err
+	}
+
+	versionFile, err := chooseVersionFile(provider)
+	if err != nil {
+		return nil, err
+	}
 
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
+	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile), terraform.ArtefactMirror(artefactMirror))
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +133,7 @@ func buildInfoClient(name, version string, infoArgs info.Args, provider iaas.Pro
 		bosh.New,
 		fly.New,
 		certs.Generate,
-		config.New(provider, name, infoArgs.Namespace),
+		configClient,
 		nil,
 		os.Stdout,
 		os.Stderr,
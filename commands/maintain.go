@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/EngineerBetter/control-tower/commands/maintain"
-	"github.com/EngineerBetter/control-tower/resource"
 
 	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/certs"
@@ -88,12 +87,17 @@ func validateMaintainArgs(c *cli.Context, maintainArgs maintain.Args) (maintain.
 }
 
 func buildMaintainClient(name, version string, maintainArgs maintain.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
+	configClient := config.New(provider, name, maintainArgs.Namespace)
+	if err := useSavedSettings(configClient); err != nil {
+		return nil, err
+	}
+
+	versionFile, err := chooseVersionFile(provider)
+	if err != nil {
+		return nil, err
+	}
 
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
+	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile), terraform.ArtefactMirror(artefactMirror))
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +114,7 @@ func buildMaintainClient(name, version string, maintainArgs maintain.Args, provi
 		bosh.New,
 		fly.New,
 		certs.Generate,
-		config.New(provider, name, maintainArgs.Namespace),
+		configClient,
 		nil,
 		os.Stdout,
 		os.Stderr,
@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/commands/mirror"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/terraform"
+	artefactmirror "github.com/EngineerBetter/control-tower/util/mirror"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var initialMirrorArgs mirror.Args
+
+var mirrorFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region of the bucket to mirror to",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialMirrorArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS to mirror the artefacts for, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialMirrorArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "to",
+		Usage:       "(required) Local directory, or bucket given as s3://bucket/prefix or gs://bucket/prefix, to download the artefacts to",
+		EnvVar:      "MIRROR_TO",
+		Destination: &initialMirrorArgs.To,
+	},
+	cli.StringFlag{
+		Name:        "runtime-config",
+		Usage:       "(optional) BOSH runtime config file given to deploy, whose addon releases are mirrored too",
+		EnvVar:      "RUNTIME_CONFIG",
+		Destination: &initialMirrorArgs.RuntimeConfig,
+	},
+}
+
+// mirrorURLs returns every artefact that this version of control-tower downloads when deploying to an IAAS,
+// and the addon releases of the runtime config if one is given
+func mirrorURLs(iaasName iaas.Name, runtimeConfig string) ([]string, error) {
+	versionFile := resource.AWSVersionFile
+	if iaasName == iaas.GCP {
+		versionFile = resource.GCPVersionFile
+	}
+	urls, err := artefactmirror.VersionFileURLs(versionFile)
+	if err != nil {
+		return nil, err
+	}
+	deploymentURLs, err := bosh.ArtefactURLs(iaasName, deploy.StemcellOSes)
+	if err != nil {
+		return nil, err
+	}
+	urls = append(urls, deploymentURLs...)
+	urls = append(urls, terraform.ProviderURLs(iaasName)...)
+
+	if runtimeConfig == "" {
+		return urls, nil
+	}
+	contents, err := ioutil.ReadFile(runtimeConfig)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := config.RuntimeConfigReleases(string(contents))
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		urls = append(urls, release.URL)
+	}
+	return urls, nil
+}
+
+func mirrorAction(mirrorArgs mirror.Args, iaasName iaas.Name, stdout io.Writer) error {
+	urls, err := mirrorURLs(iaasName, mirrorArgs.RuntimeConfig)
+	if err != nil {
+		return err
+	}
+
+	var write func(p string, contents io.Reader) error
+	var exists func(p string) (bool, error)
+	if bucket, prefix, ok := mirrorArgs.Bucket(); ok {
+		provider, err := iaas.New(iaasName, mirrorArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on mirror: [%v]", err)
+		}
+		write = func(p string, contents io.Reader) error {
+			return provider.WriteFileFrom(bucket, path.Join(prefix, p), contents)
+		}
+		exists = func(p string) (bool, error) {
+			return provider.HasFile(bucket, path.Join(prefix, p))
+		}
+	} else {
+		write = func(p string, contents io.Reader) error {
+			return writeMirrorFile(filepath.Join(mirrorArgs.To, filepath.FromSlash(p)), contents)
+		}
+		exists = func(p string) (bool, error) {
+			_, err := os.Stat(filepath.Join(mirrorArgs.To, filepath.FromSlash(p)))
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return err == nil, err
+		}
+	}
+
+	sha1s := bosh.ArtefactSHA1s()
+	seen := map[string]bool{}
+	for _, url := range urls {
+		p, err := artefactmirror.Path(url)
+		if err != nil {
+			return err
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		found, err := exists(p)
+		if err != nil {
+			return err
+		}
+		if found {
+			fmt.Fprintf(stdout, "Already mirrored %s\n", url)
+			continue
+		}
+		fmt.Fprintf(stdout, "Mirroring %s\n", url)
+		if err = mirrorArtefact(url, sha1s[url], p, write); err != nil {
+			return fmt.Errorf("failed to mirror %s: [%v]", url, err)
+		}
+	}
+	return nil
+}
+
+// mirrorArtefact streams the artefact at url to p in the mirror, so that large stemcells are never held in memory.
+// An artefact with a SHA1 pinned must match it
+func mirrorArtefact(url, sha1Sum, p string, write func(p string, contents io.Reader) error) error {
+	resp, err := artefactmirror.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var contents io.Reader = resp.Body
+	if sha1Sum != "" {
+		contents = &sha1Reader{r: resp.Body, hash: sha1.New(), url: url, want: sha1Sum}
+	}
+	return write(p, contents)
+}
+
+// sha1Reader fails the read that reaches the end of an artefact whose SHA1 is not the pinned one,
+// so that the artefact is never put in place in the mirror
+type sha1Reader struct {
+	r    io.Reader
+	hash hash.Hash
+	url  string
+	want string
+}
+
+func (s *sha1Reader) Read(b []byte) (int, error) {
+	n, err := s.r.Read(b)
+	s.hash.Write(b[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(s.hash.Sum(nil)); actual != s.want {
+			return n, fmt.Errorf("SHA1 of %s is %s, but %s is pinned", s.url, actual, s.want)
+		}
+	}
+	return n, err
+}
+
+// writeMirrorFile only puts an artefact in place once it is complete, so an interrupted mirror can be resumed
+func writeMirrorFile(name string, contents io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".download-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+var mirrorCmd = cli.Command{
+	Name:      "mirror",
+	Usage:     "Downloads every CLI, release, stemcell and terraform provider this version of control-tower deploys, for use with --artefact-mirror",
+	ArgsUsage: " ",
+	Flags:     mirrorFlags,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 0 {
+			return errors.New("Usage is `control-tower mirror --iaas <iaas> --to <dir|bucket>`")
+		}
+		mirrorArgs := initialMirrorArgs
+		if err := mirrorArgs.MarkSetFlags(c); err != nil {
+			return fmt.Errorf("failed to mark set Mirror flags: [%v]", err)
+		}
+		if err := mirrorArgs.Validate(); err != nil {
+			return fmt.Errorf("Error validating args on mirror: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(mirrorArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on mirror: [%v]", err)
+		}
+		return mirrorAction(mirrorArgs, iaasName, os.Stdout)
+	},
+}
@@ -0,0 +1,85 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// bucketSchemes are the URL schemes of buckets that can be mirrored to, for each IAAS
+var bucketSchemes = map[string]string{
+	"AWS": "s3://",
+	"GCP": "gs://",
+}
+
+// Args are arguments passed to the mirror command
+type Args struct {
+	Region      string
+	RegionIsSet bool
+	IAAS        string
+	IAASIsSet   bool
+	To          string
+	ToIsSet     bool
+	// RuntimeConfig is the path to a BOSH runtime config whose addon releases are mirrored too
+	RuntimeConfig      string
+	RuntimeConfigIsSet bool
+}
+
+// MarkSetFlags is marking which mirror Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "to":
+				a.ToIsSet = true
+			case "runtime-config":
+				a.RuntimeConfigIsSet = true
+			default:
+				return fmt.Errorf("flag %q is not supported by mirror flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates the mirror flags
+func (a *Args) Validate() error {
+	if !a.IAASIsSet {
+		return errors.New("--iaas flag not set")
+	}
+	if !a.ToIsSet || a.To == "" {
+		return errors.New("--to flag not set")
+	}
+	if !strings.Contains(a.To, "://") {
+		return nil
+	}
+	scheme := bucketSchemes[strings.ToUpper(a.IAAS)]
+	if scheme == "" || !strings.HasPrefix(a.To, scheme) || a.To == scheme {
+		return fmt.Errorf("--to must be a local directory or a bucket given as %sbucket/prefix when mirroring for %s", scheme, a.IAAS)
+	}
+	return nil
+}
+
+// Bucket returns the bucket and path prefix that --to names, or false if it is a local directory
+func (a *Args) Bucket() (string, string, bool) {
+	i := strings.Index(a.To, "://")
+	if i == -1 {
+		return "", "", false
+	}
+	bucket := a.To[i+3:]
+	prefix := ""
+	if j := strings.Index(bucket, "/"); j != -1 {
+		bucket, prefix = bucket[:j], strings.Trim(bucket[j+1:], "/")
+	}
+	return bucket, prefix, true
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
@@ -0,0 +1,91 @@
+package mirror_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/mirror"
+)
+
+func TestMirrorArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		IAAS:      "AWS",
+		IAASIsSet: true,
+		To:        "./mirror",
+		ToIsSet:   true,
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "S3 bucket for AWS",
+			modification: func() Args {
+				args := defaultFields
+				args.To = "s3://artefacts/control-tower"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "To not set",
+			modification: func() Args {
+				args := defaultFields
+				args.ToIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--to flag not set",
+		},
+		{
+			name: "GCS bucket for AWS",
+			modification: func() Args {
+				args := defaultFields
+				args.To = "gs://artefacts"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--to must be a local directory or a bucket given as s3://bucket/prefix when mirroring for AWS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("MirrorArgs %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v", tt.name, err, tt.expectedErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMirrorArgs_Bucket(t *testing.T) {
+	args := Args{To: "s3://artefacts/control-tower/"}
+	bucket, prefix, ok := args.Bucket()
+	if !ok || bucket != "artefacts" || prefix != "control-tower" {
+		t.Errorf("Bucket() = %s, %s, %v, want artefacts, control-tower, true", bucket, prefix, ok)
+	}
+	args = Args{To: "./mirror"}
+	if _, _, ok = args.Bucket(); ok {
+		t.Errorf("Bucket() is true for a local directory")
+	}
+}
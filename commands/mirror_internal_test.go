@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/commands/mirror"
+	"github.com/EngineerBetter/control-tower/iaas"
+	artefactmirror "github.com/EngineerBetter/control-tower/util/mirror"
+)
+
+func Test_mirrorArtefact(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("stemcell"))
+	}))
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "bosh.io", "stemcell.tgz")
+	write := func(p string, contents io.Reader) error {
+		return writeMirrorFile(filepath.Join(dir, filepath.FromSlash(p)), contents)
+	}
+	if err = mirrorArtefact(s.URL, "", "bosh.io/stemcell.tgz", write); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "stemcell" {
+		t.Errorf("mirrored %q, want %q", got, "stemcell")
+	}
+	entries, _ := ioutil.ReadDir(filepath.Dir(name))
+	if len(entries) != 1 {
+		t.Errorf("expected only the mirrored artefact in %s, found %d entries", filepath.Dir(name), len(entries))
+	}
+}
+
+func Test_mirrorArtefact_PinnedSHA1(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("release"))
+	}))
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(p string, contents io.Reader) error {
+		return writeMirrorFile(filepath.Join(dir, filepath.FromSlash(p)), contents)
+	}
+	sum := sha1.Sum([]byte("release"))
+	if err = mirrorArtefact(s.URL, hex.EncodeToString(sum[:]), "bosh.io/matching.tgz", write); err != nil {
+		t.Errorf("mirrorArtefact() error = %v for a release matching its SHA1", err)
+	}
+
+	err = mirrorArtefact(s.URL, "da39a3ee5e6b4b0d3255bfef95601890afd80709", "bosh.io/tampered.tgz", write)
+	if err == nil {
+		t.Error("mirrorArtefact() mirrored a release that does not match its SHA1")
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "bosh.io", "tampered.tgz")); !os.IsNotExist(statErr) {
+		t.Errorf("mirrorArtefact() put a release that does not match its SHA1 in the mirror")
+	}
+}
+
+func Test_mirrorAction_AlreadyMirrored(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	urls, err := mirrorURLs(iaas.AWS, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every artefact is already in place, so nothing is downloaded
+	for _, url := range urls {
+		p, err := artefactmirror.Path(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = writeMirrorFile(filepath.Join(dir, filepath.FromSlash(p)), bytes.NewReader(nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	if err = mirrorAction(mirror.Args{IAAS: "AWS", To: dir}, iaas.AWS, &stdout); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte("Already mirrored ")) || bytes.Contains(stdout.Bytes(), []byte("Mirroring ")) {
+		t.Errorf("mirrorAction() wrote %q, want only artefacts that are already mirrored", stdout.String())
+	}
+}
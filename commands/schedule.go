@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/commands/schedule"
+	"github.com/EngineerBetter/control-tower/concourse"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var initialScheduleArgs schedule.Args
+
+var scheduleFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialScheduleArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialScheduleArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialScheduleArgs.Namespace,
+	},
+	cli.BoolFlag{
+		Name:        "up",
+		Usage:       "(optional) Bring the deployment up now, until its next scheduled down time",
+		Destination: &initialScheduleArgs.Up,
+	},
+	cli.BoolFlag{
+		Name:        "down",
+		Usage:       "(optional) Bring the deployment down now, until its next scheduled up time",
+		Destination: &initialScheduleArgs.Down,
+	},
+}
+
+func scheduleAction(c *cli.Context, scheduleArgs schedule.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower schedule <name>`")
+	}
+
+	client, err := buildMaintainClient(name, c.App.Version, maintain.Args{Namespace: scheduleArgs.Namespace}, provider)
+	if err != nil {
+		return err
+	}
+
+	force := ""
+	switch {
+	case scheduleArgs.Up:
+		force = concourse.ScheduleUp
+	case scheduleArgs.Down:
+		force = concourse.ScheduleDown
+	}
+	return client.Schedule(force)
+}
+
+var scheduleCmd = cli.Command{
+	Name:      "schedule",
+	Usage:     "Scales down or stops a deployment deployed with --schedule-down and --schedule-up according to its schedule",
+	ArgsUsage: "<name>",
+	Flags:     scheduleFlags,
+	Action: func(c *cli.Context) error {
+		scheduleArgs := initialScheduleArgs
+		if err := scheduleArgs.MarkSetFlags(c); err != nil {
+			return fmt.Errorf("failed to mark set Schedule flags: [%v]", err)
+		}
+		if err := scheduleArgs.Validate(); err != nil {
+			return fmt.Errorf("Error validating args on schedule: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(scheduleArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on schedule: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, scheduleArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on schedule: [%v]", err)
+		}
+		return scheduleAction(c, scheduleArgs, provider)
+	},
+}
@@ -0,0 +1,56 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Args are arguments passed to the schedule command
+type Args struct {
+	Region         string
+	RegionIsSet    bool
+	Namespace      string
+	NamespaceIsSet bool
+	IAAS           string
+	IAASIsSet      bool
+	Up             bool
+	Down           bool
+}
+
+// MarkSetFlags is marking which schedule Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "namespace":
+				a.NamespaceIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "up", "down":
+				//do nothing
+			default:
+				return fmt.Errorf("flag %q is not supported by schedule flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates the schedule flags
+func (a *Args) Validate() error {
+	if !a.IAASIsSet {
+		return errors.New("--iaas flag not set")
+	}
+	if a.Up && a.Down {
+		return errors.New("only one of --up and --down can be given")
+	}
+	return nil
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
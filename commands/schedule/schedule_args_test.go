@@ -0,0 +1,60 @@
+package schedule_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/schedule"
+)
+
+func TestScheduleArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:    "eu-west-1",
+		IAAS:      "AWS",
+		IAASIsSet: true,
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "Up and down",
+			modification: func() Args {
+				args := defaultFields
+				args.Up = true
+				args.Down = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --up and --down can be given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ScheduleArgs %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v", tt.name, err, tt.expectedErr, tt.wantErr)
+			}
+		})
+	}
+}
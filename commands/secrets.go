@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/EngineerBetter/control-tower/commands/secrets"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/credhub"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var initialSecretsArgs secrets.Args
+
+var secretsCommonFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialSecretsArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialSecretsArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialSecretsArgs.Namespace,
+	},
+	cli.StringFlag{
+		Name:        "passphrase",
+		Usage:       "(required) Passphrase used to encrypt or decrypt the secrets bundle",
+		EnvVar:      "SECRETS_PASSPHRASE",
+		Destination: &initialSecretsArgs.Passphrase,
+	},
+}
+
+var secretsExportFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:        "path",
+		Usage:       "(required) CredHub path to export secrets from (eg: /concourse/main)",
+		Destination: &initialSecretsArgs.Path,
+	},
+	cli.StringFlag{
+		Name:        "file",
+		Usage:       "(optional) File to write the encrypted bundle to. Defaults to stdout",
+		Destination: &initialSecretsArgs.File,
+	},
+	cli.StringFlag{
+		Name:        "format",
+		Usage:       "(optional) Encoding of the encrypted bundle. Can be yaml or json",
+		Value:       "yaml",
+		Destination: &initialSecretsArgs.Format,
+	},
+}, secretsCommonFlags...)
+
+var secretsImportFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:        "file",
+		Usage:       "(required) Encrypted bundle written by `control-tower secrets export`",
+		Destination: &initialSecretsArgs.File,
+	},
+}, secretsCommonFlags...)
+
+func secretsExportAction(c *cli.Context, secretsArgs secrets.Args, provider iaas.Provider) error {
+	credhubClient, err := buildCredhubClient(c, "export", secretsArgs, provider)
+	if err != nil {
+		return err
+	}
+
+	exported, err := credhubClient.Export(secretsArgs.Path)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := credhub.SealBundle(exported, secretsArgs.Passphrase, secretsArgs.Format)
+	if err != nil {
+		return err
+	}
+
+	if secretsArgs.File == "" {
+		_, err = os.Stdout.Write(bundle)
+		return err
+	}
+	if err = ioutil.WriteFile(secretsArgs.File, bundle, 0600); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stderr, "Exported %d secrets to %s\n", len(exported), secretsArgs.File)
+	return err
+}
+
+func secretsImportAction(c *cli.Context, secretsArgs secrets.Args, provider iaas.Provider) error {
+	contents, err := ioutil.ReadFile(secretsArgs.File)
+	if err != nil {
+		return fmt.Errorf("error reading secrets bundle: [%v]", err)
+	}
+
+	imported, err := credhub.OpenBundle(contents, secretsArgs.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	credhubClient, err := buildCredhubClient(c, "import", secretsArgs, provider)
+	if err != nil {
+		return err
+	}
+
+	if err = credhubClient.Import(imported); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stderr, "Imported %d secrets\n", len(imported))
+	return err
+}
+
+func buildCredhubClient(c *cli.Context, subcommand string, secretsArgs secrets.Args, provider iaas.Provider) (*credhub.Client, error) {
+	name := c.Args().Get(0)
+	if name == "" {
+		return nil, fmt.Errorf("Usage is `control-tower secrets %s <name>`", subcommand)
+	}
+
+	conf, err := config.New(provider, name, secretsArgs.Namespace).Load()
+	if err != nil {
+		return nil, fmt.Errorf("error loading config for deployment %s: [%v]", name, err)
+	}
+	if err = applySavedSettings(conf); err != nil {
+		return nil, err
+	}
+	if !conf.IsCredhubCredentialManager() {
+		return nil, fmt.Errorf("deployment %s uses %s, secrets can only be exported from or imported to credhub", name, conf.GetCredentialManager())
+	}
+	if conf.GetCredhubURL() == "" {
+		return nil, errors.New("deployment has no CredHub URL, has it finished deploying?")
+	}
+
+	return credhub.New(conf.GetCredhubURL(), conf.GetCredhubCACert(), credhub.AdminClientID, conf.GetCredhubAdminClientSecret())
+}
+
+func secretsSubcommand(name, usage string, flags []cli.Flag, validate func(*secrets.Args) error, action func(*cli.Context, secrets.Args, iaas.Provider) error) cli.Command {
+	return cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "<name>",
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			secretsArgs := initialSecretsArgs
+			if err := secretsArgs.MarkSetFlags(c); err != nil {
+				return fmt.Errorf("failed to mark set Secrets flags: [%v]", err)
+			}
+			if err := validate(&secretsArgs); err != nil {
+				return fmt.Errorf("Error validating args on secrets %s: [%v]", name, err)
+			}
+			iaasName, err := iaas.Validate(secretsArgs.IAAS)
+			if err != nil {
+				return fmt.Errorf("Error mapping to supported IAASes on secrets %s: [%v]", name, err)
+			}
+			provider, err := iaas.New(iaasName, secretsArgs.Region)
+			if err != nil {
+				return fmt.Errorf("Error creating IAAS provider on secrets %s: [%v]", name, err)
+			}
+			return action(c, secretsArgs, provider)
+		},
+	}
+}
+
+var secretsCmd = cli.Command{
+	Name:  "secrets",
+	Usage: "Exports and imports encrypted bundles of CredHub secrets",
+	Subcommands: []cli.Command{
+		secretsSubcommand("export", "Writes every CredHub secret under a path to an encrypted bundle", secretsExportFlags, (*secrets.Args).ValidateExport, secretsExportAction),
+		secretsSubcommand("import", "Loads an encrypted bundle of secrets into CredHub", secretsImportFlags, (*secrets.Args).ValidateImport, secretsImportAction),
+	},
+}
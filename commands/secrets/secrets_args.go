@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/credhub"
+)
+
+// Args are arguments passed to the secrets export and import commands
+type Args struct {
+	Region         string
+	RegionIsSet    bool
+	Namespace      string
+	NamespaceIsSet bool
+	IAAS           string
+	IAASIsSet      bool
+	Path           string
+	File           string
+	Format         string
+	Passphrase     string
+}
+
+// MarkSetFlags is marking which secrets Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "namespace":
+				a.NamespaceIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "path", "file", "format", "passphrase":
+				//do nothing
+			default:
+				return fmt.Errorf("flag %q is not supported by secrets flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateExport validates the flags needed to export secrets
+func (a *Args) ValidateExport() error {
+	if err := a.validateCommon(); err != nil {
+		return err
+	}
+	if a.Path == "" {
+		return errors.New("--path flag not set")
+	}
+	for _, format := range credhub.BundleFormats {
+		if format == a.Format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown bundle format: `%s`. Valid formats are: %v", a.Format, credhub.BundleFormats)
+}
+
+// ValidateImport validates the flags needed to import secrets
+func (a *Args) ValidateImport() error {
+	if err := a.validateCommon(); err != nil {
+		return err
+	}
+	if a.File == "" {
+		return errors.New("--file flag not set")
+	}
+	return nil
+}
+
+func (a *Args) validateCommon() error {
+	if !a.IAASIsSet {
+		return errors.New("--iaas flag not set")
+	}
+	if a.Passphrase == "" {
+		return errors.New("--passphrase flag not set")
+	}
+	return nil
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
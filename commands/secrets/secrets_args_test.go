@@ -0,0 +1,108 @@
+package secrets_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/secrets"
+)
+
+func TestSecretsArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:     "eu-west-1",
+		IAAS:       "AWS",
+		IAASIsSet:  true,
+		Path:       "/concourse/main",
+		File:       "bundle.yml",
+		Format:     "yaml",
+		Passphrase: "a passphrase",
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		validate     func(Args) error
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default export args",
+			modification: func() Args {
+				return defaultFields
+			},
+			validate: func(a Args) error { return a.ValidateExport() },
+			wantErr:  false,
+		},
+		{
+			name: "Default import args",
+			modification: func() Args {
+				return defaultFields
+			},
+			validate: func(a Args) error { return a.ValidateImport() },
+			wantErr:  false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateImport() },
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "Passphrase not set",
+			modification: func() Args {
+				args := defaultFields
+				args.Passphrase = ""
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateExport() },
+			wantErr:     true,
+			expectedErr: "--passphrase flag not set",
+		},
+		{
+			name: "Export needs a path",
+			modification: func() Args {
+				args := defaultFields
+				args.Path = ""
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateExport() },
+			wantErr:     true,
+			expectedErr: "--path flag not set",
+		},
+		{
+			name: "Export format must be known",
+			modification: func() Args {
+				args := defaultFields
+				args.Format = "xml"
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateExport() },
+			wantErr:     true,
+			expectedErr: "unknown bundle format: `xml`",
+		},
+		{
+			name: "Import needs a file",
+			modification: func() Args {
+				args := defaultFields
+				args.File = ""
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateImport() },
+			wantErr:     true,
+			expectedErr: "--file flag not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := tt.validate(args)
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("SecretsArgs %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v", tt.name, err, tt.expectedErr, tt.wantErr)
+			}
+		})
+	}
+}
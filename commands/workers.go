@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/EngineerBetter/control-tower/commands/workers"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var initialWorkersArgs workers.Args
+
+var workersCommonFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialWorkersArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialWorkersArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialWorkersArgs.Namespace,
+	},
+}
+
+var workersAddExternalFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:        "public-key",
+		Usage:       "(required) File containing the SSH public key the external worker registers with",
+		Destination: &initialWorkersArgs.PublicKey,
+	},
+	cli.StringFlag{
+		Name:        "team",
+		Usage:       "(optional) Concourse team the external worker may only register for",
+		Destination: &initialWorkersArgs.Team,
+	},
+}, workersCommonFlags...)
+
+func workersAddExternalAction(c *cli.Context, workersArgs workers.Args, provider iaas.Provider) error {
+	name, workerName, err := workersCommandArgs(c, "add-external")
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := ioutil.ReadFile(workersArgs.PublicKey)
+	if err != nil {
+		return fmt.Errorf("error reading public key: [%v]", err)
+	}
+	worker, err := config.NewExternalWorker(workerName, string(publicKey), workersArgs.Team)
+	if err != nil {
+		return err
+	}
+
+	return updateExternalWorkers(name, workersArgs, provider, func(conf *config.Config) error {
+		conf.AddExternalWorker(worker)
+		return nil
+	})
+}
+
+func workersRemoveExternalAction(c *cli.Context, workersArgs workers.Args, provider iaas.Provider) error {
+	name, workerName, err := workersCommandArgs(c, "remove-external")
+	if err != nil {
+		return err
+	}
+
+	return updateExternalWorkers(name, workersArgs, provider, func(conf *config.Config) error {
+		return conf.RemoveExternalWorker(workerName)
+	})
+}
+
+func workersCommandArgs(c *cli.Context, subcommand string) (string, string, error) {
+	name := c.Args().Get(0)
+	workerName := c.Args().Get(1)
+	if name == "" || workerName == "" {
+		return "", "", fmt.Errorf("Usage is `control-tower workers %s <name> <worker-name>`", subcommand)
+	}
+	return name, workerName, nil
+}
+
+func updateExternalWorkers(name string, workersArgs workers.Args, provider iaas.Provider, update func(*config.Config) error) error {
+	configClient := config.New(provider, name, workersArgs.Namespace)
+	conf, err := configClient.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config for deployment %s: [%v]", name, err)
+	}
+	if err = update(&conf); err != nil {
+		return err
+	}
+	if err = configClient.Update(conf); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(os.Stderr, "Updated external workers of deployment %s. Run `control-tower deploy %s` to apply the change\n", name, name)
+	return err
+}
+
+func workersSubcommand(name, usage string, flags []cli.Flag, validate func(*workers.Args) error, action func(*cli.Context, workers.Args, iaas.Provider) error) cli.Command {
+	return cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "<name> <worker-name>",
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			workersArgs := initialWorkersArgs
+			if err := workersArgs.MarkSetFlags(c); err != nil {
+				return fmt.Errorf("failed to mark set Workers flags: [%v]", err)
+			}
+			if err := validate(&workersArgs); err != nil {
+				return fmt.Errorf("Error validating args on workers %s: [%v]", name, err)
+			}
+			iaasName, err := iaas.Validate(workersArgs.IAAS)
+			if err != nil {
+				return fmt.Errorf("Error mapping to supported IAASes on workers %s: [%v]", name, err)
+			}
+			provider, err := iaas.New(iaasName, workersArgs.Region)
+			if err != nil {
+				return fmt.Errorf("Error creating IAAS provider on workers %s: [%v]", name, err)
+			}
+			return action(c, workersArgs, provider)
+		},
+	}
+}
+
+var workersCmd = cli.Command{
+	Name:  "workers",
+	Usage: "Authorises and revokes external workers that register with the deployment's TSA",
+	Subcommands: []cli.Command{
+		workersSubcommand("add-external", "Authorises the public key of a worker running outside the deployment", workersAddExternalFlags, (*workers.Args).ValidateAddExternal, workersAddExternalAction),
+		workersSubcommand("remove-external", "Revokes the public key of an external worker", workersCommonFlags, (*workers.Args).ValidateRemoveExternal, workersRemoveExternalAction),
+	},
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Args are arguments passed to the workers add-external and remove-external commands
+type Args struct {
+	Region         string
+	RegionIsSet    bool
+	Namespace      string
+	NamespaceIsSet bool
+	IAAS           string
+	IAASIsSet      bool
+	PublicKey      string
+	Team           string
+}
+
+// MarkSetFlags is marking which workers Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "namespace":
+				a.NamespaceIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "public-key", "team":
+				//do nothing
+			default:
+				return fmt.Errorf("flag %q is not supported by workers flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAddExternal validates the flags needed to add an external worker
+func (a *Args) ValidateAddExternal() error {
+	if err := a.validateCommon(); err != nil {
+		return err
+	}
+	if a.PublicKey == "" {
+		return errors.New("--public-key flag not set")
+	}
+	return nil
+}
+
+// ValidateRemoveExternal validates the flags needed to remove an external worker
+func (a *Args) ValidateRemoveExternal() error {
+	return a.validateCommon()
+}
+
+func (a *Args) validateCommon() error {
+	if !a.IAASIsSet {
+		return errors.New("--iaas flag not set")
+	}
+	return nil
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
@@ -0,0 +1,73 @@
+package workers_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/workers"
+)
+
+func TestWorkersArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:    "eu-west-1",
+		IAAS:      "AWS",
+		IAASIsSet: true,
+		PublicKey: "worker_key.pub",
+		Team:      "ops",
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		validate     func(Args) error
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default add-external args",
+			modification: func() Args {
+				return defaultFields
+			},
+			validate: func(a Args) error { return a.ValidateAddExternal() },
+			wantErr:  false,
+		},
+		{
+			name: "Default remove-external args",
+			modification: func() Args {
+				return defaultFields
+			},
+			validate: func(a Args) error { return a.ValidateRemoveExternal() },
+			wantErr:  false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateRemoveExternal() },
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "Add external needs a public key",
+			modification: func() Args {
+				args := defaultFields
+				args.PublicKey = ""
+				return args
+			},
+			validate:    func(a Args) error { return a.ValidateAddExternal() },
+			wantErr:     true,
+			expectedErr: "--public-key flag not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := tt.validate(args)
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("WorkersArgs %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v", tt.name, err, tt.expectedErr, tt.wantErr)
+			}
+		})
+	}
+}
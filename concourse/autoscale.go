@@ -0,0 +1,183 @@
+package concourse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+// Autoscale resizes the default worker group according to the number of containers on its workers.
+// Only the Concourse deployment is redeployed, and the task is detached from once it starts
+func (client *Client) Autoscale() error {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+	if !conf.IsAutoscaling() {
+		return errors.New("autoscaling is not enabled for this deployment, deploy with --autoscale-max-workers to enable it")
+	}
+	if conf.IsScheduledDown() {
+		_, err = fmt.Fprintln(client.stdout, "Workers are scaled down by the deployment's schedule, not autoscaling")
+		return err
+	}
+
+	now := time.Now().UTC()
+	coolingDown, err := inAutoscaleCooldown(conf, now)
+	if err != nil {
+		return err
+	}
+	if coolingDown {
+		_, err = fmt.Fprintf(client.stdout, "Workers were last scaled at %s, waiting for the %s cooldown to pass\n", conf.AutoscaleLastScaledAt, conf.AutoscaleCooldown)
+		return err
+	}
+
+	flyClient, err := client.flyClientFactory(client.provider, fly.Credentials{
+		Target:   conf.GetDeployment(),
+		API:      fmt.Sprintf("https://%s", conf.GetDomain()),
+		Username: conf.GetConcourseUsername(),
+		Password: conf.GetConcoursePassword(),
+	},
+		client.stdout,
+		client.stderr,
+		client.versionFile,
+	)
+	if err != nil {
+		return err
+	}
+	defer flyClient.Cleanup()
+
+	load, err := flyClient.WorkerLoad()
+	if err != nil {
+		return err
+	}
+
+	desired, reason := desiredWorkerCount(conf, load)
+	if desired == conf.ConcourseWorkerCount {
+		_, err = fmt.Fprintf(client.stdout, "Keeping %d workers: %s\n", desired, reason)
+		return err
+	}
+	if _, err = fmt.Fprintf(client.stdout, "Scaling workers from %d to %d: %s\n", conf.ConcourseWorkerCount, desired, reason); err != nil {
+		return err
+	}
+
+	conf.ConcourseWorkerCount = desired
+	conf.AutoscaleLastScaledAt = now.Format(time.RFC3339)
+
+	tfOutputs, err := client.tfCLI.BuildOutput(client.tfInputVarsFactory.NewInputVars(conf))
+	if err != nil {
+		return err
+	}
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	creds, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+	creds, err1 := boshClient.DeployConcourse(creds, true)
+	if len(creds) > 0 {
+		if err = client.configClient.StoreAsset(bosh.CredsFilename, creds); err != nil {
+			return err
+		}
+	}
+	if err1 != nil {
+		return err1
+	}
+
+	// The new count is only saved once the deploy has started, so that a failed deploy is retried
+	return client.configClient.Update(conf)
+}
+
+// inAutoscaleCooldown returns true if the workers were scaled less than the cooldown period before now
+func inAutoscaleCooldown(c config.ConfigView, now time.Time) (bool, error) {
+	if c.GetAutoscaleLastScaledAt() == "" {
+		return false, nil
+	}
+	cooldown, err := time.ParseDuration(c.GetAutoscaleCooldown())
+	if err != nil {
+		return false, fmt.Errorf("invalid autoscale cooldown `%s`: [%v]", c.GetAutoscaleCooldown(), err)
+	}
+	lastScaledAt, err := time.Parse(time.RFC3339, c.GetAutoscaleLastScaledAt())
+	if err != nil {
+		return false, fmt.Errorf("invalid autoscale timestamp `%s`: [%v]", c.GetAutoscaleLastScaledAt(), err)
+	}
+	return now.Before(lastScaledAt.Add(cooldown)), nil
+}
+
+// desiredWorkerCount returns the number of default workers needed for the current load, and the reason
+// for it. Workers in pools, external workers and workers that are not running are not counted. Volumes are
+// only considered once volume limits have been saved, which deployments from before they existed lack
+func desiredWorkerCount(c config.ConfigView, load fly.WorkerLoad) (int, string) {
+	current := c.GetConcourseWorkerCount()
+
+	var workers, containers, volumes int
+	for _, worker := range load.Workers {
+		if worker.State != "running" || worker.Team != "" || len(worker.Tags) > 0 {
+			continue
+		}
+		workers++
+		containers += worker.ActiveContainers
+		volumes += worker.ActiveVolumes
+	}
+	if workers == 0 {
+		return clampWorkerCount(current, c), "no running workers to measure"
+	}
+
+	averageContainers := float64(containers) / float64(workers)
+	averageVolumes := float64(volumes) / float64(workers)
+	highContainers := c.GetAutoscaleHighContainers()
+	lowContainers := c.GetAutoscaleLowContainers()
+	highVolumes := c.GetAutoscaleHighVolumes()
+	lowVolumes := c.GetAutoscaleLowVolumes()
+	countVolumes := highVolumes > 0
+
+	var desired int
+	var reason string
+	switch {
+	case averageContainers > float64(highContainers) || (countVolumes && averageVolumes > float64(highVolumes)):
+		desired = int(math.Ceil(float64(containers) / float64(highContainers)))
+		if countVolumes {
+			if forVolumes := int(math.Ceil(float64(volumes) / float64(highVolumes))); forVolumes > desired {
+				desired = forVolumes
+			}
+		}
+		if desired <= current {
+			desired = current + 1
+		}
+		if averageContainers > float64(highContainers) {
+			reason = fmt.Sprintf("%.1f containers per worker is above %d", averageContainers, highContainers)
+		} else {
+			reason = fmt.Sprintf("%.1f volumes per worker is above %d", averageVolumes, highVolumes)
+		}
+	case averageContainers < float64(lowContainers) && (!countVolumes || averageVolumes < float64(lowVolumes)) && load.RunningBuilds < current:
+		desired = current - 1
+		reason = fmt.Sprintf("%.1f containers per worker is below %d", averageContainers, lowContainers)
+		if countVolumes {
+			reason = fmt.Sprintf("%s and %.1f volumes per worker is below %d", reason, averageVolumes, lowVolumes)
+		}
+	default:
+		desired = current
+		reason = fmt.Sprintf("%.1f containers and %.1f volumes per worker across %d running builds", averageContainers, averageVolumes, load.RunningBuilds)
+	}
+
+	return clampWorkerCount(desired, c), reason
+}
+
+// clampWorkerCount returns count limited to the autoscale minimum and maximum number of workers
+func clampWorkerCount(count int, c config.ConfigView) int {
+	if count < c.GetAutoscaleMinWorkers() {
+		return c.GetAutoscaleMinWorkers()
+	}
+	if count > c.GetAutoscaleMaxWorkers() {
+		return c.GetAutoscaleMaxWorkers()
+	}
+	return count
+}
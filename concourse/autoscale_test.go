@@ -0,0 +1,224 @@
+package concourse
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/bosh/boshfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/config/configfakes"
+	"github.com/EngineerBetter/control-tower/fly"
+	"github.com/EngineerBetter/control-tower/fly/flyfakes"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+)
+
+func TestDesiredWorkerCount(t *testing.T) {
+	conf := config.Config{
+		ConcourseWorkerCount:    3,
+		AutoscaleMinWorkers:     2,
+		AutoscaleMaxWorkers:     6,
+		AutoscaleHighContainers: 100,
+		AutoscaleLowContainers:  30,
+		AutoscaleHighVolumes:    1000,
+		AutoscaleLowVolumes:     300,
+	}
+	workers := func(containers ...int) []fly.Worker {
+		var w []fly.Worker
+		for _, c := range containers {
+			w = append(w, fly.Worker{State: "running", ActiveContainers: c, ActiveVolumes: c * 5})
+		}
+		return w
+	}
+	volumeWorkers := func(containers int, volumes ...int) []fly.Worker {
+		var w []fly.Worker
+		for _, v := range volumes {
+			w = append(w, fly.Worker{State: "running", ActiveContainers: containers, ActiveVolumes: v})
+		}
+		return w
+	}
+
+	tests := []struct {
+		name string
+		conf func(config.Config) config.Config
+		load fly.WorkerLoad
+		want int
+	}{
+		{
+			name: "scales up to fit the containers",
+			load: fly.WorkerLoad{Workers: workers(150, 150, 150), RunningBuilds: 10},
+			want: 5,
+		},
+		{
+			name: "scales up by at least one",
+			load: fly.WorkerLoad{Workers: workers(101, 101, 101), RunningBuilds: 10},
+			want: 4,
+		},
+		{
+			name: "does not scale up beyond the maximum",
+			load: fly.WorkerLoad{Workers: workers(500, 500, 500), RunningBuilds: 10},
+			want: 6,
+		},
+		{
+			name: "scales down by one when idle",
+			load: fly.WorkerLoad{Workers: workers(5, 5, 5), RunningBuilds: 0},
+			want: 2,
+		},
+		{
+			name: "does not scale down while builds need the workers",
+			load: fly.WorkerLoad{Workers: workers(5, 5, 5), RunningBuilds: 3},
+			want: 3,
+		},
+		{
+			name: "ignores pool, team and stalled workers",
+			load: fly.WorkerLoad{Workers: append(workers(50, 50, 50),
+				fly.Worker{State: "running", ActiveContainers: 500, Tags: []string{"gpu"}},
+				fly.Worker{State: "running", ActiveContainers: 500, Team: "ops"},
+				fly.Worker{State: "stalled", ActiveContainers: 500},
+			)},
+			want: 3,
+		},
+		{
+			name: "scales up to fit the volumes",
+			load: fly.WorkerLoad{Workers: volumeWorkers(50, 1500, 1500, 1500), RunningBuilds: 10},
+			want: 5,
+		},
+		{
+			name: "does not scale down while the volumes need the workers",
+			load: fly.WorkerLoad{Workers: volumeWorkers(5, 500, 500, 500), RunningBuilds: 0},
+			want: 3,
+		},
+		{
+			name: "ignores volumes without volume limits",
+			conf: func(c config.Config) config.Config {
+				c.AutoscaleHighVolumes = 0
+				c.AutoscaleLowVolumes = 0
+				return c
+			},
+			load: fly.WorkerLoad{Workers: volumeWorkers(5, 1500, 1500, 1500), RunningBuilds: 0},
+			want: 2,
+		},
+		{
+			name: "keeps the count without running workers",
+			load: fly.WorkerLoad{},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := conf
+			if tt.conf != nil {
+				c = tt.conf(conf)
+			}
+			got, reason := desiredWorkerCount(c, tt.load)
+			if got != tt.want {
+				t.Errorf("desiredWorkerCount() = %d (%s), want %d", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+// stubTFInputVarsFactory stands in for concoursefakes, which cannot be imported from within the package
+type stubTFInputVarsFactory struct{}
+
+func (stubTFInputVarsFactory) NewInputVars(config.ConfigView) terraform.InputVars {
+	return nil
+}
+
+func TestClient_Autoscale(t *testing.T) {
+	tests := []struct {
+		name      string
+		deployErr error
+		wantSaved bool
+	}{
+		{name: "saves the new worker count once the deploy has started", wantSaved: true},
+		{name: "keeps the saved worker count when the deploy fails", deployErr: errors.New("bosh failed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configClient := &configfakes.FakeIClient{}
+			configClient.LoadReturns(config.Config{
+				ConcourseWorkerCount:    2,
+				AutoscaleMinWorkers:     1,
+				AutoscaleMaxWorkers:     5,
+				AutoscaleHighContainers: 100,
+				AutoscaleLowContainers:  30,
+				AutoscaleCooldown:       "15m",
+			}, nil)
+			flyClient := &flyfakes.FakeIClient{}
+			flyClient.WorkerLoadReturns(fly.WorkerLoad{Workers: []fly.Worker{
+				{State: "running", ActiveContainers: 150},
+				{State: "running", ActiveContainers: 150},
+			}}, nil)
+			boshClient := &boshfakes.FakeIClient{}
+			boshClient.DeployConcourseReturns(nil, tt.deployErr)
+
+			client := &Client{
+				configClient: configClient,
+				flyClientFactory: func(iaas.Provider, fly.Credentials, io.Writer, io.Writer, []byte) (fly.IClient, error) {
+					return flyClient, nil
+				},
+				boshClientFactory: func(config.ConfigView, terraform.Outputs, io.Writer, io.Writer, iaas.Provider, []byte) (bosh.IClient, error) {
+					return boshClient, nil
+				},
+				tfCLI:              &terraformfakes.FakeCLIInterface{},
+				tfInputVarsFactory: stubTFInputVarsFactory{},
+				stdout:             ioutil.Discard,
+				stderr:             ioutil.Discard,
+			}
+
+			err := client.Autoscale()
+			if err != tt.deployErr {
+				t.Fatalf("Autoscale() error = %v, want %v", err, tt.deployErr)
+			}
+			if boshClient.DeployConcourseCallCount() != 1 {
+				t.Fatalf("Autoscale() deployed %d times, want once", boshClient.DeployConcourseCallCount())
+			}
+			if saved := configClient.UpdateCallCount() == 1; saved != tt.wantSaved {
+				t.Fatalf("Autoscale() saved the config: %v, want %v", saved, tt.wantSaved)
+			}
+			if tt.wantSaved && configClient.UpdateArgsForCall(0).ConcourseWorkerCount != 3 {
+				t.Errorf("Autoscale() saved %d workers, want 3", configClient.UpdateArgsForCall(0).ConcourseWorkerCount)
+			}
+		})
+	}
+}
+
+func TestInAutoscaleCooldown(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		lastScaledAt  string
+		want          bool
+		expectedError string
+	}{
+		{name: "never scaled", lastScaledAt: "", want: false},
+		{name: "within cooldown", lastScaledAt: "2020-01-01T11:50:00Z", want: true},
+		{name: "after cooldown", lastScaledAt: "2020-01-01T11:40:00Z", want: false},
+		{name: "invalid timestamp", lastScaledAt: "yesterday", expectedError: "invalid autoscale timestamp `yesterday`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := config.Config{AutoscaleCooldown: "15m", AutoscaleLastScaledAt: tt.lastScaledAt}
+			got, err := inAutoscaleCooldown(conf, now)
+			if tt.expectedError != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.expectedError) {
+					t.Errorf("inAutoscaleCooldown() error = %v, want %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("inAutoscaleCooldown() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("inAutoscaleCooldown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -39,10 +39,12 @@ type Client struct {
 
 // IClient represents a control-tower client
 type IClient interface {
+	Autoscale() error
 	Deploy() error
 	Destroy() error
 	FetchInfo() (*Info, error)
 	Maintain(maintain.Args) error
+	Schedule(force string) error
 }
 
 // New returns a new client
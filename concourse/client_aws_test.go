@@ -180,6 +180,7 @@ var _ = Describe("client", func() {
 			AvailabilityZone:         "eu-west-1a",
 			ConcoursePassword:        "s3cret",
 			ConcourseUsername:        "admin",
+			ConcourseWebCount:        1,
 			ConcourseWebSize:         "medium",
 			ConcourseWorkerCount:     1,
 			ConcourseWorkerSize:      "large",
@@ -227,6 +228,9 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 			RDSUsername:            "admin",
 			Region:                 "eu-west-1",
 			Spot:                   true,
+			StemcellOS:             "xenial",
+			CredentialManager:      "credhub",
+			Metrics:                "influxdb",
 			TFStatePath:            "example-path",
 			//These come from fixtures/director-creds.yml
 			CredhubUsername:          "credhub-cli",
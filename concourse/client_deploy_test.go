@@ -106,22 +106,26 @@ var _ = Describe("client", func() {
 
 		//At the time of writing, these are defaults from the CLI flags
 		args = &deploy.Args{
-			AllowIPs:         "0.0.0.0/0",
-			AllowIPsIsSet:    false,
-			DBSize:           "small",
-			DBSizeIsSet:      false,
-			IAAS:             "AWS",
-			IAASIsSet:        false,
-			Spot:             true,
-			SpotIsSet:        false,
-			WebSize:          "small",
-			WebSizeIsSet:     false,
-			WorkerCount:      1,
-			WorkerCountIsSet: false,
-			WorkerSize:       "xlarge",
-			WorkerSizeIsSet:  false,
-			WorkerType:       "m4",
-			WorkerTypeIsSet:  false,
+			AllowIPs:          "0.0.0.0/0",
+			AllowIPsIsSet:     false,
+			CredentialManager: "credhub",
+			Metrics:           "influxdb",
+			DBSize:            "small",
+			DBSizeIsSet:       false,
+			IAAS:              "AWS",
+			IAASIsSet:         false,
+			Spot:              true,
+			SpotIsSet:         false,
+			StemcellOS:        "xenial",
+			StemcellOSIsSet:   false,
+			WebSize:           "small",
+			WebSizeIsSet:      false,
+			WorkerCount:       1,
+			WorkerCountIsSet:  false,
+			WorkerSize:        "xlarge",
+			WorkerSizeIsSet:   false,
+			WorkerType:        "m4",
+			WorkerTypeIsSet:   false,
 		}
 
 		terraformOutputs = terraform.AWSOutputs{
@@ -151,6 +155,7 @@ var _ = Describe("client", func() {
 			AvailabilityZone:         "eu-west-1a",
 			ConcoursePassword:        "s3cret",
 			ConcourseUsername:        "admin",
+			ConcourseWebCount:        1,
 			ConcourseWebSize:         "medium",
 			ConcourseWorkerCount:     1,
 			ConcourseWorkerSize:      "large",
@@ -198,6 +203,9 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 			RDSUsername:            "admin",
 			Region:                 "eu-west-1",
 			Spot:                   true,
+			StemcellOS:             "xenial",
+			CredentialManager:      "credhub",
+			Metrics:                "influxdb",
 			TFStatePath:            "example-path",
 			//These come from fixtures/director-creds.yml
 			CredhubUsername:          "credhub-cli",
@@ -309,13 +317,17 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 						AllowIPs:               configAfterLoad.AllowIPs,
 						AvailabilityZone:       configAfterLoad.AvailabilityZone,
 						ConfigBucket:           configAfterLoad.ConfigBucket,
+						CredentialManager:      configAfterLoad.CredentialManager,
+						Metrics:                configAfterLoad.Metrics,
 						Deployment:             configAfterLoad.Deployment,
 						HostedZoneID:           configAfterLoad.HostedZoneID,
 						HostedZoneRecordPrefix: configAfterLoad.HostedZoneRecordPrefix,
 						Namespace:              configAfterLoad.Namespace,
+						NodeExporterPort:       config.NodeExporterPort,
 						NetworkCIDR:            configAfterLoad.NetworkCIDR,
 						PrivateCIDR:            configAfterLoad.PrivateCIDR,
 						Project:                configAfterLoad.Project,
+						PrometheusPort:         config.PrometheusPort,
 						PublicCIDR:             configAfterLoad.PublicCIDR,
 						PublicKey:              configAfterLoad.PublicKey,
 						RDS1CIDR:               configAfterLoad.RDS1CIDR,
@@ -327,6 +339,7 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 						Region:                 configAfterLoad.Region,
 						SourceAccessIP:         configAfterLoad.SourceAccessIP,
 						TFStatePath:            configAfterLoad.TFStatePath,
+						WebCount:               configAfterLoad.ConcourseWebCount,
 					}
 
 					//Mutations we expect to have been done after deploying the director
@@ -425,6 +438,21 @@ wEW5QkylaPEkbVDhJWeR1I8=
 					Eventually(stderr).Should(gbytes.Say("WARNING: allowing access from local machine"))
 				})
 
+				Context("and the stemcell OS is changing", func() {
+					BeforeEach(func() {
+						args.StemcellOS = "bionic"
+						args.StemcellOSIsSet = true
+					})
+
+					It("Warns that all VMs will be recreated", func() {
+						client := buildClient()
+						err := client.Deploy()
+						Expect(err).ToNot(HaveOccurred())
+
+						Eventually(stderr).Should(gbytes.Say("WARNING: changing stemcell OS from xenial to bionic will recreate all Concourse VMs"))
+					})
+				})
+
 				It("Prints the bosh credentials", func() {
 					client := buildClient()
 					err := client.Deploy()
@@ -527,13 +555,17 @@ wEW5QkylaPEkbVDhJWeR1I8=
 						AllowIPs:               configAfterLoad.AllowIPs,
 						AvailabilityZone:       configAfterLoad.AvailabilityZone,
 						ConfigBucket:           configAfterLoad.ConfigBucket,
+						CredentialManager:      configAfterLoad.CredentialManager,
+						Metrics:                configAfterLoad.Metrics,
 						Deployment:             configAfterLoad.Deployment,
 						HostedZoneID:           configAfterLoad.HostedZoneID,
 						HostedZoneRecordPrefix: configAfterLoad.HostedZoneRecordPrefix,
 						Namespace:              configAfterLoad.Namespace,
+						NodeExporterPort:       config.NodeExporterPort,
 						NetworkCIDR:            configAfterLoad.NetworkCIDR,
 						PrivateCIDR:            configAfterLoad.PrivateCIDR,
 						Project:                configAfterLoad.Project,
+						PrometheusPort:         config.PrometheusPort,
 						PublicCIDR:             configAfterLoad.PublicCIDR,
 						PublicKey:              configAfterLoad.PublicKey,
 						RDS1CIDR:               configAfterLoad.RDS1CIDR,
@@ -545,6 +577,7 @@ wEW5QkylaPEkbVDhJWeR1I8=
 						Region:                 configAfterLoad.Region,
 						SourceAccessIP:         configAfterLoad.SourceAccessIP,
 						TFStatePath:            configAfterLoad.TFStatePath,
+						WebCount:               configAfterLoad.ConcourseWebCount,
 					}
 
 					configAfterCreateEnv = configAfterLoad
@@ -609,6 +642,7 @@ wEW5QkylaPEkbVDhJWeR1I8=
 					AvailabilityZone:         "eu-west-1a",
 					ConcoursePassword:        "",
 					ConcourseUsername:        "",
+					ConcourseWebCount:        1,
 					ConcourseWebSize:         "small",
 					ConcourseWorkerCount:     1,
 					ConcourseWorkerSize:      "xlarge",
@@ -636,6 +670,9 @@ wEW5QkylaPEkbVDhJWeR1I8=
 					RDSUsername:              "admingeneratedPassword7",
 					Region:                   "eu-west-1",
 					SourceAccessIP:           "192.0.2.0",
+					StemcellOS:               "xenial",
+					CredentialManager:        "credhub",
+					Metrics:                  "influxdb",
 					TFStatePath:              "terraform.tfstate",
 					WorkerType:               "m4",
 					VMProvisioningType:       config.SPOT,
@@ -716,11 +753,15 @@ wEW5QkylaPEkbVDhJWeR1I8=
 					AllowIPs:               defaultGeneratedConfig.AllowIPs,
 					AvailabilityZone:       defaultGeneratedConfig.AvailabilityZone,
 					ConfigBucket:           defaultGeneratedConfig.ConfigBucket,
+					CredentialManager:      defaultGeneratedConfig.CredentialManager,
+					Metrics:                defaultGeneratedConfig.Metrics,
 					Deployment:             defaultGeneratedConfig.Deployment,
 					HostedZoneID:           defaultGeneratedConfig.HostedZoneID,
 					HostedZoneRecordPrefix: defaultGeneratedConfig.HostedZoneRecordPrefix,
 					Namespace:              defaultGeneratedConfig.Namespace,
+					NodeExporterPort:       config.NodeExporterPort,
 					Project:                defaultGeneratedConfig.Project,
+					PrometheusPort:         config.PrometheusPort,
 					PublicKey:              defaultGeneratedConfig.PublicKey,
 					RDS1CIDR:               defaultGeneratedConfig.RDS1CIDR,
 					RDS2CIDR:               defaultGeneratedConfig.RDS2CIDR,
@@ -731,6 +772,7 @@ wEW5QkylaPEkbVDhJWeR1I8=
 					Region:                 defaultGeneratedConfig.Region,
 					SourceAccessIP:         defaultGeneratedConfig.SourceAccessIP,
 					TFStatePath:            defaultGeneratedConfig.TFStatePath,
+					WebCount:               defaultGeneratedConfig.ConcourseWebCount,
 				}
 
 				tfInputVarsFactory.NewInputVarsReturns(terraformInputVars)
@@ -876,6 +918,38 @@ wEW5QkylaPEkbVDhJWeR1I8=
 			})
 		})
 
+		Context("When running with --dry-run", func() {
+			BeforeEach(func() {
+				args.DryRun = true
+			})
+
+			It("Plans the Concourse deployment without changing anything", func() {
+				configClient.ConfigExistsReturns(true, nil)
+				configClient.HasAssetReturns(true, nil)
+				configClient.LoadAssetReturns(directorCredsFixture, nil)
+
+				client := buildClient()
+				err := client.Deploy()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(boshClient).To(HaveReceived("PlanConcourse").With(directorCredsFixture))
+				Expect(boshClient).To(HaveReceived("Cleanup"))
+				Expect(boshClient.DeployCallCount()).To(Equal(0))
+				Expect(terraformCLI.ApplyCallCount()).To(Equal(0))
+				Expect(configClient.UpdateCallCount()).To(Equal(0))
+				Expect(configClient.StoreAssetCallCount()).To(Equal(0))
+				Expect(flyClient.SetDefaultPipelineCallCount()).To(Equal(0))
+			})
+
+			It("Fails without an existing deployment", func() {
+				configClient.ConfigExistsReturns(false, nil)
+
+				client := buildClient()
+				err := client.Deploy()
+				Expect(err).To(MatchError("--dry-run can only show changes to an existing deployment"))
+			})
+		})
+
 		Context("When running in self-update mode and the concourse is already deployed", func() {
 			It("Sets the default pipeline, before deploying the bosh director", func() {
 				flyClient.CanConnectStub = func() (bool, error) {
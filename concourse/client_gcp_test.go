@@ -207,6 +207,7 @@ var _ = Describe("client", func() {
 			AvailabilityZone:         "europe-west1-b",
 			ConcoursePassword:        "s3cret",
 			ConcourseUsername:        "admin",
+			ConcourseWebCount:        1,
 			ConcourseWebSize:         "medium",
 			ConcourseWorkerCount:     1,
 			ConcourseWorkerSize:      "large",
@@ -254,6 +255,9 @@ sWbB3FCIsym1FXB+eRnVF3Y15RwBWWKA5RfwUNpEXFxtv24tQ8jrdA==
 			RDSUsername:            "admin",
 			Region:                 "europe-west1",
 			Spot:                   true,
+			StemcellOS:             "xenial",
+			CredentialManager:      "credhub",
+			Metrics:                "influxdb",
 			TFStatePath:            "example-path",
 			//These come from fixtures/director-creds.yml
 			CredhubUsername:          "credhub-cli",
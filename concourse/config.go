@@ -6,9 +6,12 @@ import (
 	"net"
 	"strings"
 
+	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/commands/deploy"
 	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/util/yaml"
 	"github.com/asaskevich/govalidator"
 	"github.com/imdario/mergo"
 )
@@ -45,6 +48,16 @@ func (client *Client) getInitialConfig() (config.Config, bool, error) {
 			return config.Config{}, false, err
 		}
 
+		err = client.warnIfStemcellOSChanging(conf)
+		if err != nil {
+			return config.Config{}, false, err
+		}
+
+		err = client.warnIfCredentialManagerChanging(conf)
+		if err != nil {
+			return config.Config{}, false, err
+		}
+
 		conf, isDomainUpdated, err = applyArgumentsToConfig(conf, client.deployArgs, client.provider)
 		if err != nil {
 			return config.Config{}, false, fmt.Errorf("error merging new options with existing config: [%v]", err)
@@ -81,6 +94,30 @@ func assertImmutableFieldsNotChanging(deployArgs *deploy.Args, conf config.Confi
 	return nil
 }
 
+// Changing stemcell line is allowed, but BOSH will recreate every Concourse VM to move it onto the new stemcell
+func (client *Client) warnIfStemcellOSChanging(conf config.ConfigView) error {
+	if !client.deployArgs.StemcellOSIsSet || client.deployArgs.StemcellOS == conf.GetStemcellOS() {
+		return nil
+	}
+
+	_, err := client.stderr.Write([]byte(fmt.Sprintf(
+		"\nWARNING: changing stemcell OS from %s to %s will recreate all Concourse VMs using the deployment's update strategy\n\n",
+		conf.GetStemcellOS(), client.deployArgs.StemcellOS)))
+	return err
+}
+
+// Credentials are not copied between credential managers, so pipelines will fail to interpolate until they are re-added
+func (client *Client) warnIfCredentialManagerChanging(conf config.ConfigView) error {
+	if !client.deployArgs.CredentialManagerIsSet || client.deployArgs.CredentialManager == conf.GetCredentialManager() {
+		return nil
+	}
+
+	_, err := client.stderr.Write([]byte(fmt.Sprintf(
+		"\nWARNING: changing credential manager from %s to %s does not migrate existing credentials\n\n",
+		conf.GetCredentialManager(), client.deployArgs.CredentialManager)))
+	return err
+}
+
 func populateConfigWithDefaults(conf config.Config, provider iaas.Provider, passwordGenerator func(int) string, sshGenerator func() ([]byte, []byte, string, error), eightRandomLetters func() string) (config.Config, error) {
 	const defaultPasswordLength = 20
 
@@ -90,6 +127,7 @@ func populateConfigWithDefaults(conf config.Config, provider iaas.Provider, pass
 	}
 
 	conf.AvailabilityZone = ""
+	conf.ConcourseWebCount = 1
 	conf.ConcourseWebSize = "small"
 	conf.ConcourseWorkerCount = 1
 	conf.ConcourseWorkerSize = "xlarge"
@@ -106,6 +144,9 @@ func populateConfigWithDefaults(conf config.Config, provider iaas.Provider, pass
 	conf.RDSInstanceClass = provider.DBType("small")
 	conf.RDSPassword = passwordGenerator(defaultPasswordLength)
 	conf.RDSUsername = "admin" + passwordGenerator(7)
+	conf.StemcellOS = config.DefaultStemcellOS
+	conf.CredentialManager = config.CredentialManagerCredhub
+	conf.Metrics = config.MetricsInfluxDB
 	conf.VMProvisioningType = config.SPOT
 	conf.WorkerType = "m4"
 	conf = populateConfigWithDefaultCIDRs(conf, provider)
@@ -133,6 +174,9 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 
 	conf.AllowIPs = allowedIPs
 
+	if deployArgs.ArtefactMirrorIsSet {
+		conf.ArtefactMirror = deployArgs.ArtefactMirror
+	}
 	if deployArgs.ZoneIsSet {
 		conf.AvailabilityZone = deployArgs.Zone
 	}
@@ -142,6 +186,67 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 	if deployArgs.WorkerSizeIsSet {
 		conf.ConcourseWorkerSize = deployArgs.WorkerSize
 	}
+	if deployArgs.WorkerPoolsIsSet {
+		conf.WorkerPools = nil
+		for _, spec := range deployArgs.WorkerPools {
+			pool, err := config.ParseWorkerPool(spec)
+			if err != nil {
+				return config.Config{}, false, err
+			}
+			conf.WorkerPools = append(conf.WorkerPools, pool)
+		}
+	}
+	if deployArgs.AutoscaleMaxWorkersIsSet {
+		conf.AutoscaleMaxWorkers = deployArgs.AutoscaleMaxWorkers
+	}
+	if conf.IsAutoscaling() {
+		// Settings that have never been given take the flags' defaults
+		if deployArgs.AutoscaleMinWorkersIsSet || conf.AutoscaleMinWorkers == 0 {
+			conf.AutoscaleMinWorkers = deployArgs.AutoscaleMinWorkers
+		}
+		if deployArgs.AutoscaleHighContainersIsSet || conf.AutoscaleHighContainers == 0 {
+			conf.AutoscaleHighContainers = deployArgs.AutoscaleHighContainers
+		}
+		if deployArgs.AutoscaleLowContainersIsSet || conf.AutoscaleLowContainers == 0 {
+			conf.AutoscaleLowContainers = deployArgs.AutoscaleLowContainers
+		}
+		if deployArgs.AutoscaleHighVolumesIsSet || conf.AutoscaleHighVolumes == 0 {
+			conf.AutoscaleHighVolumes = deployArgs.AutoscaleHighVolumes
+		}
+		if deployArgs.AutoscaleLowVolumesIsSet || conf.AutoscaleLowVolumes == 0 {
+			conf.AutoscaleLowVolumes = deployArgs.AutoscaleLowVolumes
+		}
+		if deployArgs.AutoscaleCooldownIsSet || conf.AutoscaleCooldown == "" {
+			conf.AutoscaleCooldown = deployArgs.AutoscaleCooldown
+		}
+	}
+	if deployArgs.ScheduleDownIsSet {
+		conf.ScheduleDown = deployArgs.ScheduleDown
+	}
+	if deployArgs.ScheduleUpIsSet {
+		conf.ScheduleUp = deployArgs.ScheduleUp
+	}
+	if deployArgs.ScheduleTimezoneIsSet || conf.ScheduleTimezone == "" {
+		conf.ScheduleTimezone = deployArgs.ScheduleTimezone
+	}
+	if deployArgs.ScheduleWorkersIsSet {
+		conf.ScheduleWorkers = deployArgs.ScheduleWorkers
+	}
+	if deployArgs.ScheduleStopIsSet {
+		conf.ScheduleStop = deployArgs.ScheduleStop
+	}
+	if !conf.IsScheduled() && conf.ScheduleState == config.ScheduleStateDown {
+		// Removing the schedule brings scaled down workers back up
+		conf.ScheduleState = ""
+	}
+	conf.ConcourseProperties = deployArgs.ApplyConcourseProperties(conf.ConcourseProperties)
+	conf.SelfUpdate = deployArgs.ApplySelfUpdate(conf.SelfUpdate)
+	conf.MaintenanceWindow = deployArgs.ApplyMaintenanceWindow(conf.MaintenanceWindow)
+	conf.Notifications = deployArgs.ApplyNotifications(conf.Notifications)
+	conf.Proxy = deployArgs.ApplyProxy(conf.Proxy)
+	if deployArgs.WebCountIsSet {
+		conf.ConcourseWebCount = deployArgs.WebCount
+	}
 	if deployArgs.WebSizeIsSet {
 		conf.ConcourseWebSize = deployArgs.WebSize
 	}
@@ -152,6 +257,82 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 		conf.GithubClientID = deployArgs.GithubAuthClientID
 		conf.GithubClientSecret = deployArgs.GithubAuthClientSecret
 	}
+	if deployArgs.MainTeamGithubOrgsIsSet {
+		conf.MainTeamGithubOrgs = deployArgs.MainTeamGithubOrgs
+	}
+	if deployArgs.MainTeamGithubTeamsIsSet {
+		conf.MainTeamGithubTeams = deployArgs.MainTeamGithubTeams
+	}
+	if deployArgs.MainTeamGithubUsersIsSet {
+		conf.MainTeamGithubUsers = deployArgs.MainTeamGithubUsers
+	}
+	if deployArgs.OIDCAuthIsSet {
+		conf.OIDCIssuer = deployArgs.OIDCAuthIssuer
+		conf.OIDCClientID = deployArgs.OIDCAuthClientID
+		conf.OIDCClientSecret = deployArgs.OIDCAuthClientSecret
+	}
+	if deployArgs.OIDCAuthScopesIsSet {
+		conf.OIDCScopes = deployArgs.OIDCAuthScopes
+	}
+	if deployArgs.OIDCAuthGroupsClaimIsSet {
+		conf.OIDCGroupsClaim = deployArgs.OIDCAuthGroupsClaim
+	}
+	if deployArgs.MainTeamOIDCGroupsIsSet {
+		conf.MainTeamOIDCGroups = deployArgs.MainTeamOIDCGroups
+	}
+	if deployArgs.MainTeamOIDCUsersIsSet {
+		conf.MainTeamOIDCUsers = deployArgs.MainTeamOIDCUsers
+	}
+	if deployArgs.GitLabAuthIsSet {
+		conf.GitLabClientID = deployArgs.GitLabAuthClientID
+		conf.GitLabClientSecret = deployArgs.GitLabAuthClientSecret
+	}
+	if deployArgs.GitLabAuthHostIsSet {
+		conf.GitLabHost = deployArgs.GitLabAuthHost
+	}
+	if deployArgs.MainTeamGitLabGroupsIsSet {
+		conf.MainTeamGitLabGroups = deployArgs.MainTeamGitLabGroups
+	}
+	if deployArgs.MainTeamGitLabUsersIsSet {
+		conf.MainTeamGitLabUsers = deployArgs.MainTeamGitLabUsers
+	}
+	if deployArgs.BitbucketCloudAuthIsSet {
+		conf.BitbucketCloudClientID = deployArgs.BitbucketCloudAuthClientID
+		conf.BitbucketCloudClientSecret = deployArgs.BitbucketCloudAuthClientSecret
+	}
+	if deployArgs.MainTeamBitbucketCloudTeamsIsSet {
+		conf.MainTeamBitbucketCloudTeams = deployArgs.MainTeamBitbucketCloudTeams
+	}
+	if deployArgs.MainTeamBitbucketCloudUsersIsSet {
+		conf.MainTeamBitbucketCloudUsers = deployArgs.MainTeamBitbucketCloudUsers
+	}
+	if deployArgs.LDAPAuthIsSet {
+		conf.LDAPHost = deployArgs.LDAPAuthHost
+		conf.LDAPBindDN = deployArgs.LDAPAuthBindDN
+		conf.LDAPBindPassword = deployArgs.LDAPAuthBindPassword
+		conf.LDAPUserSearchBaseDN = deployArgs.LDAPAuthUserSearchBaseDN
+	}
+	if deployArgs.LDAPAuthUserSearchUsernameIsSet {
+		conf.LDAPUserSearchUsername = deployArgs.LDAPAuthUserSearchUsername
+	}
+	if deployArgs.LDAPAuthGroupSearchBaseDNIsSet {
+		conf.LDAPGroupSearchBaseDN = deployArgs.LDAPAuthGroupSearchBaseDN
+	}
+	if deployArgs.LDAPAuthCACertIsSet {
+		conf.LDAPCACert = deployArgs.LDAPAuthCACert
+	}
+	if deployArgs.MainTeamLDAPGroupsIsSet {
+		conf.MainTeamLDAPGroups = deployArgs.MainTeamLDAPGroups
+	}
+	if deployArgs.MainTeamLDAPUsersIsSet {
+		conf.MainTeamLDAPUsers = deployArgs.MainTeamLDAPUsers
+	}
+	if deployArgs.TeamsFileIsSet {
+		conf.ManageTeams = true
+	}
+	if deployArgs.UnmanageTeamsIsSet {
+		conf.ManageTeams = !deployArgs.UnmanageTeams
+	}
 	if deployArgs.TagsIsSet {
 		conf.Tags = deployArgs.Tags
 	}
@@ -161,6 +342,154 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 	if deployArgs.WorkerTypeIsSet {
 		conf.WorkerType = deployArgs.WorkerType
 	}
+	if deployArgs.StemcellOSIsSet {
+		conf.StemcellOS = deployArgs.StemcellOS
+	}
+	if deployArgs.ConcourseVersionIsSet {
+		conf.ConcourseVersion = deployArgs.ConcourseVersion
+	}
+	if deployArgs.CredentialManagerIsSet {
+		conf.CredentialManager = deployArgs.CredentialManager
+	}
+	if deployArgs.VaultURLIsSet {
+		conf.VaultURL = deployArgs.VaultURL
+	}
+	if deployArgs.VaultAuthBackendIsSet {
+		conf.VaultAuthBackend = deployArgs.VaultAuthBackend
+	}
+	if deployArgs.VaultRoleIDIsSet {
+		conf.VaultRoleID = deployArgs.VaultRoleID
+	}
+	if deployArgs.VaultSecretIDIsSet {
+		conf.VaultSecretID = deployArgs.VaultSecretID
+	}
+	if deployArgs.VaultClientCertIsSet {
+		conf.VaultClientCert = deployArgs.VaultClientCert
+	}
+	if deployArgs.VaultClientKeyIsSet {
+		conf.VaultClientKey = deployArgs.VaultClientKey
+	}
+	if deployArgs.VaultPathPrefixIsSet {
+		conf.VaultPathPrefix = deployArgs.VaultPathPrefix
+	}
+	if deployArgs.VaultCACertIsSet {
+		conf.VaultCACert = deployArgs.VaultCACert
+	}
+	if deployArgs.AWSCredentialPathPrefixIsSet {
+		conf.AWSCredentialPathPrefix = deployArgs.AWSCredentialPathPrefix
+	}
+	if conf.IsAWSCredentialManager() && conf.AWSCredentialPathPrefix == "" {
+		conf.AWSCredentialPathPrefix = config.DefaultAWSCredentialPathPrefix
+	}
+
+	if deployArgs.MetricsIsSet {
+		conf.Metrics = deployArgs.Metrics
+	}
+	if deployArgs.MetricsAllowIPsIsSet {
+		metricsAllow, err := parseAllowedIPsCIDRs(deployArgs.MetricsAllowIPs)
+		if err != nil {
+			return config.Config{}, false, fmt.Errorf("error determining IP addresses to allow metrics scraping from: [%v]", err)
+		}
+		conf.MetricsAllowIPs, err = metricsAllow.String()
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.DisableGrafanaIsSet {
+		conf.DisableGrafana = deployArgs.DisableGrafana
+	}
+	if deployArgs.TSAAllowIPsIsSet {
+		tsaAllow, err := parseAllowedIPsCIDRs(deployArgs.TSAAllowIPs)
+		if err != nil {
+			return config.Config{}, false, fmt.Errorf("error determining IP addresses to allow external workers from: [%v]", err)
+		}
+		conf.TSAAllowIPs, err = tsaAllow.String()
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.GrafanaDashboardsDirIsSet {
+		conf.GrafanaDashboards, conf.GrafanaNotifiers, err = readGrafanaDashboardsDir(deployArgs.GrafanaDashboardsDir)
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.DirectorOpsFilesIsSet {
+		conf.DirectorOpsFiles, err = readCustomFiles(deployArgs.DirectorOpsFiles, opsValidator(func() (string, error) { return bosh.DirectorManifest(provider.IAAS()) }))
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.CloudConfigOpsFilesIsSet {
+		conf.CloudConfigOpsFiles, err = readCustomFiles(deployArgs.CloudConfigOpsFiles, opsValidator(func() (string, error) { return bosh.CloudConfig(provider.IAAS()) }))
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.ConcourseOpsFilesIsSet {
+		conf.ConcourseOpsFiles, err = readCustomFiles(deployArgs.ConcourseOpsFiles, opsValidator(bosh.ConcourseManifest))
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.VarsFilesIsSet {
+		conf.VarsFiles, err = readCustomFiles(deployArgs.VarsFiles, yaml.ValidateVars)
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	if deployArgs.RuntimeConfigIsSet {
+		conf.RuntimeConfig, err = readRuntimeConfig(deployArgs.RuntimeConfig)
+		if err != nil {
+			return config.Config{}, false, err
+		}
+	}
+	// Settings persist between deploys and may be given on separate ones, so they are only checked once
+	// merged with the stored config
+	if err := validateVaultConfig(conf); err != nil {
+		return conf, false, err
+	}
+	if err := validateMetricsConfig(conf); err != nil {
+		return conf, false, err
+	}
+	if err := validateAutoscaleConfig(conf, deployArgs.WorkerCountIsSet); err != nil {
+		return conf, false, err
+	}
+	if err := validateScheduleConfig(conf); err != nil {
+		return conf, false, err
+	}
+	if err := validateConcourseVersion(conf, provider); err != nil {
+		return conf, false, err
+	}
+	if err := validateMaintenanceWindowConfig(conf); err != nil {
+		return conf, false, err
+	}
+	if err := validateNotificationsConfig(conf); err != nil {
+		return conf, false, err
+	}
+	if err := conf.SelfUpdate.Validate(); err != nil {
+		return conf, false, err
+	}
+	// The self-update pipeline reads the token from CredHub
+	if conf.SelfUpdate.HasGitHubAccessToken() && !conf.IsCredhubCredentialManager() {
+		return conf, false, fmt.Errorf("--self-update-github-token requires --credential-manager credhub, which the self-update pipeline reads the token from")
+	}
+	if conf.IsAutoscaling() {
+		conf.ConcourseWorkerCount = clampWorkerCount(conf.ConcourseWorkerCount, conf)
+	}
+
+	if deployArgs.SyslogAddressIsSet {
+		conf.SyslogAddress = deployArgs.SyslogAddress
+	}
+	if deployArgs.SyslogTransportIsSet {
+		conf.SyslogTransport = deployArgs.SyslogTransport
+	}
+	if deployArgs.SyslogCACertIsSet {
+		conf.SyslogCACert = deployArgs.SyslogCACert
+	}
+	if deployArgs.SyslogPermittedPeerIsSet {
+		conf.SyslogPermittedPeer = deployArgs.SyslogPermittedPeer
+	}
 
 	if deployArgs.EnableGlobalResourcesIsSet {
 		conf.EnableGlobalResources = deployArgs.EnableGlobalResources
@@ -177,10 +506,108 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 			conf.Domain = ""
 		}
 	}
+	// The load balancer has its own address, so the web nodes need a domain to share a certificate
+	if conf.IsWebLoadBalanced() && conf.Domain == "" {
+		return conf, false, fmt.Errorf("--web-count greater than 1 requires --domain")
+	}
 
 	return conf, isDomainUpdated, nil
 }
 
+// validateVaultConfig checks that Vault has a URL and an auth backend, along with the backend's credentials
+func validateVaultConfig(conf config.Config) error {
+	if !conf.IsVaultCredentialManager() {
+		return nil
+	}
+	if conf.VaultURL == "" || conf.VaultAuthBackend == "" {
+		return fmt.Errorf("--credential-manager vault requires --vault-url and --vault-auth-backend")
+	}
+	if conf.VaultAuthBackend == "approle" && (conf.VaultRoleID == "" || conf.VaultSecretID == "") {
+		return fmt.Errorf("--vault-auth-backend approle requires --vault-approle-role-id and --vault-approle-secret-id")
+	}
+	if conf.VaultAuthBackend == "cert" && (conf.VaultClientCert == "" || conf.VaultClientKey == "") {
+		return fmt.Errorf("--vault-auth-backend cert requires --vault-client-cert and --vault-client-key")
+	}
+	return nil
+}
+
+// validateMetricsConfig checks that Prometheus has addresses allowed to scrape it, and that Grafana is only disabled alongside it
+func validateMetricsConfig(conf config.Config) error {
+	if conf.IsPrometheusMetrics() && conf.MetricsAllowIPs == "" {
+		return fmt.Errorf("--metrics prometheus requires --metrics-allow-ips")
+	}
+	if conf.DisableGrafana && !conf.IsPrometheusMetrics() {
+		return fmt.Errorf("--disable-grafana requires --metrics prometheus")
+	}
+	return nil
+}
+
+// validateAutoscaleConfig checks that the worker bounds and load thresholds are ordered, and that --workers is within the bounds
+func validateAutoscaleConfig(conf config.Config, workerCountIsSet bool) error {
+	if !conf.IsAutoscaling() {
+		return nil
+	}
+	if conf.AutoscaleMinWorkers > conf.AutoscaleMaxWorkers {
+		return fmt.Errorf("--autoscale-min-workers %d is greater than --autoscale-max-workers %d", conf.AutoscaleMinWorkers, conf.AutoscaleMaxWorkers)
+	}
+	if conf.AutoscaleLowContainers >= conf.AutoscaleHighContainers {
+		return fmt.Errorf("--autoscale-low-containers must be less than --autoscale-high-containers")
+	}
+	if conf.AutoscaleLowVolumes >= conf.AutoscaleHighVolumes {
+		return fmt.Errorf("--autoscale-low-volumes must be less than --autoscale-high-volumes")
+	}
+	if workerCountIsSet && clampWorkerCount(conf.ConcourseWorkerCount, conf) != conf.ConcourseWorkerCount {
+		return fmt.Errorf("--workers must be between --autoscale-min-workers %d and --autoscale-max-workers %d", conf.AutoscaleMinWorkers, conf.AutoscaleMaxWorkers)
+	}
+	return nil
+}
+
+// validateScheduleConfig checks that the schedule has both of its times, and that it has not stopped the deployment
+func validateScheduleConfig(conf config.Config) error {
+	if (conf.ScheduleDown == "") != (conf.ScheduleUp == "") {
+		return fmt.Errorf("--schedule-down and --schedule-up must be given together")
+	}
+	if conf.ScheduleStop && !conf.IsScheduled() {
+		return fmt.Errorf("--schedule-stop requires --schedule-down and --schedule-up")
+	}
+	if conf.ScheduleState == config.ScheduleStateStopped {
+		return fmt.Errorf("deployment was stopped by its schedule, run `control-tower schedule --up %s` before deploying", strings.TrimPrefix(conf.Deployment, "control-tower-"))
+	}
+	return nil
+}
+
+// validateMaintenanceWindowConfig checks that the maintenance window has a start, a stop and only then days
+func validateMaintenanceWindowConfig(conf config.Config) error {
+	w := conf.MaintenanceWindow
+	if (w.Start == "") != (w.Stop == "") {
+		return fmt.Errorf("--maintenance-window-start and --maintenance-window-stop must be given together")
+	}
+	if len(w.Days) > 0 && !w.IsSet() {
+		return fmt.Errorf("--maintenance-window-day requires --maintenance-window-start and --maintenance-window-stop")
+	}
+	return nil
+}
+
+// validateNotificationsConfig checks that SMTP notifications have a sender and recipients
+func validateNotificationsConfig(conf config.Config) error {
+	n := conf.Notifications
+	if n.SMTPHost != "" && (n.SMTPFrom == "" || len(n.SMTPTo) == 0) {
+		return fmt.Errorf("--notify-smtp-host requires --notify-smtp-from and --notify-smtp-to")
+	}
+	return nil
+}
+
+// The pinned version persists between deploys, so it is checked against the stemcell and the
+// compatibility table of the running control-tower, which may be newer than the one that pinned it
+func validateConcourseVersion(conf config.Config, provider iaas.Provider) error {
+	if conf.ConcourseVersion == "" {
+		return nil
+	}
+	releaseVersions, _ := provider.Choose(iaas.Choice{AWS: resource.AWSReleaseVersions, GCP: resource.GCPReleaseVersions}).(string)
+	_, err := resource.FindConcourseVersion(resource.ConcourseVersions, resource.DefaultConcourseVersion(releaseVersions), conf.ConcourseVersion, conf.StemcellOS)
+	return err
+}
+
 // Set config fields that are only valid on first deployment
 func applyImmutableArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provider iaas.Provider) config.Config {
 	if hasCIDRFlagsSet(deployArgs, provider) {
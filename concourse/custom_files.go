@@ -0,0 +1,57 @@
+package concourse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/util/yaml"
+)
+
+// readCustomFiles returns the contents of each of paths, checked with validate. Empty paths are
+// skipped, so that a single empty path removes the files given to previous deploys
+func readCustomFiles(paths []string, validate func(string) error) ([]config.CustomFile, error) {
+	var files []config.CustomFile
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = validate(string(contents)); err != nil {
+			return nil, fmt.Errorf("%s is not valid: [%v]", path, err)
+		}
+		files = append(files, config.CustomFile{
+			Name:     filepath.Base(path),
+			Contents: string(contents),
+		})
+	}
+	return files, nil
+}
+
+// opsValidator returns a check that an ops file is a list of go-patch operations that apply to the
+// manifest it is for, which is only built once there is a file to check
+func opsValidator(manifest func() (string, error)) func(string) error {
+	return func(ops string) error {
+		contents, err := manifest()
+		if err != nil {
+			return fmt.Errorf("failed to build the manifest to check ops against: [%v]", err)
+		}
+		return yaml.ValidateOps(ops, contents)
+	}
+}
+
+// readRuntimeConfig returns the contents of the runtime config at path, or nothing if path is empty
+func readRuntimeConfig(path string) (string, error) {
+	files, err := readCustomFiles([]string{path}, func(contents string) error {
+		_, err := config.RuntimeConfigReleases(contents)
+		return err
+	})
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	return files[0].Contents, nil
+}
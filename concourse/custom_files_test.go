@@ -0,0 +1,90 @@
+package concourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/util/yaml"
+)
+
+func TestReadCustomFiles(t *testing.T) {
+	validateOps := opsValidator(func() (string, error) {
+		return "instance_groups:\n- name: web\n  instances: 1\n", nil
+	})
+	tests := []struct {
+		name        string
+		contents    string
+		validate    func(string) error
+		expectedErr string
+	}{
+		{
+			name:     "ops file",
+			contents: "- type: replace\n  path: /instance_groups/name=web/instances\n  value: 2\n",
+			validate: validateOps,
+		},
+		{
+			name:        "ops file with an unknown operation",
+			contents:    "- type: merge\n  path: /instance_groups\n",
+			validate:    validateOps,
+			expectedErr: "is not valid",
+		},
+		{
+			name:        "ops file for an instance group the manifest does not have",
+			contents:    "- type: replace\n  path: /instance_groups/name=db/instances\n  value: 2\n",
+			validate:    validateOps,
+			expectedErr: "is not valid",
+		},
+		{
+			name:     "ops file that adds to the manifest and uses a variable",
+			contents: "- type: replace\n  path: /instance_groups/name=web/vm_extensions?\n  value: [((extension))]\n",
+			validate: validateOps,
+		},
+		{
+			name:        "ops file that is not a list",
+			contents:    "type: replace\n",
+			validate:    validateOps,
+			expectedErr: "is not valid",
+		},
+		{
+			name:     "vars file",
+			contents: "disk_size: 100\nteam: ops\n",
+			validate: yaml.ValidateVars,
+		},
+		{
+			name:        "vars file that is not a map",
+			contents:    "- disk_size\n",
+			validate:    yaml.ValidateVars,
+			expectedErr: "is not valid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "custom-files")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "custom.yml")
+			if err = ioutil.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := readCustomFiles([]string{path, ""}, tt.validate)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("readCustomFiles() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCustomFiles() unexpected error = %v", err)
+			}
+			if len(files) != 1 || files[0].Name != "custom.yml" || files[0].Contents != tt.contents {
+				t.Errorf("readCustomFiles() = %+v, want a single custom.yml with the file's contents", files)
+			}
+		})
+	}
+}
@@ -49,6 +49,10 @@ func stripVersion(tags []string) []string {
 
 // Deploy deploys a concourse instance
 func (client *Client) Deploy() error {
+	if client.deployArgs.DryRun {
+		return client.planDeploy()
+	}
+
 	err := client.configClient.EnsureBucketExists()
 	if err != nil {
 		return fmt.Errorf("error ensuring config bucket exists before deploy: [%v]", err)
@@ -59,6 +63,11 @@ func (client *Client) Deploy() error {
 		return fmt.Errorf("error getting initial config before deploy: [%v]", err)
 	}
 
+	err = client.storeTeamsFile()
+	if err != nil {
+		return err
+	}
+
 	r, err := client.checkPreTerraformConfigRequirements(conf, client.deployArgs.SelfUpdate)
 	if err != nil {
 		return err
@@ -169,16 +178,27 @@ func (client *Client) deployBoshAndPipeline(c config.ConfigView, tfOutputs terra
 	}
 	defer flyClient.Cleanup()
 
+	if err := storeSelfUpdateToken(c.GetSelfUpdate().GitHubAccessToken, bp); err != nil {
+		return bp, err
+	}
+
 	if err := flyClient.SetDefaultPipeline(c, false); err != nil {
 		return bp, err
 	}
 
+	if err := client.setTeams(flyClient, c); err != nil {
+		return bp, err
+	}
+
 	params := deployMessageParams{
 		ConcoursePassword:         bp.ConcoursePassword,
 		ConcourseUsername:         bp.ConcourseUsername,
 		ConcourseUserProvidedCert: client.deployArgs.TLSCertIsSet && client.deployArgs.TLSKeyIsSet,
+		CredentialManager:         c.GetCredentialManager(),
+		DisableGrafana:            c.GetDisableGrafana(),
 		Domain:                    c.GetDomain(),
 		IAAS:                      c.GetIAAS(),
+		Metrics:                   c.GetMetrics(),
 		Namespace:                 c.GetNamespace(),
 		Project:                   c.GetProject(),
 		Region:                    c.GetRegion(),
@@ -230,11 +250,16 @@ func (client *Client) updateBoshAndPipeline(c config.ConfigView, tfOutputs terra
 		return bp, fmt.Errorf("In detach mode but it seems that concourse is not currently running")
 	}
 
+	if err = storeSelfUpdateToken(c.GetSelfUpdate().GitHubAccessToken, bp); err != nil {
+		return bp, err
+	}
+
 	// Allow a fly version discrepancy since we might be targetting an older Concourse
 	if err = flyClient.SetDefaultPipeline(c, true); err != nil {
 		return bp, err
 	}
 
+	// Teams are left alone here, because the director is still deploying in the background
 	bp, err = client.deployBosh(c, tfOutputs, true)
 	if err != nil {
 		return bp, err
@@ -499,11 +524,20 @@ func (client *Client) deployBosh(config config.ConfigView, tfOutputs terraform.O
 		return bp, err
 	}
 
-	bp.CredhubPassword = cc.CredhubPassword
-	bp.CredhubAdminClientSecret = cc.CredhubAdminClientSecret
-	bp.CredhubCACert = cc.InternalTLS.CA
-	bp.CredhubURL = fmt.Sprintf("https://%s:8844/", config.GetDomain())
-	bp.CredhubUsername = "credhub-cli"
+	if !config.IsCredhubCredentialManager() {
+		// CredHub is only deployed when it is the credential manager, so there are no credentials to keep
+		bp.CredhubPassword = ""
+		bp.CredhubAdminClientSecret = ""
+		bp.CredhubCACert = ""
+		bp.CredhubURL = ""
+		bp.CredhubUsername = ""
+	} else {
+		bp.CredhubPassword = cc.CredhubPassword
+		bp.CredhubAdminClientSecret = cc.CredhubAdminClientSecret
+		bp.CredhubCACert = cc.InternalTLS.CA
+		bp.CredhubURL = fmt.Sprintf("https://%s:8844/", config.GetDomain())
+		bp.CredhubUsername = "credhub-cli"
+	}
 	bp.ConcourseUsername = "admin"
 	if len(cc.AtcPassword) > 0 {
 		bp.ConcoursePassword = cc.AtcPassword
@@ -576,9 +610,10 @@ func (client *Client) setHostedZone(c config.ConfigView, domain string) (HostedZ
 const deployMsg = `DEPLOY SUCCESSFUL. Log in with:
 fly --target {{.Project}} login{{if not .ConcourseUserProvidedCert}} --insecure{{end}} --concourse-url https://{{.Domain}} --username {{.ConcourseUsername}} --password {{.ConcoursePassword}}
 
-Metrics available at https://{{.Domain}}:3000 using the same username and password
-
-Log into credhub with:
+{{if not .DisableGrafana}}Metrics available at https://{{.Domain}}:3000 using the same username and password
+{{end}}{{if eq .Metrics "prometheus"}}Prometheus metrics can be scraped from {{.Domain}}:{{prometheusPort}} and {{.Domain}}:{{nodeExporterPort}}
+{{end}}
+{{if eq .CredentialManager "credhub"}}Log into credhub with:{{else if eq .CredentialManager "vault"}}Set Vault environment variables with:{{else}}Set BOSH environment variables with:{{end}}
 eval "$(control-tower info --region {{.Region}} {{ if ne .Namespace .Region }} --namespace {{ .Namespace }} {{ end }} --iaas {{ .IAAS }} --env {{.Project}})"
 
 Please complete our quick 7-question survey so that we can learn how & why you use Control Tower! http://bit.ly/eb-ctower
@@ -588,15 +623,18 @@ type deployMessageParams struct {
 	ConcoursePassword         string
 	ConcourseUsername         string
 	ConcourseUserProvidedCert bool
+	CredentialManager         string
+	DisableGrafana            bool
 	Domain                    string
 	IAAS                      string
+	Metrics                   string
 	Namespace                 string
 	Project                   string
 	Region                    string
 }
 
 func writeDeploySuccessMessage(params deployMessageParams, stdout io.Writer) error {
-	t := template.Must(template.New("deploy").Parse(deployMsg))
+	t := template.Must(template.New("deploy").Funcs(metricsPorts).Parse(deployMsg))
 	return t.Execute(stdout, params)
 }
 
@@ -0,0 +1,56 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+const grafanaNotifiersDir = "notifiers"
+
+// readGrafanaDashboardsDir returns every dashboard JSON file in dir, and every alert
+// notification channel JSON file in its notifiers subdirectory
+func readGrafanaDashboardsDir(dir string) ([]config.CustomFile, []config.CustomFile, error) {
+	dashboards, err := readGrafanaJSONFiles(dir, "title")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notifiersDir := filepath.Join(dir, grafanaNotifiersDir)
+	if _, err = os.Stat(notifiersDir); os.IsNotExist(err) {
+		return dashboards, nil, nil
+	}
+
+	notifiers, err := readGrafanaJSONFiles(notifiersDir, "name", "type")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dashboards, notifiers, nil
+}
+
+func readGrafanaJSONFiles(dir string, requiredKeys ...string) ([]config.CustomFile, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("error reading Grafana dashboards directory: [%v]", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return readCustomFiles(paths, func(contents string) error {
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(contents), &object); err != nil {
+			return fmt.Errorf("not a JSON object: %v", err)
+		}
+		for _, key := range requiredKeys {
+			if value, ok := object[key].(string); !ok || value == "" {
+				return fmt.Errorf("missing a `%s`", key)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package concourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadGrafanaDashboardsDir(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		wantDashboards int
+		wantNotifiers  int
+		expectedErr    string
+	}{
+		{
+			name: "dashboards without notifiers",
+			files: map[string]string{
+				"builds.json":  `{"title": "Builds"}`,
+				"workers.json": `{"title": "Workers"}`,
+				"README.md":    "not a dashboard",
+			},
+			wantDashboards: 2,
+		},
+		{
+			name: "dashboards and notifiers",
+			files: map[string]string{
+				"builds.json":          `{"title": "Builds"}`,
+				"notifiers/slack.json": `{"name": "slack", "type": "slack", "settings": {"url": "https://hooks.slack.com/x"}}`,
+			},
+			wantDashboards: 1,
+			wantNotifiers:  1,
+		},
+		{
+			name: "dashboard that is not JSON",
+			files: map[string]string{
+				"builds.json": `title: Builds`,
+			},
+			expectedErr: "is not valid: [not a JSON object",
+		},
+		{
+			name: "dashboard without a title",
+			files: map[string]string{
+				"builds.json": `{"panels": []}`,
+			},
+			expectedErr: "is not valid: [missing a `title`]",
+		},
+		{
+			name: "notifier without a type",
+			files: map[string]string{
+				"notifiers/slack.json": `{"name": "slack"}`,
+			},
+			expectedErr: "is not valid: [missing a `type`]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "grafana-dashboards")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for name, contents := range tt.files {
+				path := filepath.Join(dir, name)
+				if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err = ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			dashboards, notifiers, err := readGrafanaDashboardsDir(dir)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("readGrafanaDashboardsDir() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readGrafanaDashboardsDir() unexpected error = %v", err)
+			}
+			if len(dashboards) != tt.wantDashboards || len(notifiers) != tt.wantNotifiers {
+				t.Errorf("readGrafanaDashboardsDir() = %d dashboards and %d notifiers, want %d and %d", len(dashboards), len(notifiers), tt.wantDashboards, tt.wantNotifiers)
+			}
+		})
+	}
+
+	if _, _, err := readGrafanaDashboardsDir("/does/not/exist"); err == nil {
+		t.Error("readGrafanaDashboardsDir() should fail for a missing directory")
+	}
+}
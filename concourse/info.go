@@ -24,6 +24,7 @@ type Info struct {
 	Config      config.Config   `json:"config"`
 	Instances   []bosh.Instance `json:"instances"`
 	CertExpiry  string          `json:"cert_expiry"`
+	TSAHostKey  string          `json:"tsa_host_key"`
 	GatewayUser string
 }
 
@@ -46,7 +47,7 @@ func (client *Client) FetchInfo() (*Info, error) {
 		return nil, err
 	}
 
-	var certExpiry string
+	var certExpiry, tsaHostKey string
 	if len(directorCredsBytes) > 0 {
 		natsCA, err1 := yaml.Path(directorCredsBytes, "nats_server_tls/ca")
 		if err1 != nil {
@@ -68,6 +69,11 @@ func (client *Client) FetchInfo() (*Info, error) {
 		} else {
 			return nil, fmt.Errorf("openssl output is not as expected. got: %s", out.String())
 		}
+
+		// The TSA host key is only generated once Concourse has been deployed
+		if hostKey, err1 := yaml.Path(directorCredsBytes, "tsa_host_key/public_key"); err1 == nil {
+			tsaHostKey = strings.TrimSpace(hostKey)
+		}
 	}
 
 	tfInputVars := client.tfInputVarsFactory.NewInputVars(conf)
@@ -135,18 +141,73 @@ func (client *Client) FetchInfo() (*Info, error) {
 		Instances:   instances,
 		GatewayUser: gatewayUser,
 		CertExpiry:  certExpiry,
+		TSAHostKey:  tsaHostKey,
 	}, nil
 }
 
 const infoTemplate = `Deployment:
-	Namespace: {{.Config.Namespace}}
-	IAAS:      {{.Config.IAAS}}
-	Region:    {{.Config.Region}}
+	Namespace:   {{.Config.Namespace}}
+	IAAS:        {{.Config.IAAS}}
+	Region:      {{.Config.Region}}
+	Stemcell OS: {{.Config.StemcellOS}}
+{{- if .Config.ConcourseVersion}}
+	Concourse:   {{.Config.ConcourseVersion}} (pinned)
+{{- end}}
+{{- if .Config.SyslogAddress}}
+	Syslog:      {{.Config.SyslogAddress}}
+{{- end}}
+{{- if .Config.MaintenanceWindow.IsSet}}
+	Maintenance: {{.Config.MaintenanceWindow.Start}} to {{.Config.MaintenanceWindow.Stop}} ({{.Config.MaintenanceWindow.Location}}){{with .Config.MaintenanceWindow.Days}} on {{join . ", "}}{{end}}
+{{- end}}
+{{- if gt .Config.ConcourseWebCount 1}}
+
+Web:
+	Count: {{.Config.ConcourseWebCount}}
+	Size:  {{.Config.ConcourseWebSize}}
+{{- end}}
 
 Workers:
-	Count:              {{.Config.ConcourseWorkerCount}}
+	Count:              {{.Config.ConcourseWorkerCount}}{{if .Config.IsScheduledDown}} ({{.Config.GetDeployedWorkerCount}} while {{.Config.ScheduleState}} by schedule){{end}}
 	Size:               {{.Config.ConcourseWorkerSize}}
 	Outbound Public IP: {{.Terraform.NatGatewayIP}}
+{{- if .Config.IsAutoscaling}}
+	Autoscaling:        {{.Config.AutoscaleMinWorkers}}-{{.Config.AutoscaleMaxWorkers}} workers, {{.Config.AutoscaleLowContainers}}-{{.Config.AutoscaleHighContainers}} containers and {{.Config.AutoscaleLowVolumes}}-{{.Config.AutoscaleHighVolumes}} volumes per worker
+{{- end}}
+{{- if .Config.IsScheduled}}
+	Schedule:           down at "{{.Config.ScheduleDown}}", up at "{{.Config.ScheduleUp}}" ({{.Config.ScheduleTimezone}}), {{if .Config.ScheduleStop}}stopping the web node and database{{else}}{{.Config.ScheduleWorkers}} workers while down{{end}}
+{{- end}}
+{{- if .Config.WorkerPools}}
+
+Worker pools:
+{{- range .Config.WorkerPools}}
+	{{.Name}}:
+		Running:      {{$.WorkerPoolRunning .Name}}/{{.Count}}
+		Size:         {{.Size}}
+		Provisioning: {{.VMProvisioningType}}
+{{- if .Tags}}
+		Tags:         {{join .Tags ", "}}
+{{- end}}
+{{- if .Team}}
+		Team:         {{.Team}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if or .Config.DirectorOpsFiles .Config.CloudConfigOpsFiles .Config.ConcourseOpsFiles .Config.VarsFiles}}
+
+Custom files:
+{{- with .Config.DirectorOpsFiles}}
+	Director ops:     {{fileNames .}}
+{{- end}}
+{{- with .Config.CloudConfigOpsFiles}}
+	Cloud config ops: {{fileNames .}}
+{{- end}}
+{{- with .Config.ConcourseOpsFiles}}
+	Concourse ops:    {{fileNames .}}
+{{- end}}
+{{- with .Config.VarsFiles}}
+	Concourse vars:   {{fileNames .}}
+{{- end}}
+{{- end}}
 
 Instances:
 {{range .Instances}}
@@ -158,18 +219,40 @@ Concourse credentials:
 	password: {{.Config.ConcoursePassword}}
 	URL:      https://{{.Config.Domain}}
 
-Credhub credentials:
+{{if .TSAHostKey}}External worker registration (TSA):
+	Host:     {{.Config.Domain}}
+	Port:     2222
+	Host key: {{.TSAHostKey}}
+{{- if .Config.ExternalWorkers}}
+	Authorised workers:
+{{- range .Config.ExternalWorkers}}
+		{{.Name}}{{if .Team}} (team {{.Team}}){{end}}
+{{- end}}
+{{- end}}
+
+{{end}}{{if eq .Config.CredentialManager "vault"}}Vault:
+	URL:          {{.Config.VaultURL}}
+	Auth backend: {{.Config.VaultAuthBackend}}
+	Path prefix:  {{.Config.VaultPathPrefix}}
+{{else if or (eq .Config.CredentialManager "aws-secretsmanager") (eq .Config.CredentialManager "aws-ssm")}}Credential manager:
+	Type:        {{.Config.CredentialManager}}
+	Path prefix: {{.Config.AWSCredentialPathPrefix}}
+{{else}}Credhub credentials:
 	username: {{.Config.CredhubUsername}}
 	password: {{.Config.CredhubPassword}}
 	URL:      {{.Config.CredhubURL}}
 	CA Cert:
 		{{ .Config.CredhubCACert | replace "\n" "\n\t\t"}}
+{{end}}
+{{if eq .Config.Metrics "prometheus"}}Prometheus scrape targets:
+	concourse:     {{.Config.Domain}}:{{prometheusPort}}
+	node_exporter: {{.Config.Domain}}:{{nodeExporterPort}}
 
-Grafana credentials:
+{{end}}{{if not .Config.DisableGrafana}}Grafana credentials:
 	username: {{.Config.ConcourseUsername}}
 	password: {{.Config.ConcoursePassword}}
 	URL:      https://{{.Config.Domain}}:3000
-
+{{end}}
 Bosh credentials:
 	username: {{.Config.DirectorUsername}}
 	password: {{.Config.DirectorPassword}}
@@ -184,12 +267,26 @@ Uses Control-Tower version {{.Config.Version}}
 Built by {{"EngineerBetter http://engineerbetter.com" | blue}}
 `
 
+// metricsPorts are template functions for the ports that Prometheus scrapes on the web node
+var metricsPorts = template.FuncMap{
+	"prometheusPort":   func() int { return config.PrometheusPort },
+	"nodeExporterPort": func() int { return config.NodeExporterPort },
+}
+
 func (info *Info) String() string {
-	t := template.Must(template.New("info").Funcs(template.FuncMap{
+	t := template.Must(template.New("info").Funcs(metricsPorts).Funcs(template.FuncMap{
 		"replace": func(old, new, s string) string {
 			return strings.Replace(s, old, new, -1)
 		},
 		"blue": color.New(color.FgCyan, color.Bold).Sprint,
+		"join": strings.Join,
+		"fileNames": func(files []config.CustomFile) string {
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name)
+			}
+			return strings.Join(names, ", ")
+		},
 	}).Parse(infoTemplate))
 	var buf bytes.Buffer
 	err := t.Execute(&buf, info)
@@ -199,6 +296,17 @@ func (info *Info) String() string {
 	return buf.String()
 }
 
+// WorkerPoolRunning returns the number of running instances in the named worker pool
+func (info *Info) WorkerPoolRunning(pool string) int {
+	var running int
+	for _, instance := range info.Instances {
+		if strings.HasPrefix(instance.Name, "worker-"+pool+"/") && instance.State == "running" {
+			running++
+		}
+	}
+	return running
+}
+
 func writeTempFile(data string) (name string, err error) {
 	f, err := ioutil.TempFile("", "")
 	if err != nil {
@@ -226,15 +334,22 @@ export BOSH_CLIENT={{.Config.DirectorUsername}}
 export BOSH_CLIENT_SECRET={{.Config.DirectorPassword}}
 export BOSH_GW_USER={{.GatewayUser}}
 export BOSH_GW_PRIVATE_KEY={{.Config.PrivateKey | to_file}}
+{{- if eq .Config.CredentialManager "vault"}}
+export VAULT_ADDR={{.Config.VaultURL}}
+{{- if .Config.VaultCACert}}
+export VAULT_CACERT={{.Config.VaultCACert | to_file}}
+{{- end}}
+{{- else if not (or (eq .Config.CredentialManager "aws-secretsmanager") (eq .Config.CredentialManager "aws-ssm"))}}
 export CREDHUB_SERVER={{.Config.CredhubURL}}
 export CREDHUB_CA_CERT='{{.Config.CredhubCACert}}'
 export CREDHUB_CLIENT=credhub_admin
 export CREDHUB_SECRET={{.Config.CredhubAdminClientSecret}}
+{{- end}}
 export NAMESPACE={{.Config.Namespace}}
 `))
 
 // Env returns a string that is suitable for a shell to evaluate that sets environment
-// varibles which are used to log into bosh and credhub
+// varibles which are used to log into bosh and the credential manager
 func (info *Info) Env() (string, error) {
 	var buf bytes.Buffer
 	var i Info
@@ -14,6 +14,7 @@ func TestInfo_String(t *testing.T) {
 		Config      config.Config
 		Instances   []bosh.Instance
 		CertExpiry  string
+		TSAHostKey  string
 		GatewayUser string
 	}
 	defaultFields := fields{
@@ -47,7 +48,169 @@ func TestInfo_String(t *testing.T) {
 				f.Config.IAAS = "aCloudProvider"
 				return f
 			},
-			want: "IAAS:      aCloudProvider",
+			want: "IAAS:        aCloudProvider",
+		},
+		{
+			name:   "stemcell os templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.StemcellOS = "bionic"
+				return f
+			},
+			want: "Stemcell OS: bionic",
+		},
+		{
+			name:   "syslog templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.SyslogAddress = "logs.example.com:6514"
+				return f
+			},
+			want: "Stemcell OS: \n\tSyslog:      logs.example.com:6514\n",
+		},
+		{
+			name:   "load balanced web templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.ConcourseWebCount = 3
+				f.Config.ConcourseWebSize = "large"
+				return f
+			},
+			want: "Web:\n\tCount: 3\n\tSize:  large\n\nWorkers:",
+		},
+		{
+			name:   "autoscaling templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.AutoscaleMinWorkers = 1
+				f.Config.AutoscaleMaxWorkers = 5
+				f.Config.AutoscaleLowContainers = 30
+				f.Config.AutoscaleHighContainers = 100
+				f.Config.AutoscaleLowVolumes = 300
+				f.Config.AutoscaleHighVolumes = 1000
+				return f
+			},
+			want: "\tAutoscaling:        1-5 workers, 30-100 containers and 300-1000 volumes per worker\n",
+		},
+		{
+			name:   "schedule templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.ConcourseWorkerCount = 3
+				f.Config.ScheduleDown = "0 20 * * 1-5"
+				f.Config.ScheduleUp = "0 7 * * 1-5"
+				f.Config.ScheduleTimezone = "Europe/London"
+				f.Config.ScheduleWorkers = 1
+				f.Config.ScheduleState = config.ScheduleStateDown
+				return f
+			},
+			want: "\tCount:              3 (1 while down by schedule)\n",
+		},
+		{
+			name:   "schedule line templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.ScheduleDown = "0 20 * * 1-5"
+				f.Config.ScheduleUp = "0 7 * * 1-5"
+				f.Config.ScheduleTimezone = "UTC"
+				f.Config.ScheduleStop = true
+				return f
+			},
+			want: "\tSchedule:           down at \"0 20 * * 1-5\", up at \"0 7 * * 1-5\" (UTC), stopping the web node and database\n",
+		},
+		{
+			name:   "worker pools templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.WorkerPools = []config.WorkerPool{
+					{Name: "deploy", Count: 2, Size: "large", VMProvisioningType: config.SPOT, Tags: []string{"deploy", "production"}, Team: "ops"},
+				}
+				f.Instances = []bosh.Instance{
+					{Name: "worker-deploy/0", State: "running"},
+					{Name: "worker-deploy/1", State: "failing"},
+					{Name: "worker/0", State: "running"},
+				}
+				return f
+			},
+			want: "Worker pools:\n\tdeploy:\n\t\tRunning:      1/2\n\t\tSize:         large\n\t\tProvisioning: spot\n\t\tTags:         deploy, production\n\t\tTeam:         ops\n\nInstances:",
+		},
+		{
+			name:   "concourse version templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.StemcellOS = "bionic"
+				f.Config.ConcourseVersion = "7.4.0"
+				return f
+			},
+			want: "\tStemcell OS: bionic\n\tConcourse:   7.4.0 (pinned)\n",
+		},
+		{
+			name:   "maintenance window templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.MaintenanceWindow = config.MaintenanceWindow{
+					Days:     []string{"Saturday", "Sunday"},
+					Location: "Europe/London",
+					Start:    "1:00 AM",
+					Stop:     "4:00 AM",
+				}
+				return f
+			},
+			want: "\tMaintenance: 1:00 AM to 4:00 AM (Europe/London) on Saturday, Sunday\n",
+		},
+		{
+			name:   "custom files templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.DirectorOpsFiles = []config.CustomFile{{Name: "director.yml"}}
+				f.Config.ConcourseOpsFiles = []config.CustomFile{{Name: "web.yml"}, {Name: "worker.yml"}}
+				return f
+			},
+			want: "Custom files:\n\tDirector ops:     director.yml\n\tConcourse ops:    web.yml, worker.yml\n\nInstances:",
+		},
+		{
+			name:   "tsa templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.Domain = "ci.example.com"
+				f.Config.ExternalWorkers = []config.ExternalWorker{
+					{Name: "dc-worker", PublicKey: "ssh-rsa AAAA"},
+					{Name: "ops-worker", PublicKey: "ssh-rsa BBBB", Team: "ops"},
+				}
+				f.TSAHostKey = "ssh-rsa CCCC"
+				return f
+			},
+			want: "External worker registration (TSA):\n\tHost:     ci.example.com\n\tPort:     2222\n\tHost key: ssh-rsa CCCC\n\tAuthorised workers:\n\t\tdc-worker\n\t\tops-worker (team ops)\n\nCredhub credentials:",
+		},
+		{
+			name:   "vault templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.CredentialManager = config.CredentialManagerVault
+				f.Config.VaultURL = "https://vault.example.com:8200"
+				return f
+			},
+			want: "URL:          https://vault.example.com:8200",
+		},
+		{
+			name:   "aws credential manager templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.CredentialManager = config.CredentialManagerAWSSecretsManager
+				f.Config.AWSCredentialPathPrefix = "/concourse"
+				return f
+			},
+			want: "Type:        aws-secretsmanager\n\tPath prefix: /concourse",
+		},
+		{
+			name:   "prometheus templating",
+			fields: defaultFields,
+			init: func(f fields) fields {
+				f.Config.Metrics = config.MetricsPrometheus
+				f.Config.Domain = "ci.example.com"
+				return f
+			},
+			want: "concourse:     ci.example.com:9391\n\tnode_exporter: ci.example.com:9100",
 		},
 	}
 	for _, tt := range tests {
@@ -58,6 +221,7 @@ func TestInfo_String(t *testing.T) {
 				Config:      tt.fields.Config,
 				Instances:   tt.fields.Instances,
 				CertExpiry:  tt.fields.CertExpiry,
+				TSAHostKey:  tt.fields.TSAHostKey,
 				GatewayUser: tt.fields.GatewayUser,
 			}
 			if got := info.String(); !strings.Contains(got, tt.want) {
@@ -66,3 +230,46 @@ func TestInfo_String(t *testing.T) {
 		})
 	}
 }
+
+func TestInfo_Env(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.Config
+		want    string
+		notWant string
+	}{
+		{
+			name:    "credhub",
+			config:  config.Config{CredentialManager: config.CredentialManagerCredhub, CredhubURL: "https://credhub.example.com:8844/"},
+			want:    "export CREDHUB_SERVER=https://credhub.example.com:8844/",
+			notWant: "VAULT_ADDR",
+		},
+		{
+			name:    "vault",
+			config:  config.Config{CredentialManager: config.CredentialManagerVault, VaultURL: "https://vault.example.com:8200"},
+			want:    "export VAULT_ADDR=https://vault.example.com:8200\n",
+			notWant: "CREDHUB_",
+		},
+		{
+			name:    "aws-ssm",
+			config:  config.Config{CredentialManager: config.CredentialManagerAWSSSM},
+			want:    "export BOSH_DEPLOYMENT=concourse",
+			notWant: "CREDHUB_",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Config: tt.config}
+			got, err := info.Env()
+			if err != nil {
+				t.Fatalf("Info.Env() error = %v", err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("Info.Env() = %v, want %v", got, tt.want)
+			}
+			if strings.Contains(got, tt.notWant) {
+				t.Errorf("Info.Env() = %v, should not contain %v", got, tt.notWant)
+			}
+		})
+	}
+}
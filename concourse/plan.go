@@ -0,0 +1,41 @@
+package concourse
+
+import (
+	"errors"
+	"fmt"
+)
+
+// planDeploy shows the changes a deploy would make to the Concourse deployment, without making any. The
+// infrastructure, director and cloud config are left as they are, so changes to them are not shown
+func (client *Client) planDeploy() error {
+	exists, err := client.configClient.ConfigExists()
+	if err != nil {
+		return fmt.Errorf("error determining if config already exists [%v]", err)
+	}
+	if !exists {
+		return errors.New("--dry-run can only show changes to an existing deployment")
+	}
+
+	conf, _, err := client.getInitialConfig()
+	if err != nil {
+		return fmt.Errorf("error getting initial config before planning deploy: [%v]", err)
+	}
+	conf.Tags = append([]string{fmt.Sprintf("control-tower-version=%s", client.version)}, stripVersion(conf.Tags)...)
+	conf.Version = client.version
+
+	tfOutputs, err := client.tfCLI.BuildOutput(client.tfInputVarsFactory.NewInputVars(conf))
+	if err != nil {
+		return err
+	}
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	creds, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+	return boshClient.PlanConcourse(creds)
+}
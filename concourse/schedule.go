@@ -0,0 +1,187 @@
+package concourse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/util/cron"
+)
+
+// ScheduleUp and ScheduleDown force Schedule to bring the deployment up or down regardless of its schedule
+const (
+	ScheduleUp   = "up"
+	ScheduleDown = "down"
+)
+
+// scheduleStartAttempts and scheduleRetryInterval allow the director time to reconnect to its database
+// before `bosh start` succeeds
+const (
+	scheduleStartAttempts = 10
+	scheduleRetryInterval = 30 * time.Second
+)
+
+// Schedule brings the deployment up or down as its schedule says it should be now. The deployment is
+// only changed when a scheduled time has passed since it was last changed, so that a deployment
+// brought up or down by hand stays that way until the next scheduled time
+func (client *Client) Schedule(force string) error {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+	if !conf.IsScheduled() && force == "" {
+		return errors.New("deployment has no schedule, deploy with --schedule-down and --schedule-up to add one")
+	}
+
+	now := time.Now().UTC()
+	down, changedAt := force == ScheduleDown, now
+	if force == "" {
+		down, changedAt, err = scheduledState(conf, now)
+		if err != nil {
+			return err
+		}
+		appliedAt, _ := time.Parse(time.RFC3339, conf.ScheduleAppliedAt)
+		if !changedAt.After(appliedAt) {
+			_, err = fmt.Fprintf(client.stdout, "Nothing to do, the schedule has not changed since %s\n", conf.ScheduleAppliedAt)
+			return err
+		}
+	}
+
+	target := ""
+	if down {
+		target = config.ScheduleStateDown
+		if conf.ScheduleStop {
+			target = config.ScheduleStateStopped
+		}
+	}
+	conf.ScheduleAppliedAt = changedAt.Format(time.RFC3339)
+	if target == conf.ScheduleState {
+		if _, err = fmt.Fprintf(client.stdout, "Deployment is already %s\n", scheduleStateDescription(target)); err != nil {
+			return err
+		}
+		return client.configClient.Update(conf)
+	}
+
+	return client.applyScheduleState(conf, target)
+}
+
+func (client *Client) applyScheduleState(conf config.Config, target string) error {
+	tfOutputs, err := client.tfCLI.BuildOutput(client.tfInputVarsFactory.NewInputVars(conf))
+	if err != nil {
+		return err
+	}
+	dbOutput, _ := client.provider.Choose(iaas.Choice{AWS: "DBIdentifier", GCP: "DBName"}).(string)
+	dbName, err := tfOutputs.Get(dbOutput)
+	if err != nil {
+		return err
+	}
+	if dbName == "" && (target == config.ScheduleStateStopped || conf.ScheduleState == config.ScheduleStateStopped) {
+		return errors.New("the deployment's database is not known, run `control-tower deploy` to update the deployment first")
+	}
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	if conf.ScheduleState == config.ScheduleStateStopped {
+		fmt.Fprintf(client.stdout, "Starting database %s\n", dbName)
+		if err = client.provider.StartDatabase(dbName); err != nil {
+			return err
+		}
+		fmt.Fprintln(client.stdout, "Starting Concourse VMs")
+		if err = startConcourse(boshClient, client.stdout); err != nil {
+			return err
+		}
+		conf.ScheduleState = ""
+		if err = client.configClient.Update(conf); err != nil {
+			return err
+		}
+	}
+
+	if target == config.ScheduleStateStopped {
+		fmt.Fprintln(client.stdout, "Stopping Concourse VMs")
+		if err = boshClient.Stop(); err != nil {
+			return err
+		}
+		conf.ScheduleState = target
+		if err = client.configClient.Update(conf); err != nil {
+			return err
+		}
+		fmt.Fprintf(client.stdout, "Stopping database %s\n", dbName)
+		return client.provider.StopDatabase(dbName)
+	}
+
+	conf.ScheduleState = target
+	if err = client.configClient.Update(conf); err != nil {
+		return err
+	}
+	fmt.Fprintf(client.stdout, "Deploying with %d workers\n", conf.GetDeployedWorkerCount())
+
+	creds, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+	creds, err1 := boshClient.DeployConcourse(creds, true)
+	if len(creds) > 0 {
+		if err = client.configClient.StoreAsset(bosh.CredsFilename, creds); err != nil {
+			return err
+		}
+	}
+	return err1
+}
+
+// startConcourse runs `bosh start`, retrying while the director recovers from its database being stopped
+func startConcourse(boshClient bosh.IClient, stdout io.Writer) error {
+	var err error
+	for attempt := 1; attempt <= scheduleStartAttempts; attempt++ {
+		if err = boshClient.Start(); err == nil {
+			return nil
+		}
+		fmt.Fprintf(stdout, "Failed to start Concourse VMs, retrying in %s: [%v]\n", scheduleRetryInterval, err)
+		time.Sleep(scheduleRetryInterval)
+	}
+	return err
+}
+
+// scheduledState returns whether the schedule has the deployment down at now, and the time the
+// schedule last brought it up or down
+func scheduledState(c config.ConfigView, now time.Time) (bool, time.Time, error) {
+	location, err := time.LoadLocation(c.GetScheduleTimezone())
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	down, err := cron.Parse(c.GetScheduleDown())
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	up, err := cron.Parse(c.GetScheduleUp())
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	lastDown, downOK := down.Previous(now.In(location))
+	lastUp, upOK := up.Previous(now.In(location))
+	switch {
+	case !downOK && !upOK:
+		return false, time.Time{}, nil
+	case !upOK || (downOK && lastDown.After(lastUp)):
+		return true, lastDown, nil
+	}
+	return false, lastUp, nil
+}
+
+func scheduleStateDescription(state string) string {
+	switch state {
+	case config.ScheduleStateDown:
+		return "scaled down"
+	case config.ScheduleStateStopped:
+		return "stopped"
+	}
+	return "up"
+}
@@ -0,0 +1,66 @@
+package concourse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+func TestScheduledState(t *testing.T) {
+	conf := config.Config{
+		ScheduleDown:     "0 20 * * 1-5",
+		ScheduleUp:       "0 7 * * 1-5",
+		ScheduleTimezone: "Europe/London",
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantDown      bool
+		wantChangedAt time.Time
+	}{
+		{
+			name:          "working hours",
+			now:           time.Date(2020, 7, 1, 12, 0, 0, 0, london),
+			wantDown:      false,
+			wantChangedAt: time.Date(2020, 7, 1, 7, 0, 0, 0, london),
+		},
+		{
+			name:          "weeknight in the schedule's timezone",
+			now:           time.Date(2020, 7, 1, 19, 30, 0, 0, time.UTC),
+			wantDown:      true,
+			wantChangedAt: time.Date(2020, 7, 1, 20, 0, 0, 0, london),
+		},
+		{
+			name:          "weekend",
+			now:           time.Date(2020, 7, 5, 12, 0, 0, 0, london),
+			wantDown:      true,
+			wantChangedAt: time.Date(2020, 7, 3, 20, 0, 0, 0, london),
+		},
+		{
+			name:          "monday morning",
+			now:           time.Date(2020, 7, 6, 7, 5, 0, 0, london),
+			wantDown:      false,
+			wantChangedAt: time.Date(2020, 7, 6, 7, 0, 0, 0, london),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			down, changedAt, err := scheduledState(conf, tt.now)
+			if err != nil {
+				t.Fatalf("scheduledState() unexpected error = %v", err)
+			}
+			if down != tt.wantDown {
+				t.Errorf("scheduledState() down = %v, want %v", down, tt.wantDown)
+			}
+			if !changedAt.Equal(tt.wantChangedAt) {
+				t.Errorf("scheduledState() changedAt = %v, want %v", changedAt, tt.wantChangedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package concourse
+
+import (
+	"github.com/EngineerBetter/control-tower/credhub"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+// setCredhubSecrets stores secrets in the deployment's CredHub, and is replaced in tests
+var setCredhubSecrets = func(bp BoshParams, secrets []credhub.Secret) error {
+	client, err := credhub.New(bp.CredhubURL, bp.CredhubCACert, credhub.AdminClientID, bp.CredhubAdminClientSecret)
+	if err != nil {
+		return err
+	}
+	return client.Import(secrets)
+}
+
+// storeSelfUpdateToken puts the GitHub access token for the self-update pipeline in CredHub, so
+// that the pipeline refers to it rather than holding it
+func storeSelfUpdateToken(token string, bp BoshParams) error {
+	if token == "" {
+		return nil
+	}
+	return setCredhubSecrets(bp, []credhub.Secret{{
+		Name:  fly.GitHubAccessTokenCredential,
+		Type:  "password",
+		Value: token,
+	}})
+}
@@ -0,0 +1,33 @@
+package concourse
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/credhub"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+func TestStoreSelfUpdateToken(t *testing.T) {
+	defer func(original func(BoshParams, []credhub.Secret) error) { setCredhubSecrets = original }(setCredhubSecrets)
+
+	var stored []credhub.Secret
+	setCredhubSecrets = func(bp BoshParams, secrets []credhub.Secret) error {
+		stored = append(stored, secrets...)
+		return nil
+	}
+
+	if err := storeSelfUpdateToken("", BoshParams{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 0 {
+		t.Fatalf("storeSelfUpdateToken() stored %+v without a token", stored)
+	}
+
+	if err := storeSelfUpdateToken("token", BoshParams{}); err != nil {
+		t.Fatal(err)
+	}
+	want := credhub.Secret{Name: fly.GitHubAccessTokenCredential, Type: "password", Value: "token"}
+	if len(stored) != 1 || stored[0] != want {
+		t.Errorf("storeSelfUpdateToken() stored %+v, want %+v", stored, want)
+	}
+}
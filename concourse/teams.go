@@ -0,0 +1,61 @@
+package concourse
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+const teamsFilename = "teams.yml"
+
+// storeTeamsFile validates the teams file passed to deploy and keeps it in the
+// config bucket, so that it is reapplied by later deploys
+func (client *Client) storeTeamsFile() error {
+	if !client.deployArgs.TeamsFileIsSet {
+		return nil
+	}
+
+	contents, err := ioutil.ReadFile(client.deployArgs.TeamsFile)
+	if err != nil {
+		return fmt.Errorf("error reading teams file: [%v]", err)
+	}
+
+	teams, err := fly.ParseTeamsConfig(contents)
+	if err != nil {
+		return err
+	}
+	if len(teams.Teams) == 0 && !client.deployArgs.PruneAllTeams {
+		return fmt.Errorf("the teams file has no teams, so every team other than main would be destroyed. Pass --prune-all-teams to confirm")
+	}
+
+	return client.configClient.StoreAsset(teamsFilename, contents)
+}
+
+// setTeams applies the stored teams file, unless teams are not managed by this deployment
+func (client *Client) setTeams(flyClient fly.IClient, c config.ConfigView) error {
+	if !c.GetManageTeams() {
+		return nil
+	}
+
+	hasTeams, err := client.configClient.HasAsset(teamsFilename)
+	if err != nil {
+		return err
+	}
+	if !hasTeams {
+		return nil
+	}
+
+	contents, err := client.configClient.LoadAsset(teamsFilename)
+	if err != nil {
+		return err
+	}
+
+	teams, err := fly.ParseTeamsConfig(contents)
+	if err != nil {
+		return err
+	}
+
+	return flyClient.SetTeams(teams)
+}
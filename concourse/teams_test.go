@@ -0,0 +1,160 @@
+package concourse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/config/configfakes"
+	"github.com/EngineerBetter/control-tower/fly/flyfakes"
+)
+
+const testTeamsFile = `
+teams:
+- name: platform
+  roles:
+  - name: owner
+    github:
+      teams: ["EngineerBetter:platform"]
+`
+
+func writeTeamsFile(t *testing.T, dir, contents string) string {
+	path := filepath.Join(dir, "teams.yml")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestClient_storeTeamsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "teams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name        string
+		contents    string
+		isSet       bool
+		prune       bool
+		wantStored  bool
+		expectedErr string
+	}{
+		{
+			name:  "Nothing is stored without a teams file",
+			isSet: false,
+		},
+		{
+			name:       "A teams file is stored",
+			contents:   testTeamsFile,
+			isSet:      true,
+			wantStored: true,
+		},
+		{
+			name:        "A teams file without teams is refused",
+			contents:    "teams: []",
+			isSet:       true,
+			expectedErr: "the teams file has no teams, so every team other than main would be destroyed. Pass --prune-all-teams to confirm",
+		},
+		{
+			name:       "A teams file without teams is stored when confirmed",
+			contents:   "teams: []",
+			isSet:      true,
+			prune:      true,
+			wantStored: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configClient := &configfakes.FakeIClient{}
+			client := &Client{
+				configClient: configClient,
+				deployArgs: &deploy.Args{
+					TeamsFile:      writeTeamsFile(t, dir, tt.contents),
+					TeamsFileIsSet: tt.isSet,
+					PruneAllTeams:  tt.prune,
+				},
+			}
+
+			err := client.storeTeamsFile()
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("storeTeamsFile() error = %v, want %s", err, tt.expectedErr)
+				}
+			} else if err != nil {
+				t.Errorf("storeTeamsFile() error = %v", err)
+			}
+
+			if stored := configClient.StoreAssetCallCount() == 1; stored != tt.wantStored {
+				t.Fatalf("storeTeamsFile() stored the file: %v, want %v", stored, tt.wantStored)
+			}
+			if tt.wantStored {
+				name, contents := configClient.StoreAssetArgsForCall(0)
+				if name != teamsFilename || string(contents) != tt.contents {
+					t.Errorf("storeTeamsFile() stored %s as %s", contents, name)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_setTeams(t *testing.T) {
+	tests := []struct {
+		name        string
+		manageTeams bool
+		hasAsset    bool
+		wantTeams   []string
+	}{
+		{
+			name:        "Stored teams are applied",
+			manageTeams: true,
+			hasAsset:    true,
+			wantTeams:   []string{"platform"},
+		},
+		{
+			name:        "Teams are left alone when they are not managed",
+			manageTeams: false,
+			hasAsset:    true,
+		},
+		{
+			name:        "Teams are left alone without a stored teams file",
+			manageTeams: true,
+			hasAsset:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configClient := &configfakes.FakeIClient{}
+			configClient.HasAssetReturns(tt.hasAsset, nil)
+			configClient.LoadAssetReturns([]byte(testTeamsFile), nil)
+			flyClient := &flyfakes.FakeIClient{}
+			client := &Client{configClient: configClient}
+
+			if err := client.setTeams(flyClient, config.Config{ManageTeams: tt.manageTeams}); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantTeams == nil {
+				if flyClient.SetTeamsCallCount() != 0 {
+					t.Errorf("setTeams() set teams %+v, want none", flyClient.SetTeamsArgsForCall(0))
+				}
+				return
+			}
+			if flyClient.SetTeamsCallCount() != 1 {
+				t.Fatalf("setTeams() set teams %d times, want once", flyClient.SetTeamsCallCount())
+			}
+			var names []string
+			for _, team := range flyClient.SetTeamsArgsForCall(0).Teams {
+				names = append(names, team.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantTeams, ",") {
+				t.Errorf("setTeams() set teams %v, want %v", names, tt.wantTeams)
+			}
+		})
+	}
+}
@@ -48,11 +48,18 @@ func (f *AWSInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputV
 		AllowIPs:               c.GetAllowIPs(),
 		AvailabilityZone:       c.GetAvailabilityZone(),
 		ConfigBucket:           c.GetConfigBucket(),
+		CredentialManager:      c.GetCredentialManager(),
+		CredentialPathPrefix:   c.GetAWSCredentialPathPrefix(),
 		Deployment:             c.GetDeployment(),
+		DisableGrafana:         c.GetDisableGrafana(),
 		HostedZoneID:           c.GetHostedZoneID(),
 		HostedZoneRecordPrefix: c.GetHostedZoneRecordPrefix(),
+		Metrics:                c.GetMetrics(),
+		MetricsAllowIPs:        c.GetMetricsAllowIPs(),
 		Namespace:              c.GetNamespace(),
+		NodeExporterPort:       config.NodeExporterPort,
 		Project:                c.GetProject(),
+		PrometheusPort:         config.PrometheusPort,
 		PublicKey:              c.GetPublicKey(),
 		RDSDefaultDatabaseName: c.GetRDSDefaultDatabaseName(),
 		RDSInstanceClass:       c.GetRDSInstanceClass(),
@@ -63,6 +70,8 @@ func (f *AWSInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputV
 		Region:                 c.GetRegion(),
 		SourceAccessIP:         c.GetSourceAccessIP(),
 		TFStatePath:            c.GetTFStatePath(),
+		TSAAllowIPs:            c.GetTSAAllowIPs(),
+		WebCount:               c.GetConcourseWebCount(),
 	}
 }
 
@@ -82,14 +91,21 @@ func (f *GCPInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputV
 		DBTier:             c.GetRDSInstanceClass(),
 		DBUsername:         c.GetRDSUsername(),
 		Deployment:         c.GetDeployment(),
+		DisableGrafana:     c.GetDisableGrafana(),
 		DNSManagedZoneName: c.GetHostedZoneID(),
 		DNSRecordSetPrefix: c.GetHostedZoneRecordPrefix(),
 		ExternalIP:         c.GetSourceAccessIP(),
 		GCPCredentialsJSON: f.credentialsPath,
+		Metrics:            c.GetMetrics(),
+		MetricsAllowIPs:    c.GetMetricsAllowIPs(),
 		Namespace:          c.GetNamespace(),
+		NodeExporterPort:   config.NodeExporterPort,
 		Project:            f.project,
+		PrometheusPort:     config.PrometheusPort,
 		Region:             f.region,
 		Tags:               "",
+		TSAAllowIPs:        c.GetTSAAllowIPs(),
+		WebCount:           c.GetConcourseWebCount(),
 		Zone:               f.zone,
 		PublicCIDR:         c.GetPublicCIDR(),
 		PrivateCIDR:        c.GetPrivateCIDR(),
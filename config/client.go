@@ -79,6 +79,11 @@ func (client *Client) ConfigExists() (bool, error) {
 
 // Update stores the control-tower config file to S3
 func (client *Client) Update(config Config) error {
+	config, err := client.storeCustomFiles(config)
+	if err != nil {
+		return err
+	}
+
 	bytes, err := json.Marshal(config)
 	if err != nil {
 		return err
@@ -113,9 +118,53 @@ func (client *Client) Load() (Config, error) {
 
 	conf = populateMandatoryFieldsAddedSinceLastSave(conf)
 
+	if err := client.loadCustomFiles(&conf); err != nil {
+		return Config{}, err
+	}
+
 	return conf, nil
 }
 
+// storeCustomFiles stores the contents of each custom file as an asset, and returns config with only their keys.
+// Files are stored under a key that changes with their contents, so unchanged ones are not written again
+func (client *Client) storeCustomFiles(config Config) (Config, error) {
+	for _, files := range config.customFiles() {
+		if *files == nil {
+			continue
+		}
+		stored := make([]CustomFile, len(*files))
+		for i, file := range *files {
+			if key := file.assetKey(); file.Key != key {
+				if err := client.StoreAsset(key, []byte(file.Contents)); err != nil {
+					return Config{}, fmt.Errorf("error storing %s: [%v]", file.Name, err)
+				}
+				file.Key = key
+			}
+			file.Contents = ""
+			stored[i] = file
+		}
+		*files = stored
+	}
+	return config, nil
+}
+
+// loadCustomFiles loads the contents of each custom file in conf from its asset
+func (client *Client) loadCustomFiles(conf *Config) error {
+	for _, files := range conf.customFiles() {
+		for i, file := range *files {
+			if file.Key == "" {
+				continue
+			}
+			contents, err := client.LoadAsset(file.Key)
+			if err != nil {
+				return fmt.Errorf("error loading %s: [%v]", file.Name, err)
+			}
+			(*files)[i].Contents = string(contents)
+		}
+	}
+	return nil
+}
+
 func (client *Client) NewConfig() Config {
 	return Config{
 		ConfigBucket: client.configBucket(),
@@ -195,5 +244,21 @@ func populateMandatoryFieldsAddedSinceLastSave(oldConf Config) Config {
 		oldConf.VMProvisioningType = ConvertSpotBoolToVMProvisioningType(oldConf.Spot)
 	}
 
+	if oldConf.StemcellOS == "" {
+		oldConf.StemcellOS = DefaultStemcellOS
+	}
+
+	if oldConf.CredentialManager == "" {
+		oldConf.CredentialManager = CredentialManagerCredhub
+	}
+
+	if oldConf.Metrics == "" {
+		oldConf.Metrics = MetricsInfluxDB
+	}
+
+	if oldConf.ConcourseWebCount == 0 {
+		oldConf.ConcourseWebCount = 1
+	}
+
 	return oldConf
 }
@@ -267,7 +267,11 @@ func TestClient_Load(t *testing.T) {
 				}
 			},
 			want: Config{
+				ConcourseWebCount:  1,
+				CredentialManager:  CredentialManagerCredhub,
+				Metrics:            MetricsInfluxDB,
 				Spot:               true,
+				StemcellOS:         DefaultStemcellOS,
 				VMProvisioningType: SPOT,
 			},
 			wantErr: false,
@@ -335,6 +339,10 @@ func TestClient_HasConfig(t *testing.T) {
 				}
 			},
 			want: Config{
+				ConcourseWebCount:  1,
+				CredentialManager:  CredentialManagerCredhub,
+				Metrics:            MetricsInfluxDB,
+				StemcellOS:         DefaultStemcellOS,
 				VMProvisioningType: ON_DEMAND,
 			},
 			wantErr: false,
@@ -354,3 +362,73 @@ func TestClient_HasConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CustomFilesAreAssets(t *testing.T) {
+	bucket := map[string][]byte{}
+	provider := &iaasfakes.FakeProvider{}
+	provider.WriteFileStub = func(bucketName, path string, contents []byte) error {
+		bucket[path] = contents
+		return nil
+	}
+	provider.LoadFileStub = func(bucketName, path string) ([]byte, error) {
+		contents, ok := bucket[path]
+		if !ok {
+			return nil, fmt.Errorf("no such file %s", path)
+		}
+		return contents, nil
+	}
+	client := &Client{Iaas: provider, BucketName: "config-bucket", BucketExists: true}
+
+	opsFile := CustomFile{Name: "ops.yml", Contents: "- type: remove\n  path: /instance_groups/name=web\n"}
+	varsFile := CustomFile{Name: "vars.yml", Contents: "disk_size: 100\n"}
+	dashboard := CustomFile{Name: "builds.json", Contents: `{"title": "Builds"}`}
+	conf := Config{ConcourseOpsFiles: []CustomFile{opsFile}, VarsFiles: []CustomFile{varsFile}, GrafanaDashboards: []CustomFile{dashboard}}
+	if err := client.Update(conf); err != nil {
+		t.Fatal(err)
+	}
+	if conf.ConcourseOpsFiles[0].Contents != opsFile.Contents {
+		t.Errorf("Client.Update() changed the caller's config")
+	}
+
+	var saved Config
+	if err := json.Unmarshal(bucket["config.json"], &saved); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range append(append(saved.ConcourseOpsFiles, saved.VarsFiles...), saved.GrafanaDashboards...) {
+		if file.Contents != "" || file.Key == "" {
+			t.Errorf("Client.Update() saved %+v in config.json, want only its name and key", file)
+		}
+	}
+
+	loaded, err := client.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ConcourseOpsFiles[0].Contents != opsFile.Contents || loaded.VarsFiles[0].Contents != varsFile.Contents || loaded.GrafanaDashboards[0].Contents != dashboard.Contents {
+		t.Errorf("Client.Load() = %+v, %+v and %+v, want the contents of the stored files", loaded.ConcourseOpsFiles, loaded.VarsFiles, loaded.GrafanaDashboards)
+	}
+
+	writes := provider.WriteFileCallCount()
+	if err := client.Update(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if provider.WriteFileCallCount() != writes+1 {
+		t.Errorf("Client.Update() wrote %d files for an unchanged config, want only config.json", provider.WriteFileCallCount()-writes)
+	}
+}
+
+func TestClient_LoadInlineCustomFiles(t *testing.T) {
+	provider := &iaasfakes.FakeProvider{}
+	provider.LoadFileStub = func(bucket, path string) ([]byte, error) {
+		return []byte(`{"vars_files": [{"name": "vars.yml", "contents": "disk_size: 100\n"}]}`), nil
+	}
+	client := &Client{Iaas: provider, BucketName: "config-bucket", BucketExists: true}
+
+	conf, err := client.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.VarsFiles) != 1 || conf.VarsFiles[0].Contents != "disk_size: 100\n" || provider.LoadFileCallCount() != 1 {
+		t.Errorf("Client.Load() = %+v, want the contents saved in config.json by earlier versions", conf.VarsFiles)
+	}
+}
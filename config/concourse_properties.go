@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ConcourseProperties are extra web and worker job properties. Zero values leave Concourse's own defaults in place
+type ConcourseProperties struct {
+	BuildLogsToRetain               int      `json:"build_logs_to_retain"`
+	ContainerPlacementStrategy      string   `json:"container_placement_strategy"`
+	DefaultTaskCPULimit             int      `json:"default_task_cpu_limit"`
+	DefaultTaskMemoryLimit          string   `json:"default_task_memory_limit"`
+	FeatureFlags                    []string `json:"feature_flags"`
+	GCInterval                      string   `json:"gc_interval"`
+	MaxContainersPerWorker          int      `json:"max_containers_per_worker"`
+	ResourceCheckingInterval        string   `json:"resource_checking_interval"`
+	ResourceWebhookCheckingInterval string   `json:"resource_webhook_checking_interval"`
+}
+
+// ContainerPlacementStrategies are the strategies the web node can use to choose a worker for a container
+var ContainerPlacementStrategies = []string{"volume-locality", "random", "fewest-build-containers"}
+
+// FeatureFlags are the optional Concourse features that can be enabled on the web node
+var FeatureFlags = []string{"across-step", "build-rerunning", "cache-streamed-volumes", "pipeline-instances", "redact-secrets", "resource-causality"}
+
+var memoryLimitRegexp = regexp.MustCompile(`^[0-9]+(KB|MB|GB|TB)?$`)
+
+// Validate returns an error naming the first property that Concourse would not accept
+func (p ConcourseProperties) Validate() error {
+	if p.BuildLogsToRetain < 0 {
+		return errors.New("build logs to retain cannot be negative")
+	}
+	if p.ContainerPlacementStrategy != "" && !isOneOf(p.ContainerPlacementStrategy, ContainerPlacementStrategies) {
+		return fmt.Errorf("unknown container placement strategy `%s`. Valid strategies are: %v", p.ContainerPlacementStrategy, ContainerPlacementStrategies)
+	}
+	if p.DefaultTaskCPULimit < 0 {
+		return errors.New("default task CPU limit cannot be negative")
+	}
+	if p.DefaultTaskMemoryLimit != "" && !memoryLimitRegexp.MatchString(p.DefaultTaskMemoryLimit) {
+		return fmt.Errorf("default task memory limit `%s` must be a whole number of bytes, optionally followed by KB, MB, GB or TB", p.DefaultTaskMemoryLimit)
+	}
+	for _, flag := range p.FeatureFlags {
+		if !isOneOf(flag, FeatureFlags) {
+			return fmt.Errorf("unknown feature flag `%s`. Valid feature flags are: %v", flag, FeatureFlags)
+		}
+	}
+	if p.MaxContainersPerWorker < 0 {
+		return errors.New("max containers per worker cannot be negative")
+	}
+
+	intervals := []struct {
+		name, value string
+	}{
+		{"GC interval", p.GCInterval},
+		{"resource checking interval", p.ResourceCheckingInterval},
+		{"resource webhook checking interval", p.ResourceWebhookCheckingInterval},
+	}
+	for _, interval := range intervals {
+		if interval.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(interval.value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s `%s` must be a positive duration such as 30s or 1m", interval.name, interval.value)
+		}
+	}
+
+	return nil
+}
+
+func isOneOf(value string, permitted []string) bool {
+	for _, p := range permitted {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package config_test
+
+import (
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+func TestConcourseProperties_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties ConcourseProperties
+		wantErr    string
+	}{
+		{
+			name:       "no properties",
+			properties: ConcourseProperties{},
+		},
+		{
+			name: "all properties",
+			properties: ConcourseProperties{
+				BuildLogsToRetain:               50,
+				ContainerPlacementStrategy:      "fewest-build-containers",
+				DefaultTaskCPULimit:             512,
+				DefaultTaskMemoryLimit:          "4GB",
+				FeatureFlags:                    []string{"across-step", "redact-secrets"},
+				GCInterval:                      "30s",
+				MaxContainersPerWorker:          150,
+				ResourceCheckingInterval:        "2m",
+				ResourceWebhookCheckingInterval: "1h",
+			},
+		},
+		{
+			name:       "unknown container placement strategy",
+			properties: ConcourseProperties{ContainerPlacementStrategy: "least-loaded"},
+			wantErr:    "unknown container placement strategy `least-loaded`. Valid strategies are: [volume-locality random fewest-build-containers]",
+		},
+		{
+			name:       "invalid memory limit",
+			properties: ConcourseProperties{DefaultTaskMemoryLimit: "4 gigs"},
+			wantErr:    "default task memory limit `4 gigs` must be a whole number of bytes, optionally followed by KB, MB, GB or TB",
+		},
+		{
+			name:       "unknown feature flag",
+			properties: ConcourseProperties{FeatureFlags: []string{"global-resources"}},
+			wantErr:    "unknown feature flag `global-resources`. Valid feature flags are: [across-step build-rerunning cache-streamed-volumes pipeline-instances redact-secrets resource-causality]",
+		},
+		{
+			name:       "negative max containers",
+			properties: ConcourseProperties{MaxContainersPerWorker: -1},
+			wantErr:    "max containers per worker cannot be negative",
+		},
+		{
+			name:       "invalid interval",
+			properties: ConcourseProperties{ResourceCheckingInterval: "5"},
+			wantErr:    "resource checking interval `5` must be a positive duration such as 30s or 1m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.properties.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,40 @@ package config
 const SPOT = "spot"
 const ON_DEMAND = "on-demand"
 
+// DefaultStemcellOS is the stemcell line used by deployments that predate the stemcell_os setting
+const DefaultStemcellOS = "xenial"
+
+// CredentialManagerCredhub, CredentialManagerVault, CredentialManagerAWSSecretsManager and
+// CredentialManagerAWSSSM are the supported Concourse credential managers
+const (
+	CredentialManagerCredhub           = "credhub"
+	CredentialManagerVault             = "vault"
+	CredentialManagerAWSSecretsManager = "aws-secretsmanager"
+	CredentialManagerAWSSSM            = "aws-ssm"
+)
+
+// MetricsInfluxDB and MetricsPrometheus are the supported metrics backends
+const (
+	MetricsInfluxDB   = "influxdb"
+	MetricsPrometheus = "prometheus"
+)
+
+// PrometheusPort and NodeExporterPort are where the web node serves metrics when using Prometheus
+const (
+	PrometheusPort   = 9391
+	NodeExporterPort = 9100
+)
+
+// ScheduleStateDown and ScheduleStateStopped are the states a schedule leaves a deployment in between
+// its down and up times. Down deployments have their workers scaled down, stopped ones have no VMs running
+const (
+	ScheduleStateDown    = "down"
+	ScheduleStateStopped = "stopped"
+)
+
+// DefaultAWSCredentialPathPrefix is the path under which the AWS credential managers look up credentials
+const DefaultAWSCredentialPathPrefix = "/concourse"
+
 func ConvertSpotBoolToVMProvisioningType(spot bool) string {
 	if spot {
 		return SPOT
@@ -13,83 +47,191 @@ func ConvertSpotBoolToVMProvisioningType(spot bool) string {
 
 // Config represents a control-tower configuration file
 type Config struct {
-	AllowIPs                 string `json:"allow_ips"`
-	AvailabilityZone         string `json:"availability_zone"`
-	ConcourseCACert          string `json:"concourse_ca_cert"`
-	ConcourseCert            string `json:"concourse_cert"`
-	ConcourseKey             string `json:"concourse_key"`
-	ConcoursePassword        string `json:"concourse_password"`
-	ConcourseUsername        string `json:"concourse_username"`
-	ConcourseWebSize         string `json:"concourse_web_size"`
-	ConcourseWorkerCount     int    `json:"concourse_worker_count"`
-	ConcourseWorkerSize      string `json:"concourse_worker_size"`
-	ConfigBucket             string `json:"config_bucket"`
-	CredhubAdminClientSecret string `json:"credhub_admin_client_secret"`
-	CredhubCACert            string `json:"credhub_ca_cert"`
-	CredhubPassword          string `json:"credhub_password"`
-	CredhubURL               string `json:"credhub_url"`
-	CredhubUsername          string `json:"credhub_username"`
-	Deployment               string `json:"deployment"`
-	DirectorCACert           string `json:"director_ca_cert"`
-	DirectorCert             string `json:"director_cert"`
-	DirectorHMUserPassword   string `json:"director_hm_user_password"`
-	DirectorKey              string `json:"director_key"`
-	DirectorMbusPassword     string `json:"director_mbus_password"`
-	DirectorNATSPassword     string `json:"director_nats_password"`
-	DirectorPassword         string `json:"director_password"`
-	DirectorPublicIP         string `json:"director_public_ip"`
-	DirectorRegistryPassword string `json:"director_registry_password"`
-	DirectorUsername         string `json:"director_username"`
-	Domain                   string `json:"domain"`
-	EnableGlobalResources    bool   `json:"enable_global_resources"`
-	EncryptionKey            string `json:"encryption_key"`
-	GithubClientID           string `json:"github_client_id"`
-	GithubClientSecret       string `json:"github_client_secret"`
-	GrafanaPassword          string `json:"grafana_password"`
-	HostedZoneID             string `json:"hosted_zone_id"`
-	HostedZoneRecordPrefix   string `json:"hosted_zone_record_prefix"`
-	IAAS                     string `json:"iaas"`
-	Namespace                string `json:"namespace"`
-	NetworkCIDR              string `json:"network_cidr"`
-	PrivateCIDR              string `json:"private_cidr"`
-	PrivateKey               string `json:"private_key"`
-	Project                  string `json:"project"`
-	PublicCIDR               string `json:"public_cidr"`
-	PublicKey                string `json:"public_key"`
-	RDS1CIDR                 string `json:"rds1_cidr"`
-	RDS2CIDR                 string `json:"rds2_cidr"`
-	RDSDefaultDatabaseName   string `json:"rds_default_database_name"`
-	RDSInstanceClass         string `json:"rds_instance_class"`
-	RDSPassword              string `json:"rds_password"`
-	RDSUsername              string `json:"rds_username"`
-	Region                   string `json:"region"`
-	SourceAccessIP           string `json:"source_access_ip"`
+	AllowIPs                    string       `json:"allow_ips"`
+	ArtefactMirror              string       `json:"artefact_mirror"`
+	AutoscaleCooldown           string       `json:"autoscale_cooldown"`
+	AutoscaleHighContainers     int          `json:"autoscale_high_containers"`
+	AutoscaleHighVolumes        int          `json:"autoscale_high_volumes"`
+	AutoscaleLastScaledAt       string       `json:"autoscale_last_scaled_at"`
+	AutoscaleLowContainers      int          `json:"autoscale_low_containers"`
+	AutoscaleLowVolumes         int          `json:"autoscale_low_volumes"`
+	AutoscaleMaxWorkers         int          `json:"autoscale_max_workers"`
+	AutoscaleMinWorkers         int          `json:"autoscale_min_workers"`
+	AvailabilityZone            string       `json:"availability_zone"`
+	AWSCredentialPathPrefix     string       `json:"aws_credential_path_prefix"`
+	BitbucketCloudClientID      string       `json:"bitbucket_cloud_client_id"`
+	BitbucketCloudClientSecret  string       `json:"bitbucket_cloud_client_secret"`
+	ConcourseCACert             string       `json:"concourse_ca_cert"`
+	ConcourseCert               string       `json:"concourse_cert"`
+	ConcourseKey                string       `json:"concourse_key"`
+	ConcoursePassword           string       `json:"concourse_password"`
+	ConcourseUsername           string       `json:"concourse_username"`
+	ConcourseVersion            string       `json:"concourse_version"`
+	ConcourseWebCount           int          `json:"concourse_web_count"`
+	ConcourseWebSize            string       `json:"concourse_web_size"`
+	ConcourseWorkerCount        int          `json:"concourse_worker_count"`
+	ConcourseWorkerSize         string       `json:"concourse_worker_size"`
+	ConfigBucket                string       `json:"config_bucket"`
+	CredentialManager           string       `json:"credential_manager"`
+	CredhubAdminClientSecret    string       `json:"credhub_admin_client_secret"`
+	CredhubCACert               string       `json:"credhub_ca_cert"`
+	CredhubPassword             string       `json:"credhub_password"`
+	CredhubURL                  string       `json:"credhub_url"`
+	CredhubUsername             string       `json:"credhub_username"`
+	Deployment                  string       `json:"deployment"`
+	DirectorCACert              string       `json:"director_ca_cert"`
+	DirectorCert                string       `json:"director_cert"`
+	DirectorHMUserPassword      string       `json:"director_hm_user_password"`
+	DirectorKey                 string       `json:"director_key"`
+	DirectorMbusPassword        string       `json:"director_mbus_password"`
+	DirectorNATSPassword        string       `json:"director_nats_password"`
+	DirectorPassword            string       `json:"director_password"`
+	DirectorPublicIP            string       `json:"director_public_ip"`
+	DirectorRegistryPassword    string       `json:"director_registry_password"`
+	DirectorUsername            string       `json:"director_username"`
+	DisableGrafana              bool         `json:"disable_grafana"`
+	Domain                      string       `json:"domain"`
+	EnableGlobalResources       bool         `json:"enable_global_resources"`
+	EncryptionKey               string       `json:"encryption_key"`
+	GithubClientID              string       `json:"github_client_id"`
+	GithubClientSecret          string       `json:"github_client_secret"`
+	GitLabClientID              string       `json:"gitlab_client_id"`
+	GitLabClientSecret          string       `json:"gitlab_client_secret"`
+	GitLabHost                  string       `json:"gitlab_host"`
+	GrafanaDashboards           []CustomFile `json:"grafana_dashboards"`
+	GrafanaNotifiers            []CustomFile `json:"grafana_notifiers"`
+	GrafanaPassword             string       `json:"grafana_password"`
+	HostedZoneID                string       `json:"hosted_zone_id"`
+	HostedZoneRecordPrefix      string       `json:"hosted_zone_record_prefix"`
+	IAAS                        string       `json:"iaas"`
+	LDAPBindDN                  string       `json:"ldap_bind_dn"`
+	LDAPBindPassword            string       `json:"ldap_bind_password"`
+	LDAPCACert                  string       `json:"ldap_ca_cert"`
+	LDAPGroupSearchBaseDN       string       `json:"ldap_group_search_base_dn"`
+	LDAPHost                    string       `json:"ldap_host"`
+	LDAPUserSearchBaseDN        string       `json:"ldap_user_search_base_dn"`
+	LDAPUserSearchUsername      string       `json:"ldap_user_search_username"`
+	MainTeamBitbucketCloudTeams []string     `json:"main_team_bitbucket_cloud_teams"`
+	MainTeamBitbucketCloudUsers []string     `json:"main_team_bitbucket_cloud_users"`
+	MainTeamGithubOrgs          []string     `json:"main_team_github_orgs"`
+	MainTeamGithubTeams         []string     `json:"main_team_github_teams"`
+	MainTeamGithubUsers         []string     `json:"main_team_github_users"`
+	MainTeamGitLabGroups        []string     `json:"main_team_gitlab_groups"`
+	MainTeamGitLabUsers         []string     `json:"main_team_gitlab_users"`
+	MainTeamLDAPGroups          []string     `json:"main_team_ldap_groups"`
+	MainTeamLDAPUsers           []string     `json:"main_team_ldap_users"`
+	MainTeamOIDCGroups          []string     `json:"main_team_oidc_groups"`
+	MainTeamOIDCUsers           []string     `json:"main_team_oidc_users"`
+	ManageTeams                 bool         `json:"manage_teams"`
+	Metrics                     string       `json:"metrics"`
+	MetricsAllowIPs             string       `json:"metrics_allow_ips"`
+	Namespace                   string       `json:"namespace"`
+	NetworkCIDR                 string       `json:"network_cidr"`
+	OIDCClientID                string       `json:"oidc_client_id"`
+	OIDCClientSecret            string       `json:"oidc_client_secret"`
+	OIDCGroupsClaim             string       `json:"oidc_groups_claim"`
+	OIDCIssuer                  string       `json:"oidc_issuer"`
+	OIDCScopes                  []string     `json:"oidc_scopes"`
+	PrivateCIDR                 string       `json:"private_cidr"`
+	PrivateKey                  string       `json:"private_key"`
+	Project                     string       `json:"project"`
+	PublicCIDR                  string       `json:"public_cidr"`
+	PublicKey                   string       `json:"public_key"`
+	RDS1CIDR                    string       `json:"rds1_cidr"`
+	RDS2CIDR                    string       `json:"rds2_cidr"`
+	RDSDefaultDatabaseName      string       `json:"rds_default_database_name"`
+	RDSInstanceClass            string       `json:"rds_instance_class"`
+	RDSPassword                 string       `json:"rds_password"`
+	RDSUsername                 string       `json:"rds_username"`
+	Region                      string       `json:"region"`
+	RuntimeConfig               string       `json:"runtime_config"`
+	ScheduleAppliedAt           string       `json:"schedule_applied_at"`
+	ScheduleDown                string       `json:"schedule_down"`
+	ScheduleState               string       `json:"schedule_state"`
+	ScheduleStop                bool         `json:"schedule_stop"`
+	ScheduleTimezone            string       `json:"schedule_timezone"`
+	ScheduleUp                  string       `json:"schedule_up"`
+	ScheduleWorkers             int          `json:"schedule_workers"`
+	SourceAccessIP              string       `json:"source_access_ip"`
+	StemcellOS                  string       `json:"stemcell_os"`
+	SyslogAddress               string       `json:"syslog_address"`
+	SyslogCACert                string       `json:"syslog_ca_cert"`
+	SyslogPermittedPeer         string       `json:"syslog_permitted_peer"`
+	SyslogTransport             string       `json:"syslog_transport"`
 	//Spot is deprecated, exists only as we need to migrate old configs to VMProvisioningType
 	Spot               bool     `json:"spot"`
 	Tags               []string `json:"tags"`
 	TFStatePath        string   `json:"tf_state_path"`
+	TSAAllowIPs        string   `json:"tsa_allow_ips"`
+	VaultAuthBackend   string   `json:"vault_auth_backend"`
+	VaultCACert        string   `json:"vault_ca_cert"`
+	VaultClientCert    string   `json:"vault_client_cert"`
+	VaultClientKey     string   `json:"vault_client_key"`
+	VaultPathPrefix    string   `json:"vault_path_prefix"`
+	VaultRoleID        string   `json:"vault_role_id"`
+	VaultSecretID      string   `json:"vault_secret_id"`
+	VaultURL           string   `json:"vault_url"`
 	Version            string   `json:"version"`
 	VMProvisioningType string   `json:"vm_provisioning_type"`
-	WorkerType         string   `json:"worker_type"`
+	// CloudConfigOpsFiles, ConcourseOpsFiles and DirectorOpsFiles are applied after control-tower's own ops files
+	CloudConfigOpsFiles []CustomFile `json:"cloud_config_ops_files"`
+	ConcourseOpsFiles   []CustomFile `json:"concourse_ops_files"`
+	DirectorOpsFiles    []CustomFile `json:"director_ops_files"`
+	// VarsFiles are passed to bosh deploy of the Concourse manifest
+	VarsFiles []CustomFile `json:"vars_files"`
+	// ConcourseProperties are set on the web and worker jobs in addition to those control-tower manages
+	ConcourseProperties ConcourseProperties `json:"concourse_properties"`
+	// ExternalWorkers run outside the deployment and register with the TSA using their own keys
+	ExternalWorkers []ExternalWorker `json:"external_workers"`
+	// MaintenanceWindow limits the self-update pipeline's redeploys to certain times
+	MaintenanceWindow MaintenanceWindow `json:"maintenance_window"`
+	// Notifications report the outcome of the self-update pipeline's jobs
+	Notifications Notifications `json:"notifications"`
+	// Proxy is set on the director and workers so they can fetch releases and resources
+	Proxy Proxy `json:"proxy"`
+	// SelfUpdate sets where the self-update pipeline finds new releases and how it applies them
+	SelfUpdate SelfUpdate `json:"self_update"`
+	// WorkerPools are deployed in addition to the ConcourseWorkerCount default workers
+	WorkerPools []WorkerPool `json:"worker_pools"`
+	WorkerType  string       `json:"worker_type"`
 }
 
 type ConfigView interface {
 	GetAllowIPs() string
+	GetArtefactMirror() string
+	GetAutoscaleCooldown() string
+	GetAutoscaleHighContainers() int
+	GetAutoscaleHighVolumes() int
+	GetAutoscaleLastScaledAt() string
+	GetAutoscaleLowContainers() int
+	GetAutoscaleLowVolumes() int
+	GetAutoscaleMaxWorkers() int
+	GetAutoscaleMinWorkers() int
 	GetAvailabilityZone() string
+	GetAWSCredentialPathPrefix() string
+	GetBitbucketCloudClientID() string
+	GetBitbucketCloudClientSecret() string
+	GetCloudConfigOpsFiles() []CustomFile
 	GetConcourseCACert() string
 	GetConcourseCert() string
 	GetConcourseKey() string
+	GetConcourseOpsFiles() []CustomFile
 	GetConcoursePassword() string
+	GetConcourseProperties() ConcourseProperties
 	GetConcourseUsername() string
+	GetConcourseVersion() string
+	GetConcourseWebCount() int
 	GetConcourseWebSize() string
 	GetConcourseWorkerCount() int
 	GetConcourseWorkerSize() string
 	GetConfigBucket() string
+	GetCredentialManager() string
 	GetCredhubAdminClientSecret() string
 	GetCredhubCACert() string
 	GetCredhubPassword() string
 	GetCredhubURL() string
 	GetCredhubUsername() string
+	GetDeployedWorkerCount() int
 	GetDeployment() string
 	GetDirectorCACert() string
 	GetDirectorCert() string
@@ -97,24 +239,61 @@ type ConfigView interface {
 	GetDirectorKey() string
 	GetDirectorMbusPassword() string
 	GetDirectorNATSPassword() string
+	GetDirectorOpsFiles() []CustomFile
 	GetDirectorPassword() string
 	GetDirectorPublicIP() string
 	GetDirectorRegistryPassword() string
 	GetDirectorUsername() string
+	GetDisableGrafana() bool
 	GetDomain() string
 	GetEnableGlobalResources() bool
 	GetEncryptionKey() string
+	GetExternalWorkers() []ExternalWorker
 	GetGithubClientID() string
 	GetGithubClientSecret() string
+	GetGitLabClientID() string
+	GetGitLabClientSecret() string
+	GetGitLabHost() string
+	GetGrafanaDashboards() []CustomFile
+	GetGrafanaNotifiers() []CustomFile
 	GetGrafanaPassword() string
 	GetHostedZoneID() string
 	GetHostedZoneRecordPrefix() string
 	GetIAAS() string
+	GetLDAPBindDN() string
+	GetLDAPBindPassword() string
+	GetLDAPCACert() string
+	GetLDAPGroupSearchBaseDN() string
+	GetLDAPHost() string
+	GetLDAPUserSearchBaseDN() string
+	GetLDAPUserSearchUsername() string
+	GetMainTeamBitbucketCloudTeams() []string
+	GetMainTeamBitbucketCloudUsers() []string
+	GetMainTeamGithubOrgs() []string
+	GetMainTeamGithubTeams() []string
+	GetMainTeamGithubUsers() []string
+	GetMainTeamGitLabGroups() []string
+	GetMainTeamGitLabUsers() []string
+	GetMainTeamLDAPGroups() []string
+	GetMainTeamLDAPUsers() []string
+	GetMainTeamOIDCGroups() []string
+	GetMainTeamOIDCUsers() []string
+	GetManageTeams() bool
+	GetMaintenanceWindow() MaintenanceWindow
+	GetMetrics() string
+	GetMetricsAllowIPs() string
 	GetNamespace() string
 	GetNetworkCIDR() string
+	GetNotifications() Notifications
+	GetOIDCClientID() string
+	GetOIDCClientSecret() string
+	GetOIDCGroupsClaim() string
+	GetOIDCIssuer() string
+	GetOIDCScopes() []string
 	GetPrivateCIDR() string
 	GetPrivateKey() string
 	GetProject() string
+	GetProxy() Proxy
 	GetPublicCIDR() string
 	GetPublicKey() string
 	GetRDS1CIDR() string
@@ -124,23 +303,113 @@ type ConfigView interface {
 	GetRDSPassword() string
 	GetRDSUsername() string
 	GetRegion() string
+	GetRuntimeConfig() string
+	GetScheduleAppliedAt() string
+	GetScheduleDown() string
+	GetScheduleState() string
+	GetScheduleStop() bool
+	GetScheduleTimezone() string
+	GetScheduleUp() string
+	GetScheduleWorkers() int
+	GetSelfUpdate() SelfUpdate
 	GetSourceAccessIP() string
+	GetStemcellOS() string
+	GetSyslogAddress() string
+	GetSyslogCACert() string
+	GetSyslogPermittedPeer() string
+	GetSyslogTransport() string
 	GetTags() []string
 	GetTFStatePath() string
+	GetTSAAllowIPs() string
+	GetVaultAuthBackend() string
+	GetVaultCACert() string
+	GetVaultClientCert() string
+	GetVaultClientKey() string
+	GetVaultPathPrefix() string
+	GetVaultRoleID() string
+	GetVaultSecretID() string
+	GetVaultURL() string
+	GetVarsFiles() []CustomFile
 	GetVersion() string
+	GetWorkerPools() []WorkerPool
 	GetWorkerType() string
+	IsAutoscaling() bool
+	IsAWSCredentialManager() bool
+	IsBitbucketCloudAuthSet() bool
+	IsCredhubCredentialManager() bool
 	IsGithubAuthSet() bool
+	IsGitLabAuthSet() bool
+	IsLDAPAuthSet() bool
+	IsOIDCAuthSet() bool
+	IsPrometheusMetrics() bool
+	IsScheduled() bool
+	IsScheduledDown() bool
 	IsSpot() bool
+	IsSyslogSet() bool
+	IsVaultCredentialManager() bool
+	IsWebLoadBalanced() bool
 }
 
 func (c Config) GetAllowIPs() string {
 	return c.AllowIPs
 }
 
+func (c Config) GetArtefactMirror() string {
+	return c.ArtefactMirror
+}
+
+func (c Config) GetAutoscaleCooldown() string {
+	return c.AutoscaleCooldown
+}
+
+func (c Config) GetAutoscaleHighContainers() int {
+	return c.AutoscaleHighContainers
+}
+
+func (c Config) GetAutoscaleHighVolumes() int {
+	return c.AutoscaleHighVolumes
+}
+
+func (c Config) GetAutoscaleLastScaledAt() string {
+	return c.AutoscaleLastScaledAt
+}
+
+func (c Config) GetAutoscaleLowContainers() int {
+	return c.AutoscaleLowContainers
+}
+
+func (c Config) GetAutoscaleLowVolumes() int {
+	return c.AutoscaleLowVolumes
+}
+
+func (c Config) GetAutoscaleMaxWorkers() int {
+	return c.AutoscaleMaxWorkers
+}
+
+func (c Config) GetAutoscaleMinWorkers() int {
+	return c.AutoscaleMinWorkers
+}
+
 func (c Config) GetAvailabilityZone() string {
 	return c.AvailabilityZone
 }
 
+func (c Config) GetAWSCredentialPathPrefix() string {
+	return c.AWSCredentialPathPrefix
+}
+
+func (c Config) GetBitbucketCloudClientID() string {
+	return c.BitbucketCloudClientID
+}
+
+func (c Config) GetBitbucketCloudClientSecret() string {
+	return c.BitbucketCloudClientSecret
+}
+
+func (c Config) GetCloudConfigOpsFiles() []CustomFile {
+	return c.CloudConfigOpsFiles
+}
+
 func (c Config) GetConcourseCACert() string {
 	return c.ConcourseCACert
 }
@@ -153,14 +422,30 @@ func (c Config) GetConcourseKey() string {
 	return c.ConcourseKey
 }
 
+func (c Config) GetConcourseOpsFiles() []CustomFile {
+	return c.ConcourseOpsFiles
+}
+
 func (c Config) GetConcoursePassword() string {
 	return c.ConcoursePassword
 }
 
+func (c Config) GetConcourseProperties() ConcourseProperties {
+	return c.ConcourseProperties
+}
+
 func (c Config) GetConcourseUsername() string {
 	return c.ConcourseUsername
 }
 
+func (c Config) GetConcourseVersion() string {
+	return c.ConcourseVersion
+}
+
+func (c Config) GetConcourseWebCount() int {
+	return c.ConcourseWebCount
+}
+
 func (c Config) GetConcourseWebSize() string {
 	return c.ConcourseWebSize
 }
@@ -177,6 +462,10 @@ func (c Config) GetConfigBucket() string {
 	return c.ConfigBucket
 }
 
+func (c Config) GetCredentialManager() string {
+	return c.CredentialManager
+}
+
 func (c Config) GetCredhubAdminClientSecret() string {
 	return c.CredhubAdminClientSecret
 }
@@ -197,6 +486,15 @@ func (c Config) GetCredhubUsername() string {
 	return c.CredhubUsername
 }
 
+// GetDeployedWorkerCount returns the number of default workers to deploy, which is the schedule's
+// number of workers while a schedule has scaled the deployment down
+func (c Config) GetDeployedWorkerCount() int {
+	if c.IsScheduledDown() {
+		return c.ScheduleWorkers
+	}
+	return c.ConcourseWorkerCount
+}
+
 func (c Config) GetDeployment() string {
 	return c.Deployment
 }
@@ -225,6 +523,10 @@ func (c Config) GetDirectorNATSPassword() string {
 	return c.DirectorNATSPassword
 }
 
+func (c Config) GetDirectorOpsFiles() []CustomFile {
+	return c.DirectorOpsFiles
+}
+
 func (c Config) GetDirectorPassword() string {
 	return c.DirectorPassword
 }
@@ -241,6 +543,10 @@ func (c Config) GetDirectorUsername() string {
 	return c.DirectorUsername
 }
 
+func (c Config) GetDisableGrafana() bool {
+	return c.DisableGrafana
+}
+
 func (c Config) GetDomain() string {
 	return c.Domain
 }
@@ -253,6 +559,10 @@ func (c Config) GetEncryptionKey() string {
 	return c.EncryptionKey
 }
 
+func (c Config) GetExternalWorkers() []ExternalWorker {
+	return c.ExternalWorkers
+}
+
 func (c Config) GetGithubClientID() string {
 	return c.GithubClientID
 }
@@ -261,6 +571,26 @@ func (c Config) GetGithubClientSecret() string {
 	return c.GithubClientSecret
 }
 
+func (c Config) GetGitLabClientID() string {
+	return c.GitLabClientID
+}
+
+func (c Config) GetGitLabClientSecret() string {
+	return c.GitLabClientSecret
+}
+
+func (c Config) GetGitLabHost() string {
+	return c.GitLabHost
+}
+
+func (c Config) GetGrafanaDashboards() []CustomFile {
+	return c.GrafanaDashboards
+}
+
+func (c Config) GetGrafanaNotifiers() []CustomFile {
+	return c.GrafanaNotifiers
+}
+
 func (c Config) GetGrafanaPassword() string {
 	return c.GrafanaPassword
 }
@@ -277,6 +607,94 @@ func (c Config) GetIAAS() string {
 	return c.IAAS
 }
 
+func (c Config) GetLDAPBindDN() string {
+	return c.LDAPBindDN
+}
+
+func (c Config) GetLDAPBindPassword() string {
+	return c.LDAPBindPassword
+}
+
+func (c Config) GetLDAPCACert() string {
+	return c.LDAPCACert
+}
+
+func (c Config) GetLDAPGroupSearchBaseDN() string {
+	return c.LDAPGroupSearchBaseDN
+}
+
+func (c Config) GetLDAPHost() string {
+	return c.LDAPHost
+}
+
+func (c Config) GetLDAPUserSearchBaseDN() string {
+	return c.LDAPUserSearchBaseDN
+}
+
+func (c Config) GetLDAPUserSearchUsername() string {
+	return c.LDAPUserSearchUsername
+}
+
+func (c Config) GetMainTeamBitbucketCloudTeams() []string {
+	return c.MainTeamBitbucketCloudTeams
+}
+
+func (c Config) GetMainTeamBitbucketCloudUsers() []string {
+	return c.MainTeamBitbucketCloudUsers
+}
+
+func (c Config) GetMainTeamGithubOrgs() []string {
+	return c.MainTeamGithubOrgs
+}
+
+func (c Config) GetMainTeamGithubTeams() []string {
+	return c.MainTeamGithubTeams
+}
+
+func (c Config) GetMainTeamGithubUsers() []string {
+	return c.MainTeamGithubUsers
+}
+
+func (c Config) GetMainTeamGitLabGroups() []string {
+	return c.MainTeamGitLabGroups
+}
+
+func (c Config) GetMainTeamGitLabUsers() []string {
+	return c.MainTeamGitLabUsers
+}
+
+func (c Config) GetMainTeamLDAPGroups() []string {
+	return c.MainTeamLDAPGroups
+}
+
+func (c Config) GetMainTeamLDAPUsers() []string {
+	return c.MainTeamLDAPUsers
+}
+
+func (c Config) GetMainTeamOIDCGroups() []string {
+	return c.MainTeamOIDCGroups
+}
+
+func (c Config) GetMainTeamOIDCUsers() []string {
+	return c.MainTeamOIDCUsers
+}
+
+func (c Config) GetManageTeams() bool {
+	return c.ManageTeams
+}
+
+func (c Config) GetMaintenanceWindow() MaintenanceWindow {
+	return c.MaintenanceWindow
+}
+
+func (c Config) GetMetrics() string {
+	return c.Metrics
+}
+
+func (c Config) GetMetricsAllowIPs() string {
+	return c.MetricsAllowIPs
+}
+
 func (c Config) GetNamespace() string {
 	return c.Namespace
 }
@@ -285,6 +703,30 @@ func (c Config) GetNetworkCIDR() string {
 	return c.NetworkCIDR
 }
 
+func (c Config) GetNotifications() Notifications {
+	return c.Notifications
+}
+
+func (c Config) GetOIDCClientID() string {
+	return c.OIDCClientID
+}
+
+func (c Config) GetOIDCClientSecret() string {
+	return c.OIDCClientSecret
+}
+
+func (c Config) GetOIDCGroupsClaim() string {
+	return c.OIDCGroupsClaim
+}
+
+func (c Config) GetOIDCIssuer() string {
+	return c.OIDCIssuer
+}
+
+func (c Config) GetOIDCScopes() []string {
+	return c.OIDCScopes
+}
+
 func (c Config) GetPrivateCIDR() string {
 	return c.PrivateCIDR
 }
@@ -297,6 +739,10 @@ func (c Config) GetProject() string {
 	return c.Project
 }
 
+func (c Config) GetProxy() Proxy {
+	return c.Proxy
+}
+
 func (c Config) GetPublicCIDR() string {
 	return c.PublicCIDR
 }
@@ -333,10 +779,70 @@ func (c Config) GetRegion() string {
 	return c.Region
 }
 
+func (c Config) GetRuntimeConfig() string {
+	return c.RuntimeConfig
+}
+
+func (c Config) GetScheduleAppliedAt() string {
+	return c.ScheduleAppliedAt
+}
+
+func (c Config) GetScheduleDown() string {
+	return c.ScheduleDown
+}
+
+func (c Config) GetScheduleState() string {
+	return c.ScheduleState
+}
+
+func (c Config) GetScheduleStop() bool {
+	return c.ScheduleStop
+}
+
+func (c Config) GetScheduleTimezone() string {
+	return c.ScheduleTimezone
+}
+
+func (c Config) GetScheduleUp() string {
+	return c.ScheduleUp
+}
+
+func (c Config) GetScheduleWorkers() int {
+	return c.ScheduleWorkers
+}
+
+func (c Config) GetSelfUpdate() SelfUpdate {
+	return c.SelfUpdate
+}
+
 func (c Config) GetSourceAccessIP() string {
 	return c.SourceAccessIP
 }
 
+func (c Config) GetStemcellOS() string {
+	return c.StemcellOS
+}
+
+func (c Config) GetSyslogAddress() string {
+	return c.SyslogAddress
+}
+
+func (c Config) GetSyslogCACert() string {
+	return c.SyslogCACert
+}
+
+func (c Config) GetSyslogPermittedPeer() string {
+	return c.SyslogPermittedPeer
+}
+
+// GetSyslogTransport returns tcp, the default of --syslog-transport, until another transport is given
+func (c Config) GetSyslogTransport() string {
+	if c.SyslogTransport == "" {
+		return "tcp"
+	}
+	return c.SyslogTransport
+}
+
 func (c Config) GetTags() []string {
 	return c.Tags
 }
@@ -345,18 +851,114 @@ func (c Config) GetTFStatePath() string {
 	return c.TFStatePath
 }
 
+func (c Config) GetTSAAllowIPs() string {
+	return c.TSAAllowIPs
+}
+
+func (c Config) GetVaultAuthBackend() string {
+	return c.VaultAuthBackend
+}
+
+func (c Config) GetVaultCACert() string {
+	return c.VaultCACert
+}
+
+func (c Config) GetVaultClientCert() string {
+	return c.VaultClientCert
+}
+
+func (c Config) GetVaultClientKey() string {
+	return c.VaultClientKey
+}
+
+func (c Config) GetVaultPathPrefix() string {
+	return c.VaultPathPrefix
+}
+
+func (c Config) GetVaultRoleID() string {
+	return c.VaultRoleID
+}
+
+func (c Config) GetVaultSecretID() string {
+	return c.VaultSecretID
+}
+
+func (c Config) GetVaultURL() string {
+	return c.VaultURL
+}
+
+func (c Config) GetVarsFiles() []CustomFile {
+	return c.VarsFiles
+}
+
 func (c Config) GetVersion() string {
 	return c.Version
 }
 
+func (c Config) GetWorkerPools() []WorkerPool {
+	return c.WorkerPools
+}
+
 func (c Config) GetWorkerType() string {
 	return c.WorkerType
 }
 
+func (c Config) IsAutoscaling() bool {
+	return c.AutoscaleMaxWorkers > 0
+}
+
+func (c Config) IsAWSCredentialManager() bool {
+	return c.CredentialManager == CredentialManagerAWSSecretsManager || c.CredentialManager == CredentialManagerAWSSSM
+}
+
+func (c Config) IsBitbucketCloudAuthSet() bool {
+	return c.BitbucketCloudClientID != "" && c.BitbucketCloudClientSecret != ""
+}
+
+func (c Config) IsCredhubCredentialManager() bool {
+	return c.CredentialManager == CredentialManagerCredhub
+}
+
 func (c Config) IsGithubAuthSet() bool {
 	return c.GithubClientID != "" && c.GithubClientSecret != ""
 }
 
+func (c Config) IsGitLabAuthSet() bool {
+	return c.GitLabClientID != "" && c.GitLabClientSecret != ""
+}
+
+func (c Config) IsLDAPAuthSet() bool {
+	return c.LDAPHost != "" && c.LDAPBindDN != "" && c.LDAPBindPassword != "" && c.LDAPUserSearchBaseDN != ""
+}
+
+func (c Config) IsOIDCAuthSet() bool {
+	return c.OIDCIssuer != "" && c.OIDCClientID != "" && c.OIDCClientSecret != ""
+}
+
+func (c Config) IsPrometheusMetrics() bool {
+	return c.Metrics == MetricsPrometheus
+}
+
+func (c Config) IsScheduled() bool {
+	return c.ScheduleDown != ""
+}
+
+func (c Config) IsScheduledDown() bool {
+	return c.ScheduleState != ""
+}
+
 func (c Config) IsSpot() bool {
 	return c.VMProvisioningType == SPOT
 }
+
+func (c Config) IsSyslogSet() bool {
+	return c.SyslogAddress != ""
+}
+
+func (c Config) IsVaultCredentialManager() bool {
+	return c.CredentialManager == CredentialManagerVault
+}
+
+func (c Config) IsWebLoadBalanced() bool {
+	return c.ConcourseWebCount > 1
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// customFilesDir is where the contents of custom files are kept in the config bucket
+const customFilesDir = "custom-files"
+
+// CustomFile is a user-supplied ops, vars or Grafana file, kept with the config so that every deploy applies it. The
+// config only holds its Key, and its Contents are a config bucket asset of their own
+type CustomFile struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+	// Contents are loaded from Key with the config. Configs saved before files were assets hold them here
+	Contents string `json:"contents,omitempty"`
+}
+
+// assetKey returns the key that the file's contents are stored under, which changes whenever they do
+func (f CustomFile) assetKey() string {
+	sum := sha256.Sum256([]byte(f.Contents))
+	return fmt.Sprintf("%s/%x-%s", customFilesDir, sum[:8], f.Name)
+}
+
+// customFiles returns each list of custom files in c
+func (c *Config) customFiles() []*[]CustomFile {
+	return []*[]CustomFile{&c.CloudConfigOpsFiles, &c.ConcourseOpsFiles, &c.DirectorOpsFiles, &c.VarsFiles, &c.GrafanaDashboards, &c.GrafanaNotifiers}
+}
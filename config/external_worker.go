@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ExternalWorker is a Concourse worker running outside the deployment that is authorised to register with the TSA
+type ExternalWorker struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+	Team      string `json:"team"`
+}
+
+// NewExternalWorker returns an ExternalWorker after checking that publicKey is a single SSH public key
+// in authorized_keys format. If team is set the worker may only register as a worker for that team
+func NewExternalWorker(name, publicKey, team string) (ExternalWorker, error) {
+	if !workerPoolNameRegexp.MatchString(name) {
+		return ExternalWorker{}, fmt.Errorf("external worker name `%s` must start with a letter and contain only lowercase letters, digits and hyphens", name)
+	}
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return ExternalWorker{}, fmt.Errorf("public key for external worker `%s` is not in authorized_keys format: [%v]", name, err)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return ExternalWorker{}, fmt.Errorf("public key for external worker `%s` must contain exactly one key", name)
+	}
+
+	return ExternalWorker{
+		Name:      name,
+		PublicKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Team:      team,
+	}, nil
+}
+
+// AddExternalWorker adds worker to the config, replacing any external worker with the same name
+func (c *Config) AddExternalWorker(worker ExternalWorker) {
+	for i, existing := range c.ExternalWorkers {
+		if existing.Name == worker.Name {
+			c.ExternalWorkers[i] = worker
+			return
+		}
+	}
+	c.ExternalWorkers = append(c.ExternalWorkers, worker)
+}
+
+// RemoveExternalWorker removes the named external worker from the config
+func (c *Config) RemoveExternalWorker(name string) error {
+	for i, existing := range c.ExternalWorkers {
+		if existing.Name == name {
+			c.ExternalWorkers = append(c.ExternalWorkers[:i], c.ExternalWorkers[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("deployment has no external worker named `%s`", name)
+}
@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+const externalWorkerKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEmm7pOtY+m7xaDkwzDMFacrDpSs3ZxQp+NlThSZlLOm"
+
+func TestNewExternalWorker(t *testing.T) {
+	tests := []struct {
+		name      string
+		worker    string
+		publicKey string
+		team      string
+		want      ExternalWorker
+		wantErr   string
+	}{
+		{
+			name:      "strips the key comment",
+			worker:    "dc-worker",
+			publicKey: externalWorkerKey + " dc-worker@example.com\n",
+			team:      "ops",
+			want:      ExternalWorker{Name: "dc-worker", PublicKey: externalWorkerKey, Team: "ops"},
+		},
+		{
+			name:      "invalid name",
+			worker:    "DC",
+			publicKey: externalWorkerKey,
+			wantErr:   "external worker name `DC` must start with a letter and contain only lowercase letters, digits and hyphens",
+		},
+		{
+			name:      "more than one key",
+			worker:    "dc-worker",
+			publicKey: externalWorkerKey + "\n" + externalWorkerKey + "\n",
+			wantErr:   "public key for external worker `dc-worker` must contain exactly one key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExternalWorker(tt.worker, tt.publicKey, tt.team)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("NewExternalWorker() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewExternalWorker() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewExternalWorker() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_AddAndRemoveExternalWorker(t *testing.T) {
+	c := Config{}
+	c.AddExternalWorker(ExternalWorker{Name: "a", PublicKey: "key-a"})
+	c.AddExternalWorker(ExternalWorker{Name: "b", PublicKey: "key-b"})
+	c.AddExternalWorker(ExternalWorker{Name: "a", PublicKey: "new-key-a", Team: "ops"})
+
+	want := []ExternalWorker{{Name: "a", PublicKey: "new-key-a", Team: "ops"}, {Name: "b", PublicKey: "key-b"}}
+	if !reflect.DeepEqual(c.ExternalWorkers, want) {
+		t.Fatalf("ExternalWorkers = %+v, want %+v", c.ExternalWorkers, want)
+	}
+
+	if err := c.RemoveExternalWorker("a"); err != nil {
+		t.Fatalf("RemoveExternalWorker() unexpected error = %v", err)
+	}
+	if err := c.RemoveExternalWorker("a"); err == nil || err.Error() != "deployment has no external worker named `a`" {
+		t.Errorf("RemoveExternalWorker() error = %v", err)
+	}
+	if !reflect.DeepEqual(c.ExternalWorkers, want[1:]) {
+		t.Errorf("ExternalWorkers = %+v, want %+v", c.ExternalWorkers, want[1:])
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaintenanceWindow restricts when the self-update pipeline redeploys, as a range of a Concourse time
+// resource. Start and Stop are times of day in Location, and Days limits the window to some weekdays
+type MaintenanceWindow struct {
+	Days     []string `json:"days"`
+	Location string   `json:"location"`
+	Start    string   `json:"start"`
+	Stop     string   `json:"stop"`
+}
+
+// MaintenanceWindowDays are the day names a maintenance window can be limited to
+var MaintenanceWindowDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// timeOfDayLayouts are the formats the Concourse time resource accepts for start and stop
+var timeOfDayLayouts = []string{"3:04 PM", "3PM", "3 PM", "15:04", "1504"}
+
+// IsSet returns true if the self-update pipeline should only redeploy within the window
+func (w MaintenanceWindow) IsSet() bool {
+	return w.Start != "" && w.Stop != ""
+}
+
+// Validate returns an error describing the first setting that the Concourse time resource would not accept
+func (w MaintenanceWindow) Validate() error {
+	for _, t := range []struct {
+		name, value string
+	}{
+		{"start", w.Start},
+		{"stop", w.Stop},
+	} {
+		if t.value != "" && !isTimeOfDay(t.value) {
+			return fmt.Errorf("maintenance window %s `%s` must be a time of day such as 1:00 AM or 01:00", t.name, t.value)
+		}
+	}
+	for _, day := range w.Days {
+		if !isOneOf(day, MaintenanceWindowDays) {
+			return fmt.Errorf("unknown maintenance window day `%s`. Valid days are: %v", day, MaintenanceWindowDays)
+		}
+	}
+	if w.Location != "" {
+		if _, err := time.LoadLocation(w.Location); err != nil {
+			return fmt.Errorf("maintenance window location `%s` is not a known timezone such as Europe/London", w.Location)
+		}
+	}
+	return nil
+}
+
+func isTimeOfDay(value string) bool {
+	for _, layout := range timeOfDayLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
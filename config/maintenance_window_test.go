@@ -0,0 +1,63 @@
+package config_test
+
+import (
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  MaintenanceWindow
+		wantErr string
+	}{
+		{
+			name:   "no window",
+			window: MaintenanceWindow{},
+		},
+		{
+			name: "weekend nights",
+			window: MaintenanceWindow{
+				Days:     []string{"Saturday", "Sunday"},
+				Location: "Europe/London",
+				Start:    "1:00 AM",
+				Stop:     "04:00",
+			},
+		},
+		{
+			name:    "start is not a time",
+			window:  MaintenanceWindow{Start: "midnight"},
+			wantErr: "maintenance window start `midnight` must be a time of day such as 1:00 AM or 01:00",
+		},
+		{
+			name:    "stop is out of range",
+			window:  MaintenanceWindow{Stop: "25:00"},
+			wantErr: "maintenance window stop `25:00` must be a time of day such as 1:00 AM or 01:00",
+		},
+		{
+			name:    "abbreviated day",
+			window:  MaintenanceWindow{Days: []string{"Sat"}},
+			wantErr: "unknown maintenance window day `Sat`. Valid days are: [Monday Tuesday Wednesday Thursday Friday Saturday Sunday]",
+		},
+		{
+			name:    "unknown location",
+			window:  MaintenanceWindow{Location: "Mars/Olympus"},
+			wantErr: "maintenance window location `Mars/Olympus` is not a known timezone such as Europe/London",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.window.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
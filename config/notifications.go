@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// Notifications are the targets the self-update pipeline reports the outcome of its jobs to
+type Notifications struct {
+	// Events are the job outcomes to notify on, and default to DefaultNotificationEvents
+	Events          []string `json:"events"`
+	SlackWebhookURL string   `json:"slack_webhook_url"`
+	// SMTPHost is the host:port of a mail server that supports STARTTLS
+	SMTPHost     string   `json:"smtp_host"`
+	SMTPFrom     string   `json:"smtp_from"`
+	SMTPPassword string   `json:"smtp_password"`
+	SMTPTo       []string `json:"smtp_to"`
+	SMTPUsername string   `json:"smtp_username"`
+	WebhookURL   string   `json:"webhook_url"`
+}
+
+// NotificationEvents are the job outcomes that can be notified on, named after Concourse's step hooks
+var NotificationEvents = []string{"success", "failure", "error"}
+
+// DefaultNotificationEvents are notified on when no events are chosen
+var DefaultNotificationEvents = []string{"failure", "error"}
+
+// IsSet returns true if there is at least one notification target
+func (n Notifications) IsSet() bool {
+	return n.SlackWebhookURL != "" || n.SMTPHost != "" || n.WebhookURL != ""
+}
+
+// HookEvents returns the events to add step hooks for, or none if there is nowhere to send notifications
+func (n Notifications) HookEvents() []string {
+	if !n.IsSet() {
+		return nil
+	}
+	if len(n.Events) == 0 {
+		return DefaultNotificationEvents
+	}
+	return n.Events
+}
+
+// Validate returns an error describing the first setting that notifications could not be sent with
+func (n Notifications) Validate() error {
+	for _, event := range n.Events {
+		if !isOneOf(event, NotificationEvents) {
+			return fmt.Errorf("unknown notification event `%s`. Valid events are: %v", event, NotificationEvents)
+		}
+	}
+	for _, u := range []struct {
+		name, value string
+	}{
+		{"Slack webhook URL", n.SlackWebhookURL},
+		{"webhook URL", n.WebhookURL},
+	} {
+		if u.value == "" {
+			continue
+		}
+		parsed, err := url.Parse(u.value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("notification %s `%s` must be an http or https URL", u.name, u.value)
+		}
+	}
+	if n.SMTPHost != "" {
+		if _, _, err := net.SplitHostPort(n.SMTPHost); err != nil {
+			return fmt.Errorf("notification SMTP host `%s` must be given as host:port", n.SMTPHost)
+		}
+	}
+	return nil
+}
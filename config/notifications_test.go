@@ -0,0 +1,75 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+func TestNotifications_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		notifications Notifications
+		wantErr       string
+	}{
+		{
+			name:          "no notifications",
+			notifications: Notifications{},
+		},
+		{
+			name: "every target",
+			notifications: Notifications{
+				Events:          []string{"success", "failure"},
+				SlackWebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+				SMTPHost:        "smtp.example.com:587",
+				SMTPFrom:        "concourse@example.com",
+				SMTPTo:          []string{"ops@example.com"},
+				WebhookURL:      "http://alerts.example.com/concourse",
+			},
+		},
+		{
+			name:          "unknown event",
+			notifications: Notifications{Events: []string{"abort"}},
+			wantErr:       "unknown notification event `abort`. Valid events are: [success failure error]",
+		},
+		{
+			name:          "webhook URL without a scheme",
+			notifications: Notifications{WebhookURL: "alerts.example.com/concourse"},
+			wantErr:       "notification webhook URL `alerts.example.com/concourse` must be an http or https URL",
+		},
+		{
+			name:          "SMTP host without a port",
+			notifications: Notifications{SMTPHost: "smtp.example.com"},
+			wantErr:       "notification SMTP host `smtp.example.com` must be given as host:port",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notifications.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotifications_HookEvents(t *testing.T) {
+	if got := (Notifications{Events: []string{"success"}}).HookEvents(); got != nil {
+		t.Errorf("HookEvents() = %v, want none without a target", got)
+	}
+	slack := Notifications{SlackWebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX"}
+	if got := slack.HookEvents(); !reflect.DeepEqual(got, []string{"failure", "error"}) {
+		t.Errorf("HookEvents() = %v, want the default events", got)
+	}
+	slack.Events = []string{"success"}
+	if got := slack.HookEvents(); !reflect.DeepEqual(got, []string{"success"}) {
+		t.Errorf("HookEvents() = %v, want [success]", got)
+	}
+}
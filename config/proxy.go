@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Proxy is the corporate HTTP(S) proxy that Control Tower, the director and the workers reach the internet through
+type Proxy struct {
+	HTTPProxy  string `json:"http_proxy"`
+	HTTPSProxy string `json:"https_proxy"`
+	// NoProxy are the hosts, domains and addresses that are reached directly
+	NoProxy []string `json:"no_proxy"`
+}
+
+// IsSet returns true if there is a proxy to use
+func (p Proxy) IsSet() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != ""
+}
+
+// Environment returns the variables that make HTTP clients and subprocesses use the proxy, in both of the cases
+// tools look for. Settings that are empty are left out, so the caller's own environment applies to them
+func (p Proxy) Environment() map[string]string {
+	env := map[string]string{}
+	set := func(name, value string) {
+		if value != "" {
+			env[strings.ToUpper(name)] = value
+			env[name] = value
+		}
+	}
+	set("http_proxy", p.HTTPProxy)
+	set("https_proxy", p.HTTPSProxy)
+	set("no_proxy", strings.Join(p.NoProxy, ","))
+	return env
+}
+
+// Validate returns an error describing the first proxy setting that is not a proxy URL
+func (p Proxy) Validate() error {
+	for _, u := range []struct {
+		name, value string
+	}{
+		{"HTTP proxy", p.HTTPProxy},
+		{"HTTPS proxy", p.HTTPSProxy},
+	} {
+		if u.value == "" {
+			continue
+		}
+		parsed, err := url.Parse(u.value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%s `%s` must be an http or https URL such as http://proxy.example.com:3128", u.name, u.value)
+		}
+	}
+	for _, host := range p.NoProxy {
+		if strings.ContainsAny(host, ", ") {
+			return fmt.Errorf("no-proxy entry `%s` must be a single host, domain or address", host)
+		}
+	}
+	return nil
+}
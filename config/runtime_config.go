@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AddonRelease is a BOSH release used by the addons of a runtime config
+type AddonRelease struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	URL     string `yaml:"url"`
+	SHA1    string `yaml:"sha1"`
+}
+
+type runtimeConfig struct {
+	Releases []AddonRelease `yaml:"releases"`
+	Addons   []struct {
+		Name string `yaml:"name"`
+		Jobs []struct {
+			Name    string `yaml:"name"`
+			Release string `yaml:"release"`
+		} `yaml:"jobs"`
+	} `yaml:"addons"`
+}
+
+// RuntimeConfigReleases returns the releases declared by a BOSH runtime config, after checking that
+// each can be uploaded from its URL and that every addon job comes from one of them
+func RuntimeConfigReleases(contents string) ([]AddonRelease, error) {
+	var rc runtimeConfig
+	if err := yaml.Unmarshal([]byte(contents), &rc); err != nil {
+		return nil, fmt.Errorf("runtime config is not valid YAML: [%v]", err)
+	}
+	if len(rc.Addons) == 0 {
+		return nil, errors.New("runtime config has no addons")
+	}
+
+	releases := map[string]bool{}
+	for _, release := range rc.Releases {
+		if release.Name == "" || release.Version == "" || release.URL == "" || release.SHA1 == "" {
+			return nil, fmt.Errorf("runtime config release `%s` must have a name, version, url and sha1", release.Name)
+		}
+		releases[release.Name] = true
+	}
+	for _, addon := range rc.Addons {
+		if addon.Name == "" || len(addon.Jobs) == 0 {
+			return nil, fmt.Errorf("runtime config addon `%s` must have a name and at least one job", addon.Name)
+		}
+		for _, job := range addon.Jobs {
+			if !releases[job.Release] {
+				return nil, fmt.Errorf("job `%s` of runtime config addon `%s` uses release `%s`, which is not in the runtime config's releases", job.Name, addon.Name, job.Release)
+			}
+		}
+	}
+
+	return rc.Releases, nil
+}
@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+const osConfRuntimeConfig = `
+releases:
+- name: os-conf
+  version: 22.1.2
+  url: https://bosh.io/d/github.com/cloudfoundry/os-conf-release?v=22.1.2
+  sha1: 386293038ae3d00813eaa475b4acf63f8da226ef
+addons:
+- name: banner
+  jobs:
+  - name: login_banner
+    release: os-conf
+    properties:
+      login_banner:
+        text: Authorised use only
+`
+
+func TestRuntimeConfigReleases(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []AddonRelease
+		wantErr  string
+	}{
+		{
+			name:     "os-conf addon",
+			contents: osConfRuntimeConfig,
+			want: []AddonRelease{{
+				Name:    "os-conf",
+				Version: "22.1.2",
+				URL:     "https://bosh.io/d/github.com/cloudfoundry/os-conf-release?v=22.1.2",
+				SHA1:    "386293038ae3d00813eaa475b4acf63f8da226ef",
+			}},
+		},
+		{
+			name:     "no addons",
+			contents: "releases: []\n",
+			wantErr:  "runtime config has no addons",
+		},
+		{
+			name:     "release without a url",
+			contents: "releases:\n- {name: os-conf, version: 22.1.2}\naddons:\n- name: banner\n  jobs: [{name: login_banner, release: os-conf}]\n",
+			wantErr:  "runtime config release `os-conf` must have a name, version, url and sha1",
+		},
+		{
+			name:     "addon without jobs",
+			contents: "addons:\n- name: banner\n",
+			wantErr:  "runtime config addon `banner` must have a name and at least one job",
+		},
+		{
+			name:     "job from an undeclared release",
+			contents: "addons:\n- name: av\n  jobs: [{name: clamav, release: antivirus}]\n",
+			wantErr:  "job `clamav` of runtime config addon `av` uses release `antivirus`, which is not in the runtime config's releases",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RuntimeConfigReleases(tt.contents)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("RuntimeConfigReleases() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RuntimeConfigReleases() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RuntimeConfigReleases() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultSelfUpdateRepository is the GitHub repository that publishes Control-Tower releases
+const DefaultSelfUpdateRepository = "engineerbetter/control-tower"
+
+// SelfUpdate configures the release source and update policy of the self-update pipeline.
+// Releases come from a GitHub repository unless S3Bucket is set, in which case the bucket must
+// hold each release's binary at <version>/control-tower-linux-amd64
+type SelfUpdate struct {
+	// Approval stops each update after a plan job until the self-update job is triggered by hand
+	Approval     bool   `json:"approval"`
+	GitHubAPIURL string `json:"github_api_url"`
+	// GitHubAccessToken is only held for the deploy that stores it in CredHub, and never saved in the config
+	GitHubAccessToken string `json:"-"`
+	// GitHubAccessTokenInCredhub records that the pipeline should read the token from CredHub on later deploys
+	GitHubAccessTokenInCredhub bool `json:"github_access_token_in_credhub"`
+	// Repository is owner/name, and defaults to DefaultSelfUpdateRepository
+	Repository string `json:"repository"`
+	S3Bucket   string `json:"s3_bucket"`
+	S3Region   string `json:"s3_region"`
+	StableOnly bool   `json:"stable_only"`
+	// VersionConstraint limits updates to matching versions, eg: 1.4, ~1.4.2 or ^1
+	VersionConstraint string `json:"version_constraint"`
+}
+
+var repositoryRegexp = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+var versionConstraintRegexp = regexp.MustCompile(`^([~^]?)(0|[1-9][0-9]*)(?:\.(0|[1-9][0-9]*))?(?:\.(0|[1-9][0-9]*))?$`)
+
+// Validate returns an error describing the first setting that the self-update pipeline could not use
+func (s SelfUpdate) Validate() error {
+	if s.Repository != "" && !repositoryRegexp.MatchString(s.Repository) {
+		return fmt.Errorf("self-update repository `%s` must be given as owner/name", s.Repository)
+	}
+	if s.GitHubAPIURL != "" {
+		u, err := url.Parse(s.GitHubAPIURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("self-update GitHub API URL `%s` must be an http or https URL", s.GitHubAPIURL)
+		}
+	}
+	if s.S3Bucket != "" && (s.Repository != "" || s.GitHubAPIURL != "" || s.HasGitHubAccessToken()) {
+		return errors.New("self-update releases can come from an S3 bucket or a GitHub repository, but not both")
+	}
+	if s.S3Region != "" && s.S3Bucket == "" {
+		return errors.New("self-update S3 region requires an S3 bucket")
+	}
+	if _, err := s.VersionPattern(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HasGitHubAccessToken returns true if the pipeline reads releases with a GitHub access token
+func (s SelfUpdate) HasGitHubAccessToken() bool {
+	return s.GitHubAccessToken != "" || s.GitHubAccessTokenInCredhub
+}
+
+// GitHubRepository returns the owner and name of the repository to take releases from
+func (s SelfUpdate) GitHubRepository() (string, string) {
+	repository := s.Repository
+	if repository == "" {
+		repository = DefaultSelfUpdateRepository
+	}
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) != 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// VersionPattern returns a regular expression whose only capture group matches the versions that
+// VersionConstraint and StableOnly permit. `~` allows patch updates and `^` allows minor updates,
+// from the given version upwards. A constraint without an operator matches the given version
+// components exactly and allows any others
+func (s SelfUpdate) VersionPattern() (string, error) {
+	suffix := `(?:-[0-9A-Za-z.-]+)?`
+	if s.StableOnly {
+		suffix = ""
+	}
+	if s.VersionConstraint == "" {
+		return `(\d+\.\d+\.\d+` + suffix + `)`, nil
+	}
+
+	matches := versionConstraintRegexp.FindStringSubmatch(s.VersionConstraint)
+	if matches == nil {
+		return "", fmt.Errorf("self-update version constraint `%s` must be a version such as 1.4, ~1.4.2 or ^1", s.VersionConstraint)
+	}
+	operator, major, minor, patch := matches[1], matches[2], matches[3], matches[4]
+
+	var pattern string
+	switch {
+	case minor == "":
+		pattern = major + `\.\d+\.\d+`
+	case patch == "" && operator == "^":
+		pattern = major + `\.` + atLeast(minor) + `\.\d+`
+	case patch == "":
+		pattern = major + `\.` + minor + `\.\d+`
+	case operator == "~":
+		pattern = major + `\.` + minor + `\.` + atLeast(patch)
+	case operator == "^":
+		nextMinor, _ := strconv.Atoi(minor)
+		pattern = major + `\.(?:` + minor + `\.` + atLeast(patch) + `|` + atLeast(strconv.Itoa(nextMinor+1)) + `\.\d+)`
+	default:
+		pattern = major + `\.` + minor + `\.` + patch
+	}
+	return `(` + pattern + suffix + `)`, nil
+}
+
+// atLeast returns a regular expression matching the decimal numbers no smaller than n
+func atLeast(n string) string {
+	alternatives := []string{fmt.Sprintf(`[1-9]\d{%d,}`, len(n))}
+	for i := 0; i < len(n); i++ {
+		if n[i] == '9' {
+			continue
+		}
+		alternative := n[:i] + "[" + string(n[i]+1) + "-9]"
+		if rest := len(n) - i - 1; rest > 0 {
+			alternative += fmt.Sprintf(`\d{%d}`, rest)
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	alternatives = append(alternatives, n)
+	return `(?:` + strings.Join(alternatives, "|") + `)`
+}
@@ -0,0 +1,183 @@
+package config_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+func TestSelfUpdate_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		selfUpdate SelfUpdate
+		wantErr    string
+	}{
+		{
+			name:       "defaults",
+			selfUpdate: SelfUpdate{},
+		},
+		{
+			name: "GitHub Enterprise fork",
+			selfUpdate: SelfUpdate{
+				Approval:          true,
+				GitHubAPIURL:      "https://github.example.com/api/v3/",
+				GitHubAccessToken: "token",
+				Repository:        "platform/control-tower",
+				StableOnly:        true,
+				VersionConstraint: "~1.4",
+			},
+		},
+		{
+			name:       "S3 mirror",
+			selfUpdate: SelfUpdate{S3Bucket: "releases", S3Region: "eu-west-1"},
+		},
+		{
+			name:       "repository without an owner",
+			selfUpdate: SelfUpdate{Repository: "control-tower"},
+			wantErr:    "self-update repository `control-tower` must be given as owner/name",
+		},
+		{
+			name:       "GitHub API URL without a scheme",
+			selfUpdate: SelfUpdate{GitHubAPIURL: "github.example.com/api/v3"},
+			wantErr:    "self-update GitHub API URL `github.example.com/api/v3` must be an http or https URL",
+		},
+		{
+			name:       "S3 bucket and GitHub repository",
+			selfUpdate: SelfUpdate{Repository: "platform/control-tower", S3Bucket: "releases"},
+			wantErr:    "self-update releases can come from an S3 bucket or a GitHub repository, but not both",
+		},
+		{
+			name:       "S3 region without a bucket",
+			selfUpdate: SelfUpdate{S3Region: "eu-west-1"},
+			wantErr:    "self-update S3 region requires an S3 bucket",
+		},
+		{
+			name:       "range constraint",
+			selfUpdate: SelfUpdate{VersionConstraint: ">=1.4"},
+			wantErr:    "self-update version constraint `>=1.4` must be a version such as 1.4, ~1.4.2 or ^1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.selfUpdate.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelfUpdate_GitHubAccessTokenIsNotSaved(t *testing.T) {
+	saved, err := json.Marshal(SelfUpdate{GitHubAccessToken: "secret-token", GitHubAccessTokenInCredhub: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(saved), "secret-token") {
+		t.Errorf("saved self-update config %s contains the GitHub access token", saved)
+	}
+
+	var loaded SelfUpdate
+	if err = json.Unmarshal(saved, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.HasGitHubAccessToken() {
+		t.Errorf("loaded self-update config %#v does not use the GitHub access token", loaded)
+	}
+}
+
+func TestSelfUpdate_GitHubRepository(t *testing.T) {
+	owner, name := SelfUpdate{}.GitHubRepository()
+	if owner != "engineerbetter" || name != "control-tower" {
+		t.Errorf("GitHubRepository() = %s, %s, want the upstream repository", owner, name)
+	}
+	owner, name = SelfUpdate{Repository: "platform/ct"}.GitHubRepository()
+	if owner != "platform" || name != "ct" {
+		t.Errorf("GitHubRepository() = %s, %s, want platform, ct", owner, name)
+	}
+}
+
+func TestSelfUpdate_VersionPattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		selfUpdate SelfUpdate
+		matches    []string
+		rejects    []string
+	}{
+		{
+			name:       "any version",
+			selfUpdate: SelfUpdate{},
+			matches:    []string{"1.4.2", "10.0.0", "1.5.0-rc.1"},
+			rejects:    []string{"1.4", "latest"},
+		},
+		{
+			name:       "stable versions",
+			selfUpdate: SelfUpdate{StableOnly: true},
+			matches:    []string{"1.4.2"},
+			rejects:    []string{"1.5.0-rc.1"},
+		},
+		{
+			name:       "major version",
+			selfUpdate: SelfUpdate{VersionConstraint: "1"},
+			matches:    []string{"1.0.0", "1.12.3"},
+			rejects:    []string{"2.0.0", "11.0.0"},
+		},
+		{
+			name:       "minor version",
+			selfUpdate: SelfUpdate{VersionConstraint: "1.4"},
+			matches:    []string{"1.4.0", "1.4.12", "1.4.1-rc.1"},
+			rejects:    []string{"1.40.0", "1.5.0", "1.3.9"},
+		},
+		{
+			name:       "exact version",
+			selfUpdate: SelfUpdate{VersionConstraint: "1.4.2"},
+			matches:    []string{"1.4.2"},
+			rejects:    []string{"1.4.3", "1.4.20"},
+		},
+		{
+			name:       "tilde with patch",
+			selfUpdate: SelfUpdate{VersionConstraint: "~1.4.2", StableOnly: true},
+			matches:    []string{"1.4.2", "1.4.9", "1.4.10"},
+			rejects:    []string{"1.4.1", "1.4.0", "1.5.0", "1.4.3-rc.1"},
+		},
+		{
+			name:       "caret with minor",
+			selfUpdate: SelfUpdate{VersionConstraint: "^1.4"},
+			matches:    []string{"1.4.0", "1.9.9", "1.10.0", "1.40.2"},
+			rejects:    []string{"1.3.9", "2.0.0", "0.4.0"},
+		},
+		{
+			name:       "caret with patch",
+			selfUpdate: SelfUpdate{VersionConstraint: "^1.9.18"},
+			matches:    []string{"1.9.18", "1.9.19", "1.9.100", "1.10.0", "1.20.1"},
+			rejects:    []string{"1.9.17", "1.9.9", "1.8.20", "2.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := tt.selfUpdate.VersionPattern()
+			if err != nil {
+				t.Fatalf("VersionPattern() unexpected error = %v", err)
+			}
+			versionRegexp := regexp.MustCompile("^" + pattern + "$")
+			for _, version := range tt.matches {
+				if !versionRegexp.MatchString(version) {
+					t.Errorf("VersionPattern() = %s, want it to match %s", pattern, version)
+				}
+			}
+			for _, version := range tt.rejects {
+				if versionRegexp.MatchString(version) {
+					t.Errorf("VersionPattern() = %s, want it not to match %s", pattern, version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WorkerPool is a named group of workers deployed as its own instance group alongside the default workers
+type WorkerPool struct {
+	Name               string   `json:"name"`
+	Count              int      `json:"count"`
+	Size               string   `json:"size"`
+	VMProvisioningType string   `json:"vm_provisioning_type"`
+	Tags               []string `json:"tags"`
+	Team               string   `json:"team"`
+}
+
+var workerPoolNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ParseWorkerPool parses a worker pool given as name:count:size followed by any of the options
+// spot, on-demand, tag=TAG (which may be repeated) and team=TEAM, separated by colons
+func ParseWorkerPool(s string) (WorkerPool, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 {
+		return WorkerPool{}, fmt.Errorf("`%s` is not in the format `name:count:size[:option]...`", s)
+	}
+
+	pool := WorkerPool{
+		Name:               parts[0],
+		Size:               parts[2],
+		VMProvisioningType: ON_DEMAND,
+	}
+	if !workerPoolNameRegexp.MatchString(pool.Name) {
+		return WorkerPool{}, fmt.Errorf("worker pool name `%s` must start with a letter and contain only lowercase letters, digits and hyphens", pool.Name)
+	}
+	count, err := strconv.Atoi(parts[1])
+	if err != nil || count < 1 {
+		return WorkerPool{}, fmt.Errorf("worker pool `%s` must have a count of at least 1", pool.Name)
+	}
+	pool.Count = count
+
+	for _, option := range parts[3:] {
+		switch {
+		case option == SPOT, option == "preemptible":
+			pool.VMProvisioningType = SPOT
+		case option == ON_DEMAND:
+			pool.VMProvisioningType = ON_DEMAND
+		case strings.HasPrefix(option, "tag=") && option != "tag=":
+			pool.Tags = append(pool.Tags, strings.TrimPrefix(option, "tag="))
+		case strings.HasPrefix(option, "team=") && option != "team=":
+			pool.Team = strings.TrimPrefix(option, "team=")
+		default:
+			return WorkerPool{}, fmt.Errorf("unknown option `%s` for worker pool `%s`. Valid options are: spot, on-demand, tag=TAG, team=TEAM", option, pool.Name)
+		}
+	}
+
+	return pool, nil
+}
+
+// IsSpot returns true if the pool's workers use spot or preemptible instances
+func (p WorkerPool) IsSpot() bool {
+	return p.VMProvisioningType == SPOT
+}
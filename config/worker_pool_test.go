@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/config"
+)
+
+func TestParseWorkerPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    WorkerPool
+		wantErr string
+	}{
+		{
+			name: "defaults to on-demand with no tags or team",
+			spec: "builds:4:2xlarge",
+			want: WorkerPool{Name: "builds", Count: 4, Size: "2xlarge", VMProvisioningType: ON_DEMAND},
+		},
+		{
+			name: "all options",
+			spec: "deploy:1:large:spot:tag=deploy:tag=production:team=ops",
+			want: WorkerPool{Name: "deploy", Count: 1, Size: "large", VMProvisioningType: SPOT, Tags: []string{"deploy", "production"}, Team: "ops"},
+		},
+		{
+			name: "preemptible is an alias for spot",
+			spec: "builds:2:xlarge:preemptible",
+			want: WorkerPool{Name: "builds", Count: 2, Size: "xlarge", VMProvisioningType: SPOT},
+		},
+		{
+			name:    "too few fields",
+			spec:    "builds:2",
+			wantErr: "`builds:2` is not in the format `name:count:size[:option]...`",
+		},
+		{
+			name:    "invalid name",
+			spec:    "Builds:2:xlarge",
+			wantErr: "worker pool name `Builds` must start with a letter and contain only lowercase letters, digits and hyphens",
+		},
+		{
+			name:    "invalid count",
+			spec:    "builds:0:xlarge",
+			wantErr: "worker pool `builds` must have a count of at least 1",
+		},
+		{
+			name:    "unknown option",
+			spec:    "builds:2:xlarge:cheap",
+			wantErr: "unknown option `cheap` for worker pool `builds`. Valid options are: spot, on-demand, tag=TAG, team=TEAM",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkerPool(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ParseWorkerPool() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWorkerPool() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWorkerPool() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
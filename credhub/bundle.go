@@ -0,0 +1,104 @@
+package credhub
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/crypto/scrypt"
+)
+
+// BundleFormats are the permitted encodings for an exported bundle
+var BundleFormats = []string{"yaml", "json"}
+
+const bundleVersion = 1
+
+// encryptedBundle is the on-disk form of an export. Secrets are encrypted with
+// AES-256-GCM using a key derived from the passphrase with scrypt
+type encryptedBundle struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SealBundle encrypts secrets with passphrase and encodes the result as YAML or JSON
+func SealBundle(secrets []Secret, passphrase, format string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("a passphrase is required to encrypt secrets")
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := encryptedBundle{Version: bundleVersion, Salt: make([]byte, 16)}
+	if _, err = io.ReadFull(rand.Reader, bundle.Salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, bundle.Salt)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, bundle.Nonce); err != nil {
+		return nil, err
+	}
+	bundle.Ciphertext = gcm.Seal(nil, bundle.Nonce, plaintext, nil)
+
+	switch format {
+	case "json":
+		return json.MarshalIndent(bundle, "", "  ")
+	case "yaml":
+		return yaml.Marshal(bundle)
+	default:
+		return nil, fmt.Errorf("unknown bundle format: `%s`. Valid formats are: %v", format, BundleFormats)
+	}
+}
+
+// OpenBundle decrypts a bundle written by SealBundle in either format
+func OpenBundle(contents []byte, passphrase string) ([]Secret, error) {
+	var bundle encryptedBundle
+	if err := yaml.Unmarshal(contents, &bundle); err != nil {
+		return nil, fmt.Errorf("error parsing secrets bundle: [%v]", err)
+	}
+	if bundle.Version != bundleVersion {
+		return nil, fmt.Errorf("unsupported secrets bundle version %d", bundle.Version)
+	}
+
+	gcm, err := newGCM(passphrase, bundle.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("secrets bundle is corrupt")
+	}
+	plaintext, err := gcm.Open(nil, bundle.Nonce, bundle.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt secrets bundle, check the passphrase")
+	}
+
+	var secrets []Secret
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("error parsing decrypted secrets: [%v]", err)
+	}
+	return secrets, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
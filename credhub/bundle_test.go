@@ -0,0 +1,63 @@
+package credhub
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	secrets := []Secret{
+		{Name: "/concourse/main/password", Type: "password", Value: "hunter2"},
+		{Name: "/concourse/main/config", Type: "json", Value: map[string]interface{}{"key": "value"}},
+	}
+
+	for _, format := range BundleFormats {
+		t.Run(format, func(t *testing.T) {
+			sealed, err := SealBundle(secrets, "a passphrase", format)
+			if err != nil {
+				t.Fatalf("SealBundle() error = %v", err)
+			}
+
+			got, err := OpenBundle(sealed, "a passphrase")
+			if err != nil {
+				t.Fatalf("OpenBundle() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, secrets) {
+				t.Errorf("OpenBundle() = %v, want %v", got, secrets)
+			}
+
+			if _, err = OpenBundle(sealed, "the wrong passphrase"); err == nil {
+				t.Errorf("OpenBundle() with the wrong passphrase should fail")
+			}
+		})
+	}
+}
+
+func TestSealBundle_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		passphrase  string
+		format      string
+		expectedErr string
+	}{
+		{
+			name:        "passphrase is required",
+			format:      "yaml",
+			expectedErr: "a passphrase is required to encrypt secrets",
+		},
+		{
+			name:        "format must be known",
+			passphrase:  "a passphrase",
+			format:      "toml",
+			expectedErr: "unknown bundle format: `toml`. Valid formats are: [yaml json]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SealBundle(nil, tt.passphrase, tt.format)
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("SealBundle() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package credhub
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/util/proxy"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// AdminClientID is the UAA client Control Tower creates for administering CredHub
+const AdminClientID = "credhub_admin"
+
+// SecretTypes are the CredHub credential types that can be exported and imported, which are all of them
+var SecretTypes = []string{"value", "password", "certificate", "ssh", "rsa", "json", "user"}
+
+// derivedFields are the fields of each credential type that CredHub works out itself when the credential is set
+var derivedFields = map[string]string{
+	"ssh":  "public_key_fingerprint",
+	"user": "password_hash",
+}
+
+// Secret is a single CredHub credential with its type preserved
+type Secret struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// Client talks to the CredHub API directly, authenticating against UAA as the admin client
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// New returns a CredHub client that trusts caCert and authenticates with clientID and clientSecret
+func New(credhubURL, caCert, clientID, clientSecret string) (*Client, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, errors.New("failed to parse CredHub CA certificate")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	transport.Proxy = proxy.Func
+	tlsClient := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+
+	credhubURL = strings.TrimSuffix(credhubURL, "/")
+	authURL, err := authServerURL(tlsClient, credhubURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenConfig := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     authURL + "/oauth/token",
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, tlsClient)
+
+	return &Client{
+		url:        credhubURL,
+		httpClient: tokenConfig.Client(ctx),
+	}, nil
+}
+
+func authServerURL(httpClient *http.Client, credhubURL string) (string, error) {
+	var info struct {
+		AuthServer struct {
+			URL string `json:"url"`
+		} `json:"auth-server"`
+	}
+	resp, err := httpClient.Get(credhubURL + "/info")
+	if err != nil {
+		return "", fmt.Errorf("error reaching CredHub: [%v]", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("error parsing CredHub info: [%v]", err)
+	}
+	if info.AuthServer.URL == "" {
+		return "", errors.New("CredHub did not report an auth server")
+	}
+	return strings.TrimSuffix(info.AuthServer.URL, "/"), nil
+}
+
+// Export returns the current value of every credential under path
+func (client *Client) Export(path string) ([]Secret, error) {
+	var found struct {
+		Credentials []struct {
+			Name string `json:"name"`
+		} `json:"credentials"`
+	}
+	if err := client.do(http.MethodGet, "/api/v1/data?path="+url.QueryEscape(path), nil, &found); err != nil {
+		return nil, err
+	}
+
+	var secrets []Secret
+	for _, credential := range found.Credentials {
+		var current struct {
+			Data []Secret `json:"data"`
+		}
+		if err := client.do(http.MethodGet, "/api/v1/data?current=true&name="+url.QueryEscape(credential.Name), nil, &current); err != nil {
+			return nil, err
+		}
+		if len(current.Data) == 0 {
+			continue
+		}
+		secret := current.Data[0]
+		if !isSecretType(secret.Type) {
+			return nil, fmt.Errorf("secret %s has type %s, which cannot be exported. Supported types are: %v", secret.Name, secret.Type, SecretTypes)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// Import sets every secret in CredHub, overwriting any existing value
+func (client *Client) Import(secrets []Secret) error {
+	for _, secret := range secrets {
+		if !isSecretType(secret.Type) {
+			return fmt.Errorf("secret %s has type %s, which cannot be imported. Supported types are: %v", secret.Name, secret.Type, SecretTypes)
+		}
+		if err := client.do(http.MethodPut, "/api/v1/data", settable(secret), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *Client) do(method, path string, body, result interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, client.url+path, &reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling CredHub: [%v]", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("CredHub returned %s for %s %s: %s", resp.Status, method, path, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// settable drops the fields CredHub derives when a secret is set, as it rejects them on the way in
+func settable(secret Secret) Secret {
+	value, ok := secret.Value.(map[string]interface{})
+	derived, hasDerived := derivedFields[secret.Type]
+	if !ok || !hasDerived {
+		return secret
+	}
+	trimmed := map[string]interface{}{}
+	for k, v := range value {
+		if k != derived {
+			trimmed[k] = v
+		}
+	}
+	secret.Value = trimmed
+	return secret
+}
+
+func isSecretType(secretType string) bool {
+	for _, t := range SecretTypes {
+		if t == secretType {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,7 @@
 package fly
 
 import (
-	"strings"
-
+	"github.com/EngineerBetter/control-tower/config"
 	"github.com/aws/aws-sdk-go/aws/session"
 )
 
@@ -36,23 +35,26 @@ var getCredsFromSession = func() (string, string, error) {
 }
 
 //BuildPipelineParams builds params for AWS control-tower self update pipeline
-func (a AWSPipeline) BuildPipelineParams(deployment, namespace, region, domain, iaas string) (Pipeline, error) {
+func (a AWSPipeline) BuildPipelineParams(deployment, namespace, region, domain, iaas string, autoscale, schedule bool, selfUpdate config.SelfUpdate, maintenanceWindow config.MaintenanceWindow, notifications config.Notifications, artefactMirror string, proxy config.Proxy) (Pipeline, error) {
 	accessKeyID, secretAccessKey, err := a.credsGetter()
 	if err != nil {
 		return nil, err
 	}
 
+	params, err := newPipelineTemplateParams(deployment, namespace, region, domain, iaas, autoscale, schedule, selfUpdate, maintenanceWindow, notifications, artefactMirror, proxy)
+	if err != nil {
+		return nil, err
+	}
+	// A release mirror is read with the same credentials as the deployment
+	if params.S3Bucket != "" {
+		params.S3AccessKeyID = accessKeyID
+		params.S3SecretAccessKey = secretAccessKey
+	}
+
 	return AWSPipeline{
-		PipelineTemplateParams: PipelineTemplateParams{
-			ControlTowerVersion: ControlTowerVersion,
-			Deployment:          strings.TrimPrefix(deployment, "control-tower-"),
-			Domain:              domain,
-			Namespace:           namespace,
-			Region:              region,
-			IaaS:                iaas,
-		},
-		AWSAccessKeyID:     accessKeyID,
-		AWSSecretAccessKey: secretAccessKey,
+		PipelineTemplateParams: params,
+		AWSAccessKeyID:         accessKeyID,
+		AWSSecretAccessKey:     secretAccessKey,
 	}, nil
 }
 
@@ -64,13 +66,20 @@ func (a AWSPipeline) GetConfigTemplate() string {
 
 const awsPipelineTemplate = `
 ---` + selfUpdateResources + `
-jobs:
+jobs:` + planSelfUpdateJob + `
+      AWS_ACCESS_KEY_ID: "{{ .AWSAccessKeyID }}"
+      AWS_REGION: "{{ .Region }}"
+      AWS_SECRET_ACCESS_KEY: "{{ .AWSSecretAccessKey }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + planSelfUpdateTask + `
+          set -eu
+
+          cd control-tower-release` + planSelfUpdateJobEnd + `
 - name: self-update
   serial_groups: [cup]
   serial: true
-  plan:
-  - get: control-tower-release
-    trigger: true
+  plan:` + selfUpdateGet + putBuildMetadata + `
   - task: update
     params:
       AWS_ACCESS_KEY_ID: "{{ .AWSAccessKeyID }}"
@@ -78,7 +87,7 @@ jobs:
       AWS_SECRET_ACCESS_KEY: "{{ .AWSSecretAccessKey }}"
       DEPLOYMENT: "{{ .Deployment }}"
       IAAS: "{{ .IaaS }}"
-      NAMESPACE: "{{ .Namespace }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
       SELF_UPDATE: true
     config:
       platform: linux
@@ -95,17 +104,16 @@ jobs:
         - |
           set -eux
 
-          cd control-tower-release
+          cd control-tower-release` + skipDeployedRelease + checkMaintenanceWindow + `
           chmod +x control-tower-linux-amd64
-          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT` + notifyHooks + `self-update` + notifyHooksEnd + `
 - name: renew-https-cert
   serial_groups: [cup]
   serial: true
   plan:
   - get: control-tower-release
-    version: {tag: {{ .ControlTowerVersion }} }
-  - get: every-day
-    trigger: true
+    version: {{"{"}}{{ .ReleaseVersionKey }}: {{ .ReleaseVersion }} }
+` + dailyTrigger + putBuildMetadata + `
   - task: update
     params:
       AWS_ACCESS_KEY_ID: "{{ .AWSAccessKeyID }}"
@@ -113,7 +121,7 @@ jobs:
       AWS_SECRET_ACCESS_KEY: "{{ .AWSSecretAccessKey }}"
       DEPLOYMENT: "{{ .Deployment }}"
       IAAS: "{{ .IaaS }}"
-      NAMESPACE: "{{ .Namespace }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
       SELF_UPDATE: true
     config:
       platform: linux
@@ -129,9 +137,81 @@ jobs:
         - -c
         - |
           set -euxo pipefail
-          cd control-tower-release
+          cd control-tower-release` + checkMaintenanceWindow + `
           chmod +x control-tower-linux-amd64
 ` + renewCertsDateCheck + `
           echo Certificates expire in $days_until_expiry days, redeploying to renew them
-          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT` + notifyHooks + `renew-https-cert` + notifyHooksEnd + `
+{{- if .Autoscale }}
+- name: autoscale-workers
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {{"{"}}{{ .ReleaseVersionKey }}: {{ .ReleaseVersion }} }
+  - get: every-five-minutes
+    trigger: true
+  - task: autoscale
+    params:
+      AWS_ACCESS_KEY_ID: "{{ .AWSAccessKeyID }}"
+      AWS_REGION: "{{ .Region }}"
+      AWS_SECRET_ACCESS_KEY: "{{ .AWSSecretAccessKey }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: control-tower-release
+      run:
+        path: bash
+        args:
+        - -c
+        - |
+          set -eux
+
+          cd control-tower-release
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 autoscale $DEPLOYMENT
+{{- end }}
+{{- if .Schedule }}
+- name: schedule
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {{"{"}}{{ .ReleaseVersionKey }}: {{ .ReleaseVersion }} }
+  - get: every-five-minutes
+    trigger: true
+  - task: schedule
+    params:
+      AWS_ACCESS_KEY_ID: "{{ .AWSAccessKeyID }}"
+      AWS_REGION: "{{ .Region }}"
+      AWS_SECRET_ACCESS_KEY: "{{ .AWSSecretAccessKey }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: control-tower-release
+      run:
+        path: bash
+        args:
+        - -c
+        - |
+          set -eux
+
+          cd control-tower-release
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 schedule $DEPLOYMENT
+{{- end }}
 `
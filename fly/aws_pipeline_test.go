@@ -1,6 +1,9 @@
 package fly_test
 
 import (
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/config"
 	. "github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/util"
 	. "github.com/onsi/ginkgo"
@@ -114,7 +117,7 @@ jobs:
 
 			pipeline := NewAWSPipeline(fakeCredsGetter)
 
-			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS")
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", false, false, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
 			Expect(err).ToNot(HaveOccurred())
 
 			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
@@ -123,6 +126,278 @@ jobs:
 			actual := string(yamlBytes)
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("Adds an autoscale-workers job when autoscaling", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", true, false, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).To(ContainSubstring(`
+- name: every-five-minutes
+  type: time
+  icon: clock
+  source: {interval: 5m}
+`))
+			Expect(actual).To(ContainSubstring(`
+- name: autoscale-workers
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {tag: COMPILE_TIME_VARIABLE_fly_control_tower_version }
+  - get: every-five-minutes
+    trigger: true
+`))
+			Expect(actual).To(HaveSuffix("          ./control-tower-linux-amd64 autoscale $DEPLOYMENT\n"))
+		})
+
+		It("Only redeploys within the maintenance window", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			window := config.MaintenanceWindow{
+				Days:     []string{"Saturday", "Sunday"},
+				Location: "Europe/London",
+				Start:    "1:00 AM",
+				Stop:     "4:00 AM",
+			}
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", false, false, config.SelfUpdate{}, window, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).ToNot(ContainSubstring("every-day"))
+			Expect(actual).To(ContainSubstring(`
+- name: maintenance-window
+  type: time
+  icon: calendar-clock
+  source:
+    start: "1:00 AM"
+    stop: "4:00 AM"
+    location: Europe/London
+    days:
+    - Saturday
+    - Sunday
+`))
+			Expect(actual).To(ContainSubstring(`
+- name: self-update
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+  - get: maintenance-window
+    trigger: true
+  - task: update
+`))
+			Expect(actual).To(ContainSubstring(`
+          cd control-tower-release
+          release=$(cat tag 2>/dev/null || cat version)
+          deployed="COMPILE_TIME_VARIABLE_fly_control_tower_version"
+          if [ "${release#v}" = "${deployed#v}" ]; then
+            echo Control-Tower COMPILE_TIME_VARIABLE_fly_control_tower_version is already deployed
+            exit 0
+          fi
+          tz="Europe/London"
+          now=$(TZ=$tz date +%s)
+          start=$(TZ=$tz date --date="1:00 AM" +%s)
+          stop=$(TZ=$tz date --date="4:00 AM" +%s)
+          outside=
+          if [ $stop -le $start ]; then
+            [ $now -ge $start ] || [ $now -lt $stop ] || outside=true
+          else
+            [ $now -ge $start ] && [ $now -lt $stop ] || outside=true
+          fi
+          case $(TZ=$tz date +%A) in
+            Saturday|Sunday) ;;
+            *) outside=true ;;
+          esac
+          if [ -n "$outside" ]; then
+            echo "Redeploys only run between 1:00 AM and 4:00 AM $tz on Saturday, Sunday. Trigger this job again within the maintenance window"
+            exit 1
+          fi
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+`))
+			Expect(actual).To(ContainSubstring(`
+          cd control-tower-release
+          tz="Europe/London"
+          now=$(TZ=$tz date +%s)
+          start=$(TZ=$tz date --date="1:00 AM" +%s)
+          stop=$(TZ=$tz date --date="4:00 AM" +%s)
+          outside=
+          if [ $stop -le $start ]; then
+            [ $now -ge $start ] || [ $now -lt $stop ] || outside=true
+          else
+            [ $now -ge $start ] && [ $now -lt $stop ] || outside=true
+          fi
+          case $(TZ=$tz date +%A) in
+            Saturday|Sunday) ;;
+            *) outside=true ;;
+          esac
+          if [ -n "$outside" ]; then
+            echo "Redeploys only run between 1:00 AM and 4:00 AM $tz on Saturday, Sunday. Trigger this job again within the maintenance window"
+            exit 1
+          fi
+          chmod +x control-tower-linux-amd64
+
+          now_seconds=$(date +%s)
+`))
+			Expect(actual).To(ContainSubstring(`
+  - get: control-tower-release
+    version: {tag: COMPILE_TIME_VARIABLE_fly_control_tower_version }
+  - get: maintenance-window
+    trigger: true
+`))
+		})
+
+		It("Checks the maintenance window when a self-update that needs approval or a certificate renewal is triggered", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			window := config.MaintenanceWindow{
+				Days:     []string{"Saturday", "Sunday"},
+				Location: "Europe/London",
+				Start:    "1:00 AM",
+				Stop:     "4:00 AM",
+			}
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", false, false, config.SelfUpdate{Approval: true}, window, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).To(ContainSubstring(`
+- name: self-update
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    passed: [plan-self-update]
+  - get: maintenance-window
+  - task: update
+`))
+			Expect(actual).ToNot(ContainSubstring("is already deployed"))
+			Expect(actual).To(ContainSubstring(`
+          cd control-tower-release
+          tz="Europe/London"
+          now=$(TZ=$tz date +%s)
+          start=$(TZ=$tz date --date="1:00 AM" +%s)
+          stop=$(TZ=$tz date --date="4:00 AM" +%s)
+          outside=
+          if [ $stop -le $start ]; then
+            [ $now -ge $start ] || [ $now -lt $stop ] || outside=true
+          else
+            [ $now -ge $start ] && [ $now -lt $stop ] || outside=true
+          fi
+          case $(TZ=$tz date +%A) in
+            Saturday|Sunday) ;;
+            *) outside=true ;;
+          esac
+          if [ -n "$outside" ]; then
+            echo "Redeploys only run between 1:00 AM and 4:00 AM $tz on Saturday, Sunday. Trigger this job again within the maintenance window"
+            exit 1
+          fi
+          chmod +x control-tower-linux-amd64
+`))
+			Expect(strings.Count(actual, "Trigger this job again within the maintenance window")).To(Equal(2))
+		})
+
+		It("Passes the artefact mirror to every task that runs control-tower", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", true, true, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "https://artefacts.example.com/control-tower", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(strings.Count(actual, `
+      NAMESPACE: "prod"
+      ARTEFACT_MIRROR: "https://artefacts.example.com/control-tower"
+`)).To(Equal(4))
+		})
+
+		It("Passes the saved proxy to every task that runs control-tower", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			proxy := config.Proxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: []string{"10.0.0.0/16", ".internal.example.com"}}
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", true, true, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "", proxy)
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(strings.Count(actual, `
+      NAMESPACE: "prod"
+      HTTPS_PROXY: "http://proxy.example.com:3128"
+      https_proxy: "http://proxy.example.com:3128"
+      NO_PROXY: "10.0.0.0/16,.internal.example.com"
+      no_proxy: "10.0.0.0/16,.internal.example.com"
+`)).To(Equal(4))
+			Expect(actual).ToNot(ContainSubstring("HTTP_PROXY"))
+		})
+
+		It("Reads releases from an S3 mirror with the deployment's credentials", func() {
+			fakeCredsGetter := func() (string, string, error) {
+				return "access-key", "secret-key", nil
+			}
+
+			pipeline := NewAWSPipeline(fakeCredsGetter)
+
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "eu-west-1", "ci.engineerbetter.com", "AWS", false, false, config.SelfUpdate{S3Bucket: "releases", S3Region: "eu-west-2", StableOnly: true}, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).To(ContainSubstring(`
+resources:
+- name: control-tower-release
+  type: s3
+  icon: aws
+  source:
+    bucket: releases
+    regexp: '^(\d+\.\d+\.\d+)/control-tower-linux-amd64$'
+    region_name: eu-west-2
+    access_key_id: "access-key"
+    secret_access_key: "secret-key"
+- name: every-day
+`))
+			Expect(actual).To(ContainSubstring(`
+  - get: control-tower-release
+    version: {path: COMPILE_TIME_VARIABLE_fly_control_tower_version/control-tower-linux-amd64 }
+  - get: every-day
+`))
+		})
 	})
 })
 
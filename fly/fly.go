@@ -7,10 +7,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"os/exec"
-	"runtime"
 	"strings"
 	"time"
 
@@ -27,6 +25,8 @@ var ControlTowerVersion = "COMPILE_TIME_VARIABLE_fly_control_tower_version"
 type IClient interface {
 	CanConnect() (bool, error)
 	SetDefaultPipeline(config config.ConfigView, allowFlyVersionDiscrepancy bool) error
+	SetTeams(teams TeamsConfig) error
+	WorkerLoad() (WorkerLoad, error)
 	Cleanup() error
 }
 
@@ -56,32 +56,18 @@ func New(provider iaas.Provider, creds Credentials, stdout, stderr io.Writer, ve
 		return nil, err
 	}
 
-	fileHandler, err := os.Create(tempDir.Path("fly"))
-	if err != nil {
-		return nil, err
-	}
-	defer fileHandler.Close()
-
-	url, err := getFlyURL(versionFile)
-	if err != nil {
+	var binaries map[string]util.BinaryPaths
+	if err = json.Unmarshal(versionFile, &binaries); err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Get(url)
+	cachedPath, err := util.DownloadFly(binaries)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if _, err := io.Copy(fileHandler, resp.Body); err != nil {
-		return nil, err
-	}
-
-	if err := fileHandler.Sync(); err != nil {
-		return nil, err
-	}
 
-	if err := os.Chmod(fileHandler.Name(), 0700); err != nil {
+	// fly sync replaces the binary it is run from, so the verified copy in the cache is left untouched
+	if err = copyExecutable(cachedPath, tempDir.Path("fly")); err != nil {
 		return nil, err
 	}
 
@@ -192,7 +178,13 @@ func (client *Client) SetDefaultPipeline(config config.ConfigView, allowFlyVersi
 		return err
 	}
 
-	if err := client.run("pause-job", "--job", pipelineName+"/self-update"); err != nil {
+	// Without approval the self-update job applies every new release, so it stays paused until the
+	// user opts in. With approval it only runs when triggered, which a paused job would leave pending
+	selfUpdateJobState := "pause-job"
+	if config.GetSelfUpdate().Approval {
+		selfUpdateJobState = "unpause-job"
+	}
+	if err := client.run(selfUpdateJobState, "--job", pipelineName+"/self-update"); err != nil {
 		return err
 	}
 
@@ -206,7 +198,7 @@ func (client *Client) writePipelineConfig(pipelinePath string, config config.Con
 	}
 	defer fileHandler.Close()
 
-	params, err := client.pipeline.BuildPipelineParams(config.GetDeployment(), config.GetNamespace(), config.GetRegion(), config.GetDomain(), config.GetIAAS())
+	params, err := client.pipeline.BuildPipelineParams(config.GetDeployment(), config.GetNamespace(), config.GetRegion(), config.GetDomain(), config.GetIAAS(), config.IsAutoscaling(), config.IsScheduled() && !config.GetScheduleStop(), config.GetSelfUpdate(), config.GetMaintenanceWindow(), config.GetNotifications(), config.GetArtefactMirror(), config.GetProxy())
 	if err != nil {
 		return err
 	}
@@ -267,18 +259,21 @@ func (client *Client) run(args ...string) error {
 	return cmd.Run()
 }
 
-func getFlyURL(versionFile []byte) (string, error) {
-	var x map[string]map[string]string
-	err := json.Unmarshal(versionFile, &x)
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
 	if err != nil {
-		return "", err
+		return err
 	}
-	switch runtime.GOOS {
-	case "darwin":
-		return x["fly"]["mac"], nil
-	case "linux":
-		return x["fly"]["linux"], nil
-	default:
-		return "", fmt.Errorf("unknown os: `%s`", runtime.GOOS)
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0700)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return err
 	}
+	return out.Sync()
 }
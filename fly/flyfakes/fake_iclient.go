@@ -43,6 +43,29 @@ type FakeIClient struct {
 	setDefaultPipelineReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetTeamsStub        func(fly.TeamsConfig) error
+	setTeamsMutex       sync.RWMutex
+	setTeamsArgsForCall []struct {
+		arg1 fly.TeamsConfig
+	}
+	setTeamsReturns struct {
+		result1 error
+	}
+	setTeamsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WorkerLoadStub        func() (fly.WorkerLoad, error)
+	workerLoadMutex       sync.RWMutex
+	workerLoadArgsForCall []struct {
+	}
+	workerLoadReturns struct {
+		result1 fly.WorkerLoad
+		result2 error
+	}
+	workerLoadReturnsOnCall map[int]struct {
+		result1 fly.WorkerLoad
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -215,6 +238,121 @@ func (fake *FakeIClient) SetDefaultPipelineReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) SetTeams(arg1 fly.TeamsConfig) error {
+	fake.setTeamsMutex.Lock()
+	ret, specificReturn := fake.setTeamsReturnsOnCall[len(fake.setTeamsArgsForCall)]
+	fake.setTeamsArgsForCall = append(fake.setTeamsArgsForCall, struct {
+		arg1 fly.TeamsConfig
+	}{arg1})
+	fake.recordInvocation("SetTeams", []interface{}{arg1})
+	fake.setTeamsMutex.Unlock()
+	if fake.SetTeamsStub != nil {
+		return fake.SetTeamsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setTeamsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) SetTeamsCallCount() int {
+	fake.setTeamsMutex.RLock()
+	defer fake.setTeamsMutex.RUnlock()
+	return len(fake.setTeamsArgsForCall)
+}
+
+func (fake *FakeIClient) SetTeamsCalls(stub func(fly.TeamsConfig) error) {
+	fake.setTeamsMutex.Lock()
+	defer fake.setTeamsMutex.Unlock()
+	fake.SetTeamsStub = stub
+}
+
+func (fake *FakeIClient) SetTeamsArgsForCall(i int) fly.TeamsConfig {
+	fake.setTeamsMutex.RLock()
+	defer fake.setTeamsMutex.RUnlock()
+	argsForCall := fake.setTeamsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) SetTeamsReturns(result1 error) {
+	fake.setTeamsMutex.Lock()
+	defer fake.setTeamsMutex.Unlock()
+	fake.SetTeamsStub = nil
+	fake.setTeamsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) SetTeamsReturnsOnCall(i int, result1 error) {
+	fake.setTeamsMutex.Lock()
+	defer fake.setTeamsMutex.Unlock()
+	fake.SetTeamsStub = nil
+	if fake.setTeamsReturnsOnCall == nil {
+		fake.setTeamsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTeamsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) WorkerLoad() (fly.WorkerLoad, error) {
+	fake.workerLoadMutex.Lock()
+	ret, specificReturn := fake.workerLoadReturnsOnCall[len(fake.workerLoadArgsForCall)]
+	fake.workerLoadArgsForCall = append(fake.workerLoadArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerLoad", []interface{}{})
+	fake.workerLoadMutex.Unlock()
+	if fake.WorkerLoadStub != nil {
+		return fake.WorkerLoadStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.workerLoadReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) WorkerLoadCallCount() int {
+	fake.workerLoadMutex.RLock()
+	defer fake.workerLoadMutex.RUnlock()
+	return len(fake.workerLoadArgsForCall)
+}
+
+func (fake *FakeIClient) WorkerLoadCalls(stub func() (fly.WorkerLoad, error)) {
+	fake.workerLoadMutex.Lock()
+	defer fake.workerLoadMutex.Unlock()
+	fake.WorkerLoadStub = stub
+}
+
+func (fake *FakeIClient) WorkerLoadReturns(result1 fly.WorkerLoad, result2 error) {
+	fake.workerLoadMutex.Lock()
+	defer fake.workerLoadMutex.Unlock()
+	fake.WorkerLoadStub = nil
+	fake.workerLoadReturns = struct {
+		result1 fly.WorkerLoad
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) WorkerLoadReturnsOnCall(i int, result1 fly.WorkerLoad, result2 error) {
+	fake.workerLoadMutex.Lock()
+	defer fake.workerLoadMutex.Unlock()
+	fake.WorkerLoadStub = nil
+	if fake.workerLoadReturnsOnCall == nil {
+		fake.workerLoadReturnsOnCall = make(map[int]struct {
+			result1 fly.WorkerLoad
+			result2 error
+		})
+	}
+	fake.workerLoadReturnsOnCall[i] = struct {
+		result1 fly.WorkerLoad
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -224,6 +362,10 @@ func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	defer fake.cleanupMutex.RUnlock()
 	fake.setDefaultPipelineMutex.RLock()
 	defer fake.setDefaultPipelineMutex.RUnlock()
+	fake.setTeamsMutex.RLock()
+	defer fake.setTeamsMutex.RUnlock()
+	fake.workerLoadMutex.RLock()
+	defer fake.workerLoadMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
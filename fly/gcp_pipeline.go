@@ -2,7 +2,8 @@ package fly
 
 import (
 	"io/ioutil"
-	"strings"
+
+	"github.com/EngineerBetter/control-tower/config"
 )
 
 // GCPPipeline is GCP specific implementation of Pipeline interface
@@ -23,17 +24,15 @@ func NewGCPPipeline(credsPath string) (Pipeline, error) {
 }
 
 //BuildPipelineParams builds params for AWS control-tower self update pipeline
-func (a GCPPipeline) BuildPipelineParams(deployment, namespace, region, domain, iaas string) (Pipeline, error) {
+func (a GCPPipeline) BuildPipelineParams(deployment, namespace, region, domain, iaas string, autoscale, schedule bool, selfUpdate config.SelfUpdate, maintenanceWindow config.MaintenanceWindow, notifications config.Notifications, artefactMirror string, proxy config.Proxy) (Pipeline, error) {
+	params, err := newPipelineTemplateParams(deployment, namespace, region, domain, iaas, autoscale, schedule, selfUpdate, maintenanceWindow, notifications, artefactMirror, proxy)
+	if err != nil {
+		return nil, err
+	}
+
 	return GCPPipeline{
-		PipelineTemplateParams: PipelineTemplateParams{
-			ControlTowerVersion: ControlTowerVersion,
-			Deployment:          strings.TrimPrefix(deployment, "control-tower-"),
-			Domain:              domain,
-			Namespace:           namespace,
-			Region:              region,
-			IaaS:                iaas,
-		},
-		GCPCreds: a.GCPCreds,
+		PipelineTemplateParams: params,
+		GCPCreds:               a.GCPCreds,
 	}, nil
 }
 
@@ -53,20 +52,27 @@ func readFileContents(path string) (string, error) {
 
 const gcpPipelineTemplate = `
 ---` + selfUpdateResources + `
-jobs:
+jobs:` + planSelfUpdateJob + `
+      AWS_REGION: "{{ .Region }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      GCPCreds: '{{ .GCPCreds }}'
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + planSelfUpdateTask + `
+          cd control-tower-release
+          echo "${GCPCreds}" > googlecreds.json
+          export GOOGLE_APPLICATION_CREDENTIALS=$PWD/googlecreds.json
+          set -eu` + planSelfUpdateJobEnd + `
 - name: self-update
   serial_groups: [cup]
   serial: true
-  plan:
-  - get: control-tower-release
-    trigger: true
+  plan:` + selfUpdateGet + putBuildMetadata + `
   - task: update
     params:
       AWS_REGION: "{{ .Region }}"
       DEPLOYMENT: "{{ .Deployment }}"
       GCPCreds: '{{ .GCPCreds }}'
       IAAS: "{{ .IaaS }}"
-      NAMESPACE: "{{ .Namespace }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
       SELF_UPDATE: true
     config:
       platform: linux
@@ -84,24 +90,23 @@ jobs:
           cd control-tower-release
           echo "${GCPCreds}" > googlecreds.json
           export GOOGLE_APPLICATION_CREDENTIALS=$PWD/googlecreds.json
-          set -eux
+          set -eux` + skipDeployedRelease + checkMaintenanceWindow + `
           chmod +x control-tower-linux-amd64
-          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT` + notifyHooks + `self-update` + notifyHooksEnd + `
 - name: renew-https-cert
   serial_groups: [cup]
   serial: true
   plan:
   - get: control-tower-release
-    version: {tag: "{{ .ControlTowerVersion }}" }
-  - get: every-day
-    trigger: true
+    version: {{"{"}}{{ .ReleaseVersionKey }}: "{{ .ReleaseVersion }}" }
+` + dailyTrigger + putBuildMetadata + `
   - task: update
     params:
       AWS_REGION: "{{ .Region }}"
       DEPLOYMENT: "{{ .Deployment }}"
       GCPCreds: '{{ .GCPCreds }}'
       IAAS: "{{ .IaaS }}"
-      NAMESPACE: "{{ .Namespace }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
       SELF_UPDATE: true
     config:
       platform: linux
@@ -119,9 +124,81 @@ jobs:
           echo "${GCPCreds}" > googlecreds.json
           export GOOGLE_APPLICATION_CREDENTIALS=$PWD/googlecreds.json
           set -euxo pipefail
-          cd control-tower-release
+          cd control-tower-release` + checkMaintenanceWindow + `
           chmod +x control-tower-linux-amd64
 ` + renewCertsDateCheck + `
           echo Certificates expire in $days_until_expiry days, redeploying to renew them
-          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT` + notifyHooks + `renew-https-cert` + notifyHooksEnd + `
+{{- if .Autoscale }}
+- name: autoscale-workers
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {{"{"}}{{ .ReleaseVersionKey }}: "{{ .ReleaseVersion }}" }
+  - get: every-five-minutes
+    trigger: true
+  - task: autoscale
+    params:
+      AWS_REGION: "{{ .Region }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      GCPCreds: '{{ .GCPCreds }}'
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: control-tower-release
+      run:
+        path: bash
+        args:
+        - -c
+        - |
+          echo "${GCPCreds}" > googlecreds.json
+          export GOOGLE_APPLICATION_CREDENTIALS=$PWD/googlecreds.json
+          set -eux
+          cd control-tower-release
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 autoscale $DEPLOYMENT
+{{- end }}
+{{- if .Schedule }}
+- name: schedule
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {{"{"}}{{ .ReleaseVersionKey }}: "{{ .ReleaseVersion }}" }
+  - get: every-five-minutes
+    trigger: true
+  - task: schedule
+    params:
+      AWS_REGION: "{{ .Region }}"
+      DEPLOYMENT: "{{ .Deployment }}"
+      GCPCreds: '{{ .GCPCreds }}'
+      IAAS: "{{ .IaaS }}"
+      NAMESPACE: "{{ .Namespace }}"` + controlTowerParams + `
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: control-tower-release
+      run:
+        path: bash
+        args:
+        - -c
+        - |
+          echo "${GCPCreds}" > googlecreds.json
+          export GOOGLE_APPLICATION_CREDENTIALS=$PWD/googlecreds.json
+          set -eux
+          cd control-tower-release
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 schedule $DEPLOYMENT
+{{- end }}
 `
@@ -3,7 +3,9 @@ package fly_test
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/EngineerBetter/control-tower/config"
 	. "github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/util"
 	. "github.com/onsi/ginkgo"
@@ -123,7 +125,7 @@ jobs:
 			pipeline, err := NewGCPPipeline(tempFile.Name())
 			Expect(err).ToNot(HaveOccurred())
 
-			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP")
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP", false, false, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
 			Expect(err).ToNot(HaveOccurred())
 
 			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
@@ -132,5 +134,220 @@ jobs:
 			actual := string(yamlBytes)
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("Adds a schedule job when scheduled", func() {
+			tempFile, err := ioutil.TempFile("", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer os.Remove(tempFile.Name()) // clean up
+
+			_, err = tempFile.Write([]byte("creds-content"))
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, err := NewGCPPipeline(tempFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP", false, true, config.SelfUpdate{}, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).To(ContainSubstring("- name: every-five-minutes\n"))
+			Expect(actual).ToNot(ContainSubstring("- name: autoscale-workers\n"))
+			Expect(actual).To(ContainSubstring(`
+- name: schedule
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    version: {tag: "COMPILE_TIME_VARIABLE_fly_control_tower_version" }
+  - get: every-five-minutes
+    trigger: true
+`))
+			Expect(actual).To(HaveSuffix("          ./control-tower-linux-amd64 schedule $DEPLOYMENT\n"))
+		})
+
+		It("Checks the maintenance window in the self-update and renew-https-cert jobs in either mode", func() {
+			tempFile, err := ioutil.TempFile("", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer os.Remove(tempFile.Name()) // clean up
+
+			_, err = tempFile.Write([]byte("creds-content"))
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, err := NewGCPPipeline(tempFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			window := config.MaintenanceWindow{Start: "1:00 AM", Stop: "4:00 AM"}
+			for _, approval := range []bool{false, true} {
+				params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP", false, false, config.SelfUpdate{Approval: approval}, window, config.Notifications{}, "", config.Proxy{})
+				Expect(err).ToNot(HaveOccurred())
+
+				yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+				Expect(err).ToNot(HaveOccurred())
+
+				actual := string(yamlBytes)
+				Expect(strings.Count(actual, `echo "Redeploys only run between 1:00 AM and 4:00 AM $tz. Trigger this job again within the maintenance window"`)).To(Equal(2))
+				Expect(actual).To(ContainSubstring(`
+          cd control-tower-release
+          tz="UTC"
+`))
+			}
+		})
+
+		It("Notifies the configured targets when jobs fail", func() {
+			tempFile, err := ioutil.TempFile("", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer os.Remove(tempFile.Name()) // clean up
+
+			_, err = tempFile.Write([]byte("creds-content"))
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, err := NewGCPPipeline(tempFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			notifications := config.Notifications{
+				SlackWebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+				SMTPHost:        "smtp.example.com:587",
+				SMTPFrom:        "concourse@example.com",
+				SMTPTo:          []string{"ops@example.com", "oncall@example.com"},
+			}
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP", false, false, config.SelfUpdate{}, config.MaintenanceWindow{}, notifications, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).ToNot(ContainSubstring("on_success:"))
+			Expect(actual).To(ContainSubstring(`
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+  on_failure:
+    task: notify
+    params:
+      DEPLOYMENT: "my-deployment"
+      DOMAIN: "ci.engineerbetter.com"
+      EVENT: failure
+      JOB: self-update
+      OLD_VERSION: "COMPILE_TIME_VARIABLE_fly_control_tower_version"
+      SLACK_WEBHOOK_URL: "https://hooks.slack.com/services/T000/B000/XXXX"
+      SMTP_FROM: "concourse@example.com"
+      SMTP_HOST: "smtp.example.com:587"
+      SMTP_PASSWORD: ""
+      SMTP_TO: "ops@example.com oncall@example.com"
+      SMTP_USERNAME: ""
+    config:
+`))
+			Expect(actual).To(ContainSubstring(`
+  on_error:
+    task: notify
+    params:
+      DEPLOYMENT: "my-deployment"
+      DOMAIN: "ci.engineerbetter.com"
+      EVENT: error
+      JOB: renew-https-cert
+`))
+			Expect(actual).To(ContainSubstring(`
+---
+resource_types:
+- name: metadata
+  type: docker-image
+  source:
+    repository: swce/metadata-resource
+resources:
+`))
+			Expect(actual).To(ContainSubstring(`
+- name: build-metadata
+  type: metadata
+`))
+			Expect(strings.Count(actual, `
+  - put: build-metadata
+  - task: update
+`)).To(Equal(2))
+			Expect(actual).To(ContainSubstring(`
+          if [ -s build-metadata/build_name ]; then
+            url="$url/builds/$(cat build-metadata/build_name)"
+          fi
+`))
+			Expect(actual).To(ContainSubstring(`jq -n --arg text "$text" '{text: $text}' > slack.json`))
+		})
+
+		It("Stops at a plan job when self-updates need approval", func() {
+			tempFile, err := ioutil.TempFile("", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer os.Remove(tempFile.Name()) // clean up
+
+			_, err = tempFile.Write([]byte("creds-content"))
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, err := NewGCPPipeline(tempFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			selfUpdate := config.SelfUpdate{
+				Approval:                   true,
+				GitHubAPIURL:               "https://github.example.com/api/v3/",
+				GitHubAccessTokenInCredhub: true,
+				Repository:                 "platform/control-tower",
+				StableOnly:                 true,
+				VersionConstraint:          "1.4",
+			}
+			params, err := pipeline.BuildPipelineParams("my-deployment", "prod", "europe-west1", "ci.engineerbetter.com", "GCP", false, false, selfUpdate, config.MaintenanceWindow{}, config.Notifications{}, "", config.Proxy{})
+			Expect(err).ToNot(HaveOccurred())
+
+			yamlBytes, err := util.RenderTemplate("self-update pipeline", pipeline.GetConfigTemplate(), params)
+			Expect(err).ToNot(HaveOccurred())
+
+			actual := string(yamlBytes)
+			Expect(actual).To(ContainSubstring(`
+- name: control-tower-release
+  type: github-release
+  icon: github
+  source:
+    user: platform
+    repository: control-tower
+    pre_release: false
+    tag_filter: '^v?(1\.4\.\d+)$'
+    github_api_url: https://github.example.com/api/v3/
+    access_token: ((github_access_token))
+`))
+			Expect(actual).To(ContainSubstring(`
+jobs:
+- name: plan-self-update
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    trigger: true
+  - task: plan
+    params:
+      AWS_REGION: "europe-west1"
+      DEPLOYMENT: "my-deployment"
+      GCPCreds: 'creds-content'
+      IAAS: "GCP"
+      NAMESPACE: "prod"
+      DRY_RUN: true
+      SELF_UPDATE: true
+`))
+			Expect(actual).To(ContainSubstring(`
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          echo
+          echo "Trigger the self-update job to apply these changes"
+`))
+			Expect(actual).To(ContainSubstring(`
+- name: self-update
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    passed: [plan-self-update]
+  - task: update
+`))
+		})
 	})
 })
@@ -1,35 +1,310 @@
 package fly
 
+import (
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
 // Pipeline is interface for self update pipeline
 type Pipeline interface {
-	BuildPipelineParams(deployment, namespace, region, domain, iaas string) (Pipeline, error)
+	BuildPipelineParams(deployment, namespace, region, domain, iaas string, autoscale, schedule bool, selfUpdate config.SelfUpdate, maintenanceWindow config.MaintenanceWindow, notifications config.Notifications, artefactMirror string, proxy config.Proxy) (Pipeline, error)
 	GetConfigTemplate() string
 }
 
 type PipelineTemplateParams struct {
+	Approval            bool
+	ArtefactMirror      string
+	Autoscale           bool
 	ControlTowerVersion string
 	Deployment          string
 	Domain              string
-	Namespace           string
-	Region              string
-	IaaS                string
+	GitHubAPIURL        string
+	// GitHubAccessTokenIsSet reads the token from GitHubAccessTokenCredential, where deploy stores it
+	GitHubAccessTokenIsSet bool
+	GitHubOwner            string
+	GitHubRepository       string
+	MaintenanceWindow      config.MaintenanceWindow
+	Namespace              string
+	Notifications          config.Notifications
+	PreRelease             bool
+	Proxy                  config.Proxy
+	Region                 string
+	// ReleaseVersionKey and ReleaseVersion pin jobs other than self-update to the deployed release
+	ReleaseVersion    string
+	ReleaseVersionKey string
+	S3AccessKeyID     string
+	S3Bucket          string
+	S3Regexp          string
+	S3Region          string
+	S3SecretAccessKey string
+	Schedule          bool
+	IaaS              string
+	// TagFilter restricts GitHub releases to those matching a version constraint
+	TagFilter string
+}
+
+func newPipelineTemplateParams(deployment, namespace, region, domain, iaas string, autoscale, schedule bool, selfUpdate config.SelfUpdate, maintenanceWindow config.MaintenanceWindow, notifications config.Notifications, artefactMirror string, proxy config.Proxy) (PipelineTemplateParams, error) {
+	versionPattern, err := selfUpdate.VersionPattern()
+	if err != nil {
+		return PipelineTemplateParams{}, err
+	}
+
+	params := PipelineTemplateParams{
+		Approval:            selfUpdate.Approval,
+		ArtefactMirror:      artefactMirror,
+		Autoscale:           autoscale,
+		ControlTowerVersion: ControlTowerVersion,
+		Deployment:          strings.TrimPrefix(deployment, "control-tower-"),
+		Domain:              domain,
+		MaintenanceWindow:   maintenanceWindow,
+		Namespace:           namespace,
+		Notifications:       notifications,
+		Proxy:               proxy,
+		Region:              region,
+		Schedule:            schedule,
+		IaaS:                iaas,
+	}
+
+	if selfUpdate.S3Bucket != "" {
+		params.S3Bucket = selfUpdate.S3Bucket
+		params.S3Region = selfUpdate.S3Region
+		params.S3Regexp = "^" + versionPattern + "/" + releaseBinary + "$"
+		params.ReleaseVersionKey = "path"
+		params.ReleaseVersion = ControlTowerVersion + "/" + releaseBinary
+		return params, nil
+	}
+
+	params.GitHubOwner, params.GitHubRepository = selfUpdate.GitHubRepository()
+	params.GitHubAPIURL = selfUpdate.GitHubAPIURL
+	params.GitHubAccessTokenIsSet = selfUpdate.HasGitHubAccessToken()
+	params.PreRelease = !selfUpdate.StableOnly
+	if selfUpdate.VersionConstraint != "" {
+		params.TagFilter = "^v?" + versionPattern + "$"
+	}
+	params.ReleaseVersionKey = "tag"
+	params.ReleaseVersion = ControlTowerVersion
+	return params, nil
 }
 
+// GitHubAccessTokenCredential is the CredHub credential the self-update pipeline reads as ((github_access_token))
+const GitHubAccessTokenCredential = "/concourse/main/control-tower-self-update/github_access_token"
+
+// releaseBinary is the release asset that every job of the pipeline runs
+const releaseBinary = "control-tower-linux-amd64"
+
 const selfUpdateResources = `
+{{- if .Notifications.IsSet }}
+resource_types:
+- name: metadata
+  type: docker-image
+  source:
+    repository: swce/metadata-resource
+{{- end }}
 resources:
 - name: control-tower-release
+{{- if .S3Bucket }}
+  type: s3
+  icon: aws
+  source:
+    bucket: {{ .S3Bucket }}
+    regexp: '{{ .S3Regexp }}'
+{{- if .S3Region }}
+    region_name: {{ .S3Region }}
+{{- end }}
+{{- if .S3AccessKeyID }}
+    access_key_id: "{{ .S3AccessKeyID }}"
+    secret_access_key: "{{ .S3SecretAccessKey }}"
+{{- end }}
+{{- else }}
   type: github-release
   icon: github
   source:
-    user: engineerbetter
-    repository: control-tower
-    pre_release: true
+    user: {{ .GitHubOwner }}
+    repository: {{ .GitHubRepository }}
+    pre_release: {{ .PreRelease }}
+{{- if .TagFilter }}
+    tag_filter: '{{ .TagFilter }}'
+{{- end }}
+{{- if .GitHubAPIURL }}
+    github_api_url: {{ .GitHubAPIURL }}
+{{- end }}
+{{- if .GitHubAccessTokenIsSet }}
+    access_token: ((github_access_token))
+{{- end }}
+{{- end }}
+{{- if .Notifications.IsSet }}
+- name: build-metadata
+  type: metadata
+  icon: information-outline
+{{- end }}
+{{- if .MaintenanceWindow.IsSet }}
+- name: maintenance-window
+  type: time
+  icon: calendar-clock
+  source:
+    start: "{{ .MaintenanceWindow.Start }}"
+    stop: "{{ .MaintenanceWindow.Stop }}"
+{{- if .MaintenanceWindow.Location }}
+    location: {{ .MaintenanceWindow.Location }}
+{{- end }}
+{{- if .MaintenanceWindow.Days }}
+    days:
+{{- range .MaintenanceWindow.Days }}
+    - {{ . }}
+{{- end }}
+{{- end }}
+{{- else }}
 - name: every-day
   type: time
   icon: clock
   source: {interval: 24h}
+{{- end }}
+{{- if or .Autoscale .Schedule }}
+- name: every-five-minutes
+  type: time
+  icon: clock
+  source: {interval: 5m}
+{{- end }}
 `
 
+// planSelfUpdateJob shows what a new release would change, by running its deploy with --dry-run, so that
+// someone can decide whether to trigger the self-update. Each IaaS adds its own params and setup between
+// planSelfUpdateJob, planSelfUpdateTask and planSelfUpdateJobEnd
+const planSelfUpdateJob = `
+{{- if .Approval }}
+- name: plan-self-update
+  serial_groups: [cup]
+  serial: true
+  plan:
+  - get: control-tower-release
+    trigger: true
+  - task: plan
+    params:`
+
+const planSelfUpdateTask = `
+      DRY_RUN: true
+      SELF_UPDATE: true
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: control-tower-release
+      run:
+        path: bash
+        args:
+        - -c
+        - |`
+
+const planSelfUpdateJobEnd = `
+
+          echo "Control-Tower {{ .ControlTowerVersion }} is deployed, and $(cat version) is available"
+          if [ -s body ]; then
+            echo
+            cat body
+          fi
+          echo
+          chmod +x control-tower-linux-amd64
+          ./control-tower-linux-amd64 deploy $DEPLOYMENT
+          echo
+          echo "Trigger the self-update job to apply these changes"
+{{- end }}`
+
+// selfUpdateGet fetches the release to apply. Without approval it triggers on new releases, or daily
+// within the maintenance window if there is one. With approval the window resource is fetched too, so the
+// job shows the window it is limited to
+const selfUpdateGet = `
+  - get: control-tower-release
+{{- if .Approval }}
+    passed: [plan-self-update]
+{{- if .MaintenanceWindow.IsSet }}
+  - get: maintenance-window
+{{- end }}
+{{- else if .MaintenanceWindow.IsSet }}
+  - get: maintenance-window
+    trigger: true
+{{- else }}
+    trigger: true
+{{- end }}`
+
+// skipDeployedRelease ends a self-update that the maintenance window triggered without a new release.
+// Release tags may start with a v, which the version control-tower reports does not
+const skipDeployedRelease = `
+{{- if and .MaintenanceWindow.IsSet (not .Approval) }}
+          release=$(cat tag 2>/dev/null || cat version)
+          deployed="{{ .ControlTowerVersion }}"
+          if [ "${release#v}" = "${deployed#v}" ]; then
+            echo Control-Tower {{ .ControlTowerVersion }} is already deployed
+            exit 0
+          fi
+{{- end }}`
+
+// checkMaintenanceWindow fails a redeploy that was triggered by hand outside the maintenance window.
+// A time resource cannot stop a manual trigger, so the task checks the clock itself
+const checkMaintenanceWindow = `
+{{- with .MaintenanceWindow }}
+{{- if .IsSet }}
+          tz="{{ if .Location }}{{ .Location }}{{ else }}UTC{{ end }}"
+          now=$(TZ=$tz date +%s)
+          start=$(TZ=$tz date --date="{{ .Start }}" +%s)
+          stop=$(TZ=$tz date --date="{{ .Stop }}" +%s)
+          outside=
+          if [ $stop -le $start ]; then
+            [ $now -ge $start ] || [ $now -lt $stop ] || outside=true
+          else
+            [ $now -ge $start ] && [ $now -lt $stop ] || outside=true
+          fi
+{{- if .Days }}
+          case $(TZ=$tz date +%A) in
+            {{ range $i, $day := .Days }}{{ if $i }}|{{ end }}{{ $day }}{{ end }}) ;;
+            *) outside=true ;;
+          esac
+{{- end }}
+          if [ -n "$outside" ]; then
+            echo "Redeploys only run between {{ .Start }} and {{ .Stop }} $tz{{ if .Days }} on {{ range $i, $day := .Days }}{{ if $i }}, {{ end }}{{ $day }}{{ end }}{{ end }}. Trigger this job again within the maintenance window"
+            exit 1
+          fi
+{{- end }}
+{{- end }}`
+
+// putBuildMetadata records the build for the notify task to link to. Tasks cannot see their build's metadata,
+// but the metadata resource is given it as $BUILD_* and writes it to files
+const putBuildMetadata = `
+{{- if .Notifications.IsSet }}
+  - put: build-metadata
+{{- end }}`
+
+// dailyTrigger starts the certificate renewal check once a day, within the maintenance window if there is one
+const dailyTrigger = `  - get: {{ if .MaintenanceWindow.IsSet }}maintenance-window{{ else }}every-day{{ end }}
+    trigger: true`
+
+// controlTowerParams are the task params that every task running control-tower needs, beyond those
+// that identify the deployment, so that it downloads from the same places as the deploy that set it
+const controlTowerParams = `
+{{- if .ArtefactMirror }}
+      ARTEFACT_MIRROR: "{{ .ArtefactMirror }}"
+{{- end }}` + proxyParams
+
+// proxyParams give a task the saved proxy, which control-tower needs before it can load the saved config
+const proxyParams = `
+{{- with $.Proxy }}
+{{- if .HTTPProxy }}
+      HTTP_PROXY: "{{ .HTTPProxy }}"
+      http_proxy: "{{ .HTTPProxy }}"
+{{- end }}
+{{- if .HTTPSProxy }}
+      HTTPS_PROXY: "{{ .HTTPSProxy }}"
+      https_proxy: "{{ .HTTPSProxy }}"
+{{- end }}
+{{- if .NoProxy }}
+      NO_PROXY: "{{ range $i, $host := .NoProxy }}{{ if $i }},{{ end }}{{ $host }}{{ end }}"
+      no_proxy: "{{ range $i, $host := .NoProxy }}{{ if $i }},{{ end }}{{ $host }}{{ end }}"
+{{- end }}
+{{- end }}`
+
 const renewCertsDateCheck = `
           now_seconds=$(date +%s)
           not_after=$(echo | openssl s_client -connect {{.Domain}}:443 2>/dev/null | openssl x509 -noout -enddate)
@@ -42,3 +317,87 @@ const renewCertsDateCheck = `
             exit 0
           fi
 `
+
+// notifyHooks and notifyHooksEnd surround the name of a job, and report its outcome to every notification
+// target. The message links to the build recorded by putBuildMetadata, or to the job if the build failed first
+const notifyHooks = `
+{{- range .Notifications.HookEvents }}
+  on_{{ . }}:
+    task: notify
+    params:
+      DEPLOYMENT: "{{ $.Deployment }}"
+      DOMAIN: "{{ $.Domain }}"
+      EVENT: {{ . }}
+      JOB: `
+
+const notifyHooksEnd = `
+      OLD_VERSION: "{{ $.ControlTowerVersion }}"` + proxyParams + `
+{{- with $.Notifications }}
+{{- if .SlackWebhookURL }}
+      SLACK_WEBHOOK_URL: "{{ .SlackWebhookURL }}"
+{{- end }}
+{{- if .SMTPHost }}
+      SMTP_FROM: "{{ .SMTPFrom }}"
+      SMTP_HOST: "{{ .SMTPHost }}"
+      SMTP_PASSWORD: "{{ .SMTPPassword }}"
+      SMTP_TO: "{{ range $i, $to := .SMTPTo }}{{ if $i }} {{ end }}{{ $to }}{{ end }}"
+      SMTP_USERNAME: "{{ .SMTPUsername }}"
+{{- end }}
+{{- if .WebhookURL }}
+      WEBHOOK_URL: "{{ .WebhookURL }}"
+{{- end }}
+{{- end }}
+    config:
+      platform: linux
+      image_resource:
+        type: docker-image
+        source:
+          repository: engineerbetter/pcf-ops
+      inputs:
+      - name: build-metadata
+        optional: true
+      - name: control-tower-release
+        optional: true
+      run:
+        path: bash
+        args:
+        - -c
+        - |
+          set -e
+
+          case $EVENT in
+            success) outcome=succeeded ;;
+            failure) outcome=failed ;;
+            *) outcome=errored ;;
+          esac
+          new_version=$(cat control-tower-release/tag 2>/dev/null || cat control-tower-release/version 2>/dev/null || echo $OLD_VERSION)
+          url="https://$DOMAIN/teams/main/pipelines/control-tower-self-update/jobs/$JOB"
+          if [ -s build-metadata/build_name ]; then
+            url="$url/builds/$(cat build-metadata/build_name)"
+          fi
+          text="Control-Tower $JOB $outcome on $DEPLOYMENT ($OLD_VERSION to $new_version): $url"
+          echo "$text"
+
+          if [ -n "$WEBHOOK_URL" ]; then
+            jq -n --arg deployment "$DEPLOYMENT" --arg job "$JOB" --arg event "$EVENT" --arg old_version "$OLD_VERSION" \
+              --arg new_version "$new_version" --arg url "$url" --arg text "$text" \
+              '{deployment: $deployment, job: $job, event: $event, old_version: $old_version, new_version: $new_version, url: $url, text: $text}' > webhook.json
+            curl -sS --fail -H "Content-Type: application/json" --data @webhook.json "$WEBHOOK_URL" || echo Failed to notify the webhook
+          fi
+          if [ -n "$SLACK_WEBHOOK_URL" ]; then
+            jq -n --arg text "$text" '{text: $text}' > slack.json
+            curl -sS --fail -H "Content-Type: application/json" --data @slack.json "$SLACK_WEBHOOK_URL" || echo Failed to notify Slack
+          fi
+          if [ -n "$SMTP_HOST" ]; then
+            recipients=()
+            for to in $SMTP_TO; do
+              recipients+=(--mail-rcpt "$to")
+            done
+            credentials=()
+            if [ -n "$SMTP_USERNAME" ]; then
+              credentials=(--user "$SMTP_USERNAME:$SMTP_PASSWORD")
+            fi
+            printf "From: %s\r\nTo: %s\r\nSubject: Control-Tower %s %s on %s\r\n\r\n%s\r\n" "$SMTP_FROM" "${SMTP_TO// /, }" "$JOB" "$outcome" "$DEPLOYMENT" "$text" > email.txt
+            curl -sS --fail --ssl-reqd --url "smtp://$SMTP_HOST" --mail-from "$SMTP_FROM" "${recipients[@]}" "${credentials[@]}" --upload-file email.txt || echo Failed to send the email
+          fi
+{{- end }}`
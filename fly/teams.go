@@ -0,0 +1,186 @@
+package fly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+)
+
+// TeamRoles are the permitted Concourse team roles
+var TeamRoles = []string{"owner", "member", "pipeline-operator", "viewer"}
+
+// TeamsConfig is the declarative set of Concourse teams, other than main, managed by Control Tower
+type TeamsConfig struct {
+	Teams []Team `json:"teams"`
+}
+
+// Team is a Concourse team and the users and groups given each role within it
+type Team struct {
+	Name  string     `json:"name"`
+	Roles []TeamRole `json:"roles"`
+}
+
+// TeamRole grants a role to users and groups from each auth connector,
+// using the same layout as `fly set-team --config`
+type TeamRole struct {
+	Name           string    `json:"name"`
+	Local          *TeamAuth `json:"local,omitempty"`
+	Github         *TeamAuth `json:"github,omitempty"`
+	GitLab         *TeamAuth `json:"gitlab,omitempty"`
+	BitbucketCloud *TeamAuth `json:"bitbucket-cloud,omitempty"`
+	OIDC           *TeamAuth `json:"oidc,omitempty"`
+	LDAP           *TeamAuth `json:"ldap,omitempty"`
+}
+
+// TeamAuth lists the members of a role for a single auth connector
+type TeamAuth struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	Orgs   []string `json:"orgs,omitempty"`
+	Teams  []string `json:"teams,omitempty"`
+}
+
+// ParseTeamsConfig parses and validates a teams YAML file
+func ParseTeamsConfig(contents []byte) (TeamsConfig, error) {
+	var teams TeamsConfig
+
+	j, err := yaml.YAMLToJSON(contents)
+	if err != nil {
+		return TeamsConfig{}, fmt.Errorf("error parsing teams file: [%v]", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(j))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&teams); err != nil {
+		return TeamsConfig{}, fmt.Errorf("error parsing teams file: [%v]", err)
+	}
+
+	return teams, teams.validate()
+}
+
+func (t TeamsConfig) validate() error {
+	seen := map[string]bool{}
+	for _, team := range t.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("every team in the teams file requires a name")
+		}
+		if team.Name == "main" {
+			return fmt.Errorf("the main team is configured with deploy flags and cannot be in the teams file")
+		}
+		if seen[team.Name] {
+			return fmt.Errorf("team `%s` appears more than once in the teams file", team.Name)
+		}
+		seen[team.Name] = true
+
+		if err := team.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t Team) validate() error {
+	if len(t.Roles) == 0 {
+		return fmt.Errorf("team `%s` has no roles", t.Name)
+	}
+
+	seen := map[string]bool{}
+	for _, role := range t.Roles {
+		if !isTeamRole(role.Name) {
+			return fmt.Errorf("unknown role `%s` in team `%s`. Valid roles are: %v", role.Name, t.Name, TeamRoles)
+		}
+		if seen[role.Name] {
+			return fmt.Errorf("role `%s` appears more than once in team `%s`", role.Name, t.Name)
+		}
+		seen[role.Name] = true
+
+		if role.Local == nil && role.Github == nil && role.GitLab == nil && role.BitbucketCloud == nil && role.OIDC == nil && role.LDAP == nil {
+			return fmt.Errorf("role `%s` in team `%s` has no users or groups", role.Name, t.Name)
+		}
+	}
+	return nil
+}
+
+func isTeamRole(name string) bool {
+	for _, role := range TeamRoles {
+		if role == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTeams makes the Concourse teams match the given config, destroying any
+// team other than main that is not in it
+func (client *Client) SetTeams(teams TeamsConfig) error {
+	if err := client.login(); err != nil {
+		return err
+	}
+
+	wanted := map[string]bool{"main": true}
+	for _, team := range teams.Teams {
+		wanted[team.Name] = true
+		if err := client.setTeam(team); err != nil {
+			return err
+		}
+	}
+
+	existing, err := client.teamNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range existing {
+		if wanted[name] {
+			continue
+		}
+		if err := client.run("destroy-team", "--team-name", name, "--non-interactive"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) setTeam(team Team) error {
+	teamPath := client.tempDir.Path(fmt.Sprintf("team-%s.yml", team.Name))
+
+	contents, err := yaml.Marshal(struct {
+		Roles []TeamRole `json:"roles"`
+	}{team.Roles})
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(teamPath, contents, 0600); err != nil {
+		return err
+	}
+	defer os.Remove(teamPath)
+
+	return client.run("set-team", "--team-name", team.Name, "--config", teamPath, "--non-interactive")
+}
+
+func (client *Client) teamNames() ([]string, error) {
+	cmd := client.runFly("--target", client.creds.Target, "teams", "--json")
+	cmd.Stderr = client.stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &teams); err != nil {
+		return nil, fmt.Errorf("error parsing output of fly teams: [%v]", err)
+	}
+
+	var names []string
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	return names, nil
+}
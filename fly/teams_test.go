@@ -0,0 +1,177 @@
+package fly
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/util"
+)
+
+func TestParseTeamsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    string
+		want        TeamsConfig
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "parses teams with roles for several connectors",
+			contents: `
+teams:
+- name: platform
+  roles:
+  - name: owner
+    github:
+      teams: ["EngineerBetter:platform"]
+  - name: viewer
+    oidc:
+      groups: [everyone]
+    local:
+      users: [visitor]
+`,
+			want: TeamsConfig{Teams: []Team{{
+				Name: "platform",
+				Roles: []TeamRole{
+					{Name: "owner", Github: &TeamAuth{Teams: []string{"EngineerBetter:platform"}}},
+					{Name: "viewer", OIDC: &TeamAuth{Groups: []string{"everyone"}}, Local: &TeamAuth{Users: []string{"visitor"}}},
+				},
+			}}},
+		},
+		{
+			name: "rejects unknown roles",
+			contents: `
+teams:
+- name: platform
+  roles:
+  - name: admin
+    github:
+      users: [someone]
+`,
+			wantErr:     true,
+			expectedErr: "unknown role `admin` in team `platform`. Valid roles are: [owner member pipeline-operator viewer]",
+		},
+		{
+			name: "rejects the main team",
+			contents: `
+teams:
+- name: main
+  roles:
+  - name: owner
+    github:
+      users: [someone]
+`,
+			wantErr:     true,
+			expectedErr: "the main team is configured with deploy flags and cannot be in the teams file",
+		},
+		{
+			name: "rejects duplicate teams",
+			contents: `
+teams:
+- name: dev
+  roles:
+  - name: member
+    ldap:
+      groups: [dev]
+- name: dev
+  roles:
+  - name: member
+    ldap:
+      groups: [dev]
+`,
+			wantErr:     true,
+			expectedErr: "team `dev` appears more than once in the teams file",
+		},
+		{
+			name: "rejects roles without members",
+			contents: `
+teams:
+- name: dev
+  roles:
+  - name: member
+`,
+			wantErr:     true,
+			expectedErr: "role `member` in team `dev` has no users or groups",
+		},
+		{
+			name: "rejects unknown connectors",
+			contents: `
+teams:
+- name: dev
+  roles:
+  - name: member
+    gitbucket:
+      users: [someone]
+`,
+			wantErr:     true,
+			expectedErr: "error parsing teams file: [json: unknown field \"gitbucket\"]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTeamsConfig([]byte(tt.contents))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTeamsConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.expectedErr {
+					t.Errorf("ParseTeamsConfig() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTeamsConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_SetTeams(t *testing.T) {
+	tempDir, err := util.NewTempDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tempDir.Cleanup()
+
+	var calls []string
+	execCommand = func(command string, args ...string) *exec.Cmd {
+		calls = append(calls, strings.Join(args, " "))
+		return fakeExecCommand(command, args...)
+	}
+	defer func() { execCommand = exec.Command }()
+	os.Setenv("TEST_HELPER_OUTPUT", `[{"name":"main"},{"name":"platform"},{"name":"retired"}]`)
+	defer os.Unsetenv("TEST_HELPER_OUTPUT")
+
+	client := &Client{
+		tempDir: tempDir,
+		creds:   Credentials{Target: "ct", API: "https://ci.example.com", Username: "admin", Password: "secret"},
+		stdout:  ioutil.Discard,
+		stderr:  ioutil.Discard,
+	}
+	teams := TeamsConfig{Teams: []Team{{
+		Name:  "platform",
+		Roles: []TeamRole{{Name: "owner", Github: &TeamAuth{Teams: []string{"EngineerBetter:platform"}}}},
+	}}}
+
+	if err := client.SetTeams(teams); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"--target ct login --insecure --concourse-url https://ci.example.com --username admin --password secret",
+		"--target ct set-team --team-name platform --config " + tempDir.Path("team-platform.yml") + " --non-interactive",
+		"--target ct teams --json",
+		"--target ct destroy-team --team-name retired --non-interactive",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("SetTeams() ran fly with\n%s\nwant\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+	if _, err := os.Stat(tempDir.Path("team-platform.yml")); !os.IsNotExist(err) {
+		t.Errorf("SetTeams() left the team config behind: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package fly
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// runningBuildsToCount is how many of the most recent builds are checked for ones still running
+const runningBuildsToCount = "500"
+
+// Worker is a Concourse worker as reported by `fly workers --json`
+type Worker struct {
+	Name             string   `json:"name"`
+	State            string   `json:"state"`
+	ActiveContainers int      `json:"active_containers"`
+	ActiveVolumes    int      `json:"active_volumes"`
+	Team             string   `json:"team"`
+	Tags             []string `json:"tags"`
+}
+
+// WorkerLoad is the load across a Concourse's workers
+type WorkerLoad struct {
+	Workers       []Worker
+	RunningBuilds int
+}
+
+// WorkerLoad returns the containers and volumes on each worker, and how many builds are running
+func (client *Client) WorkerLoad() (WorkerLoad, error) {
+	var load WorkerLoad
+	if err := client.login(); err != nil {
+		return load, err
+	}
+
+	if err := client.runJSON(&load.Workers, "workers"); err != nil {
+		return load, err
+	}
+
+	var builds []struct {
+		Status string `json:"status"`
+	}
+	if err := client.runJSON(&builds, "builds", "--all-teams", "--count", runningBuildsToCount); err != nil {
+		return load, err
+	}
+	for _, build := range builds {
+		if build.Status == "started" || build.Status == "pending" {
+			load.RunningBuilds++
+		}
+	}
+
+	return load, nil
+}
+
+func (client *Client) runJSON(v interface{}, args ...string) error {
+	args = append([]string{"--target", client.creds.Target}, args...)
+	cmd := client.runFly(append(args, "--json")...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = client.stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	return json.Unmarshal(stdout.Bytes(), v)
+}
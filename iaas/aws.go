@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/route53"
 )
 
@@ -384,3 +385,52 @@ func (a *AWSProvider) FindLongestMatchingHostedZone(subdomain string) (string, s
 func (a *AWSProvider) CreateDatabases(name, username, password string) error {
 	return fmt.Errorf("Not implemented yet")
 }
+
+// StopDatabase stops the RDS instance with the given identifier, if it is not already stopped
+func (a *AWSProvider) StopDatabase(identifier string) error {
+	rdsClient := rds.New(a.sess)
+	status, err := dbInstanceStatus(rdsClient, identifier)
+	if err != nil {
+		return err
+	}
+	if status == "stopped" || status == "stopping" {
+		return nil
+	}
+	_, err = rdsClient.StopDBInstance(&rds.StopDBInstanceInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+	return err
+}
+
+// StartDatabase starts the RDS instance with the given identifier and waits until it is available
+func (a *AWSProvider) StartDatabase(identifier string) error {
+	rdsClient := rds.New(a.sess)
+	status, err := dbInstanceStatus(rdsClient, identifier)
+	if err != nil {
+		return err
+	}
+	if status == "stopped" {
+		_, err = rdsClient.StartDBInstance(&rds.StartDBInstanceInput{
+			DBInstanceIdentifier: aws.String(identifier),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return rdsClient.WaitUntilDBInstanceAvailable(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+}
+
+func dbInstanceStatus(rdsClient *rds.RDS, identifier string) (string, error) {
+	output, err := rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(output.DBInstances) == 0 {
+		return "", fmt.Errorf("no RDS instance with identifier %s", identifier)
+	}
+	return aws.StringValue(output.DBInstances[0].DBInstanceStatus), nil
+}
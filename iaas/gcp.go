@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net"
@@ -18,6 +19,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	clouddns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/iterator"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 
 	// PostgreSQL driver required at runtime
 	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
@@ -206,6 +208,22 @@ func (g *GCPProvider) WriteFile(bucket, path string, contents []byte) error {
 	return nil
 }
 
+// WriteFileFrom writes the specified object from a reader, streaming it to the bucket
+func (g *GCPProvider) WriteFileFrom(bucket, path string, contents io.Reader) error {
+	wc := g.storage.Bucket(bucket).Object(path).NewWriter(g.ctx)
+
+	if _, err := io.Copy(wc, contents); err != nil {
+		wc.Close()
+		return fmt.Errorf("failed to write %s to bucket: [%s]", path, err)
+	}
+
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("failed to close writer for %s: [%s]", path, err)
+	}
+
+	return nil
+}
+
 func (g *GCPProvider) Region() string {
 	return g.region
 }
@@ -445,3 +463,46 @@ func (g *GCPProvider) CreateDatabases(name, username, password string) error {
 	}
 	return nil
 }
+
+// StopDatabase stops the Cloud SQL instance with the given name
+func (g *GCPProvider) StopDatabase(name string) error {
+	return g.setDatabaseActivationPolicy(name, "NEVER")
+}
+
+// StartDatabase starts the Cloud SQL instance with the given name
+func (g *GCPProvider) StartDatabase(name string) error {
+	return g.setDatabaseActivationPolicy(name, "ALWAYS")
+}
+
+func (g *GCPProvider) setDatabaseActivationPolicy(name, policy string) error {
+	project, err := g.Attr("project")
+	if err != nil {
+		return err
+	}
+	c, err := google.DefaultClient(g.ctx, sqladmin.SqlserviceAdminScope)
+	if err != nil {
+		return err
+	}
+	sqlService, err := sqladmin.New(c)
+	if err != nil {
+		return err
+	}
+
+	op, err := sqlService.Instances.Patch(project, name, &sqladmin.DatabaseInstance{
+		Settings: &sqladmin.Settings{ActivationPolicy: policy},
+	}).Context(g.ctx).Do()
+	if err != nil {
+		return err
+	}
+	for op.Status != "DONE" {
+		time.Sleep(10 * time.Second)
+		op, err = sqlService.Operations.Get(project, op.Name).Context(g.ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return fmt.Errorf("failed to set activation policy of Cloud SQL instance %s to %s: [%s]", name, policy, op.Error.Errors[0].Message)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package iaas
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -59,7 +60,10 @@ type Provider interface {
 	IAAS() Name
 	LoadFile(bucket, path string) ([]byte, error)
 	Region() string
+	StartDatabase(name string) error
+	StopDatabase(name string) error
 	WriteFile(bucket, path string, contents []byte) error
+	WriteFileFrom(bucket, path string, contents io.Reader) error
 	Zone(string, string) string
 	Choose(Choice) interface{}
 }
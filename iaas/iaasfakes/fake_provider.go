@@ -2,6 +2,7 @@
 package iaasfakes
 
 import (
+	"io"
 	"sync"
 
 	"github.com/EngineerBetter/control-tower/iaas"
@@ -223,6 +224,28 @@ type FakeProvider struct {
 	regionReturnsOnCall map[int]struct {
 		result1 string
 	}
+	StartDatabaseStub        func(string) error
+	startDatabaseMutex       sync.RWMutex
+	startDatabaseArgsForCall []struct {
+		arg1 string
+	}
+	startDatabaseReturns struct {
+		result1 error
+	}
+	startDatabaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StopDatabaseStub        func(string) error
+	stopDatabaseMutex       sync.RWMutex
+	stopDatabaseArgsForCall []struct {
+		arg1 string
+	}
+	stopDatabaseReturns struct {
+		result1 error
+	}
+	stopDatabaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteFileStub        func(string, string, []byte) error
 	writeFileMutex       sync.RWMutex
 	writeFileArgsForCall []struct {
@@ -236,6 +259,19 @@ type FakeProvider struct {
 	writeFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteFileFromStub        func(string, string, io.Reader) error
+	writeFileFromMutex       sync.RWMutex
+	writeFileFromArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 io.Reader
+	}
+	writeFileFromReturns struct {
+		result1 error
+	}
+	writeFileFromReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ZoneStub        func(string, string) string
 	zoneMutex       sync.RWMutex
 	zoneArgsForCall []struct {
@@ -1306,6 +1342,126 @@ func (fake *FakeProvider) RegionReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeProvider) StartDatabase(arg1 string) error {
+	fake.startDatabaseMutex.Lock()
+	ret, specificReturn := fake.startDatabaseReturnsOnCall[len(fake.startDatabaseArgsForCall)]
+	fake.startDatabaseArgsForCall = append(fake.startDatabaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StartDatabase", []interface{}{arg1})
+	fake.startDatabaseMutex.Unlock()
+	if fake.StartDatabaseStub != nil {
+		return fake.StartDatabaseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.startDatabaseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) StartDatabaseCallCount() int {
+	fake.startDatabaseMutex.RLock()
+	defer fake.startDatabaseMutex.RUnlock()
+	return len(fake.startDatabaseArgsForCall)
+}
+
+func (fake *FakeProvider) StartDatabaseCalls(stub func(string) error) {
+	fake.startDatabaseMutex.Lock()
+	defer fake.startDatabaseMutex.Unlock()
+	fake.StartDatabaseStub = stub
+}
+
+func (fake *FakeProvider) StartDatabaseArgsForCall(i int) string {
+	fake.startDatabaseMutex.RLock()
+	defer fake.startDatabaseMutex.RUnlock()
+	argsForCall := fake.startDatabaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProvider) StartDatabaseReturns(result1 error) {
+	fake.startDatabaseMutex.Lock()
+	defer fake.startDatabaseMutex.Unlock()
+	fake.StartDatabaseStub = nil
+	fake.startDatabaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) StartDatabaseReturnsOnCall(i int, result1 error) {
+	fake.startDatabaseMutex.Lock()
+	defer fake.startDatabaseMutex.Unlock()
+	fake.StartDatabaseStub = nil
+	if fake.startDatabaseReturnsOnCall == nil {
+		fake.startDatabaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startDatabaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) StopDatabase(arg1 string) error {
+	fake.stopDatabaseMutex.Lock()
+	ret, specificReturn := fake.stopDatabaseReturnsOnCall[len(fake.stopDatabaseArgsForCall)]
+	fake.stopDatabaseArgsForCall = append(fake.stopDatabaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StopDatabase", []interface{}{arg1})
+	fake.stopDatabaseMutex.Unlock()
+	if fake.StopDatabaseStub != nil {
+		return fake.StopDatabaseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.stopDatabaseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) StopDatabaseCallCount() int {
+	fake.stopDatabaseMutex.RLock()
+	defer fake.stopDatabaseMutex.RUnlock()
+	return len(fake.stopDatabaseArgsForCall)
+}
+
+func (fake *FakeProvider) StopDatabaseCalls(stub func(string) error) {
+	fake.stopDatabaseMutex.Lock()
+	defer fake.stopDatabaseMutex.Unlock()
+	fake.StopDatabaseStub = stub
+}
+
+func (fake *FakeProvider) StopDatabaseArgsForCall(i int) string {
+	fake.stopDatabaseMutex.RLock()
+	defer fake.stopDatabaseMutex.RUnlock()
+	argsForCall := fake.stopDatabaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProvider) StopDatabaseReturns(result1 error) {
+	fake.stopDatabaseMutex.Lock()
+	defer fake.stopDatabaseMutex.Unlock()
+	fake.StopDatabaseStub = nil
+	fake.stopDatabaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) StopDatabaseReturnsOnCall(i int, result1 error) {
+	fake.stopDatabaseMutex.Lock()
+	defer fake.stopDatabaseMutex.Unlock()
+	fake.StopDatabaseStub = nil
+	if fake.stopDatabaseReturnsOnCall == nil {
+		fake.stopDatabaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.stopDatabaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeProvider) WriteFile(arg1 string, arg2 string, arg3 []byte) error {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -1373,6 +1529,68 @@ func (fake *FakeProvider) WriteFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeProvider) WriteFileFrom(arg1 string, arg2 string, arg3 io.Reader) error {
+	fake.writeFileFromMutex.Lock()
+	ret, specificReturn := fake.writeFileFromReturnsOnCall[len(fake.writeFileFromArgsForCall)]
+	fake.writeFileFromArgsForCall = append(fake.writeFileFromArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("WriteFileFrom", []interface{}{arg1, arg2, arg3})
+	fake.writeFileFromMutex.Unlock()
+	if fake.WriteFileFromStub != nil {
+		return fake.WriteFileFromStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.writeFileFromReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) WriteFileFromCallCount() int {
+	fake.writeFileFromMutex.RLock()
+	defer fake.writeFileFromMutex.RUnlock()
+	return len(fake.writeFileFromArgsForCall)
+}
+
+func (fake *FakeProvider) WriteFileFromCalls(stub func(string, string, io.Reader) error) {
+	fake.writeFileFromMutex.Lock()
+	defer fake.writeFileFromMutex.Unlock()
+	fake.WriteFileFromStub = stub
+}
+
+func (fake *FakeProvider) WriteFileFromArgsForCall(i int) (string, string, io.Reader) {
+	fake.writeFileFromMutex.RLock()
+	defer fake.writeFileFromMutex.RUnlock()
+	argsForCall := fake.writeFileFromArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeProvider) WriteFileFromReturns(result1 error) {
+	fake.writeFileFromMutex.Lock()
+	defer fake.writeFileFromMutex.Unlock()
+	fake.WriteFileFromStub = nil
+	fake.writeFileFromReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) WriteFileFromReturnsOnCall(i int, result1 error) {
+	fake.writeFileFromMutex.Lock()
+	defer fake.writeFileFromMutex.Unlock()
+	fake.WriteFileFromStub = nil
+	if fake.writeFileFromReturnsOnCall == nil {
+		fake.writeFileFromReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileFromReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeProvider) Zone(arg1 string, arg2 string) string {
 	fake.zoneMutex.Lock()
 	ret, specificReturn := fake.zoneReturnsOnCall[len(fake.zoneArgsForCall)]
@@ -1471,8 +1689,14 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.loadFileMutex.RUnlock()
 	fake.regionMutex.RLock()
 	defer fake.regionMutex.RUnlock()
+	fake.startDatabaseMutex.RLock()
+	defer fake.startDatabaseMutex.RUnlock()
+	fake.stopDatabaseMutex.RLock()
+	defer fake.stopDatabaseMutex.RUnlock()
 	fake.writeFileMutex.RLock()
 	defer fake.writeFileMutex.RUnlock()
+	fake.writeFileFromMutex.RLock()
+	defer fake.writeFileFromMutex.RUnlock()
 	fake.zoneMutex.RLock()
 	defer fake.zoneMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"io"
 	"io/ioutil"
 
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
 const (
@@ -135,6 +137,19 @@ func (client *AWSProvider) WriteFile(bucket, path string, contents []byte) error
 	return err
 }
 
+// WriteFileFrom writes the specified S3 object from a reader, uploading it in parts so that
+// large objects are never held in memory
+func (client *AWSProvider) WriteFileFrom(bucket, path string, contents io.Reader) error {
+	uploader := s3manager.NewUploader(client.sess)
+
+	_, err := uploader.Upload(&s3manager.UploadInput{
+		Bucket: &bucket,
+		Key:    &path,
+		Body:   contents,
+	})
+	return err
+}
+
 // HasFile returns true if the specified S3 object exists
 func (client *AWSProvider) HasFile(bucket, path string) (bool, error) {
 	s3Client := s3.New(client.sess)
@@ -84,6 +84,11 @@ variable "rds2_cidr" {
   default = "{{ .RDS2CIDR }}"
 }
 
+variable "web_count" {
+  type = "string"
+  default = "{{ .WebCount }}"
+}
+
 {{if .HostedZoneID }}
 variable "hosted_zone_id" {
   type = "string"
@@ -180,6 +185,94 @@ resource "aws_iam_user_policy" "bosh" {
 EOF
 }
 
+{{if or (eq .CredentialManager "aws-secretsmanager") (eq .CredentialManager "aws-ssm") }}
+resource "aws_iam_role" "web" {
+  name = "${var.deployment}-{{ .Namespace }}-web"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "ec2.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy" "web" {
+  name = "${var.deployment}-{{ .Namespace }}-web"
+  role = "${aws_iam_role.web.id}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {{if eq .CredentialManager "aws-secretsmanager" }}{
+      "Action": [
+        "secretsmanager:DescribeSecret",
+        "secretsmanager:GetSecretValue"
+      ],
+      "Effect": "Allow",
+      "Resource": "arn:aws:secretsmanager:${var.region}:*:secret:{{ .CredentialPathPrefix }}/*"
+    },
+    {{else}}{
+      "Action": [
+        "ssm:GetParameter",
+        "ssm:GetParameters",
+        "ssm:GetParametersByPath"
+      ],
+      "Effect": "Allow",
+      "Resource": "arn:aws:ssm:${var.region}:*:parameter{{ .CredentialPathPrefix }}/*"
+    },
+    {{end}}{
+      "Action": "kms:Decrypt",
+      "Effect": "Allow",
+      "Resource": "*",
+      "Condition": {
+        "StringEquals": {
+          "kms:ViaService": "{{if eq .CredentialManager "aws-secretsmanager" }}secretsmanager{{else}}ssm{{end}}.${var.region}.amazonaws.com"
+        }
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_instance_profile" "web" {
+  name = "${var.deployment}-{{ .Namespace }}-web"
+  role = "${aws_iam_role.web.name}"
+}
+
+resource "aws_iam_user_policy" "bosh_pass_web_role" {
+  name = "${var.deployment}-${var.region}-bosh-pass-web-role"
+  user = "${aws_iam_user.bosh.name}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "iam:PassRole",
+      "Effect": "Allow",
+      "Resource": "${aws_iam_role.web.arn}"
+    }
+  ]
+}
+EOF
+}
+
+output "web_instance_profile" {
+  value = "${aws_iam_instance_profile.web.name}"
+}
+{{end}}
+
 resource "aws_vpc" "default" {
   cidr_block = "${var.network_cidr}"
 
@@ -270,7 +363,7 @@ resource "aws_route53_record" "concourse" {
   name    = "${var.hosted_zone_record_prefix}"
   ttl     = "60"
   type    = "A"
-  records = ["${aws_eip.atc.public_ip}"]
+  records = ["{{if gt .WebCount 1}}${aws_eip.web_lb.public_ip}{{else}}${aws_eip.atc.public_ip}{{end}}"]
 }
 {{end}}
 
@@ -294,6 +387,68 @@ resource "aws_eip" "atc" {
   }
 }
 
+{{if gt .WebCount 1}}
+// The first web instance keeps the ATC EIP, each additional one gets its own
+resource "aws_eip" "web" {
+  count = "${var.web_count - 1}"
+  vpc = true
+  depends_on = ["aws_internet_gateway.default"]
+
+    tags {
+    Name = "${var.deployment}-web-${count.index + 1}"
+    control-tower-project = "${var.project}"
+  }
+}
+
+resource "aws_eip" "web_lb" {
+  vpc = true
+  depends_on = ["aws_internet_gateway.default"]
+
+    tags {
+    Name = "${var.deployment}-web-lb"
+    control-tower-project = "${var.project}"
+  }
+}
+
+resource "aws_lb" "web" {
+  load_balancer_type = "network"
+
+  subnet_mapping {
+    subnet_id     = "${aws_subnet.public.id}"
+    allocation_id = "${aws_eip.web_lb.id}"
+  }
+
+  tags {
+    Name = "${var.deployment}-web"
+    control-tower-project = "${var.project}"
+    control-tower-component = "concourse"
+  }
+}
+{{range .WebLoadBalancerPorts}}
+resource "aws_lb_target_group" "web_{{.}}" {
+  port     = {{.}}
+  protocol = "TCP"
+  vpc_id   = "${aws_vpc.default.id}"
+
+  tags {
+    Name = "${var.deployment}-web-{{.}}"
+    control-tower-project = "${var.project}"
+    control-tower-component = "concourse"
+  }
+}
+
+resource "aws_lb_listener" "web_{{.}}" {
+  load_balancer_arn = "${aws_lb.web.arn}"
+  port              = {{.}}
+  protocol          = "TCP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = "${aws_lb_target_group.web_{{.}}.arn}"
+  }
+}
+{{end}}
+{{end}}
 resource "aws_eip" "nat" {
   vpc = true
   depends_on = ["aws_internet_gateway.default"]
@@ -500,12 +655,31 @@ resource "aws_security_group" "atc" {
     cidr_blocks = ["${aws_eip.nat.public_ip}/32", "${aws_eip.atc.public_ip}/32", {{ .AllowIPs }}]
   }
 
+{{if not .DisableGrafana}}
   ingress {
     from_port   = 3000
     to_port     = 3000
     protocol    = "tcp"
     cidr_blocks = ["${aws_eip.nat.public_ip}/32", {{ .AllowIPs }}]
   }
+{{end}}
+{{if eq .Metrics "prometheus"}}
+  // concourse prometheus emitter
+  ingress {
+    from_port   = {{ .PrometheusPort }}
+    to_port     = {{ .PrometheusPort }}
+    protocol    = "tcp"
+    cidr_blocks = [{{ .MetricsAllowIPs }}]
+  }
+
+  // node_exporter
+  ingress {
+    from_port   = {{ .NodeExporterPort }}
+    to_port     = {{ .NodeExporterPort }}
+    protocol    = "tcp"
+    cidr_blocks = [{{ .MetricsAllowIPs }}]
+  }
+{{end}}
 
   ingress {
     from_port   = 8844
@@ -527,6 +701,24 @@ resource "aws_security_group" "atc" {
     protocol    = "tcp"
     cidr_blocks = ["${var.private_cidr}"]
   }
+{{if .TSAAllowIPs}}
+  // 2222 == TSA, for external workers
+  ingress {
+    from_port   = 2222
+    to_port     = 2222
+    protocol    = "tcp"
+    cidr_blocks = [{{ .TSAAllowIPs }}]
+  }
+{{end}}
+{{if gt .WebCount 1}}
+  // Load balancer health checks, peer routing between web instances, and web instances calling the load balancer
+  ingress {
+    from_port   = 80
+    to_port     = 8844
+    protocol    = "tcp"
+    cidr_blocks = ["${var.public_cidr}", "${formatlist("%s/32", aws_eip.web.*.public_ip)}"]
+  }
+{{end}}
 }
 
 resource "aws_route_table" "rds" {
@@ -641,6 +833,15 @@ output "vms_security_group_id" {
 output "atc_security_group_id" {
   value = "${aws_security_group.atc.id}"
 }
+{{if gt .WebCount 1}}
+output "web_public_ips" {
+  value = "${join(",", aws_eip.web.*.public_ip)}"
+}
+
+output "web_target_groups" {
+  value = "{{range $i, $port := .WebLoadBalancerPorts}}{{if $i}},{{end}}${aws_lb_target_group.web_{{$port}}.name}{{end}}"
+}
+{{end}}
 
 output "nat_gateway_ip" {
   value = "${aws_nat_gateway.default.public_ip}"
@@ -683,3 +884,7 @@ output "bosh_db_port" {
 output "bosh_db_address" {
   value = "${aws_db_instance.default.address}"
 }
+
+output "db_identifier" {
+  value = "${aws_db_instance.default.id}"
+}
@@ -67,6 +67,11 @@ variable "private_cidr" {
   default = "{{ .PrivateCIDR }}"
 }
 
+variable "web_count" {
+  type = "string"
+  default = "{{ .WebCount }}"
+}
+
 {{if .DNSManagedZoneName }}
 variable "dns_managed_zone_name" {
   type = "string"
@@ -105,7 +110,7 @@ resource "google_dns_record_set" "dns" {
   type    = "A"
   ttl     = 60
 
-  rrdatas = ["${google_compute_address.atc_ip.address}"]
+  rrdatas = ["{{if gt .WebCount 1}}${google_compute_address.web_lb_ip.address}{{else}}${google_compute_address.atc_ip.address}{{end}}"]
 }
 {{end}}
 
@@ -247,10 +252,64 @@ resource "google_compute_firewall" "atc-services" {
   source_ranges = ["${google_compute_address.nat_ip.address}/32", "${google_compute_address.atc_ip.address}/32", {{ .AllowIPs }}]
   allow {
     protocol = "tcp"
-    ports = ["3000", "8844"]
+    ports = [{{if not .DisableGrafana}}"3000", {{end}}"8844"]
+  }
+}
+{{if eq .Metrics "prometheus"}}
+resource "google_compute_firewall" "atc-metrics" {
+  name = "${var.deployment}-atc-metrics"
+  description = "Firewall for scraping Prometheus metrics from concourse atc"
+  network     = "${google_compute_network.default.self_link}"
+  target_tags = ["web"]
+  source_ranges = [{{ .MetricsAllowIPs }}]
+  allow {
+    protocol = "tcp"
+    // concourse prometheus emitter and node_exporter
+    ports = ["{{ .PrometheusPort }}", "{{ .NodeExporterPort }}"]
+  }
+}
+{{end}}
+{{if .TSAAllowIPs}}
+resource "google_compute_firewall" "atc-tsa" {
+  name = "${var.deployment}-atc-tsa"
+  description = "Firewall for external workers registering with concourse atc"
+  network     = "${google_compute_network.default.self_link}"
+  target_tags = ["web"]
+  source_ranges = [{{ .TSAAllowIPs }}]
+  allow {
+    protocol = "tcp"
+    // 2222 == TSA
+    ports = ["2222"]
+  }
+}
+{{end}}
+
+{{if gt .WebCount 1}}
+resource "google_compute_firewall" "web-peers" {
+  name = "${var.deployment}-web-peers"
+  description = "Firewall for peer routing between web instances, and web instances calling the load balancer"
+  network     = "${google_compute_network.default.self_link}"
+  target_tags = ["web"]
+  source_ranges = ["${var.public_cidr}", "${formatlist("%s/32", google_compute_address.web_ip.*.address)}"]
+  allow {
+    protocol = "tcp"
+    ports = ["80-8844"]
   }
 }
 
+resource "google_compute_firewall" "web-health-check" {
+  name = "${var.deployment}-web-health-check"
+  description = "Firewall for the load balancer's health checks of web instances"
+  network     = "${google_compute_network.default.self_link}"
+  target_tags = ["web"]
+  // Legacy health checks, which target pools use, come from these ranges
+  source_ranges = ["35.191.0.0/16", "209.85.152.0/22", "209.85.204.0/22"]
+  allow {
+    protocol = "tcp"
+    ports = ["80"]
+  }
+}
+{{end}}
 resource "google_compute_firewall" "internal" {
   name        = "${var.deployment}-int"
   description = "BOSH CI Internal Traffic"
@@ -301,6 +360,42 @@ resource "google_compute_address" "atc_ip" {
   name = "${var.deployment}-atc-ip"
 }
 
+{{if gt .WebCount 1}}
+// The first web instance keeps the ATC IP, each additional one gets its own
+resource "google_compute_address" "web_ip" {
+  count = "${var.web_count - 1}"
+  name = "${var.deployment}-web-ip-${count.index + 1}"
+}
+
+resource "google_compute_address" "web_lb_ip" {
+  name = "${var.deployment}-web-lb-ip"
+}
+
+// Stops the load balancer sending requests to web instances that are down or being replaced
+resource "google_compute_http_health_check" "web" {
+  name = "${var.deployment}-web"
+  port = 80
+  request_path = "/api/v1/info"
+  check_interval_sec = 5
+  timeout_sec = 5
+  healthy_threshold = 2
+  unhealthy_threshold = 2
+}
+
+resource "google_compute_target_pool" "web" {
+  name = "${var.deployment}-web"
+  region = "${var.region}"
+  health_checks = ["${google_compute_http_health_check.web.name}"]
+}
+
+resource "google_compute_forwarding_rule" "web" {
+  name = "${var.deployment}-web"
+  region = "${var.region}"
+  target = "${google_compute_target_pool.web.self_link}"
+  ip_address = "${google_compute_address.web_lb_ip.address}"
+  ip_protocol = "TCP"
+}
+{{end}}
 resource "google_compute_address" "director" {
   name = "${var.deployment}-director-ip"
 }
@@ -334,7 +429,11 @@ resource "google_sql_database_instance" "director" {
         {
           name = "nat"
           value = "${google_compute_address.nat_ip.address}/32"
-        }
+        }{{range .AdditionalWebIPIndexes}},
+        {
+          name = "web_{{.}}"
+          value = "${google_compute_address.web_ip.{{.}}.address}/32"
+        }{{end}}
       ]
     }
   }
@@ -379,6 +478,15 @@ value = "${google_compute_subnetwork.public.gateway_address}"
 output "atc_public_ip" {
 value = "${google_compute_address.atc_ip.address}"
 }
+{{if gt .WebCount 1}}
+output "web_public_ips" {
+  value = "${join(",", google_compute_address.web_ip.*.address)}"
+}
+
+output "web_target_pool" {
+  value = "${google_compute_target_pool.web.name}"
+}
+{{end}}
 
 output "director_account_creds" {
   value = "${base64decode(google_service_account_key.bosh.private_key)}"
@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/resource/internal/file"
+	"gopkg.in/yaml.v2"
+)
+
+// ConcourseVersion is a release of Concourse that can be deployed instead of the one control-tower-ops pins
+type ConcourseVersion struct {
+	Version   string   `yaml:"version"`
+	URL       string   `yaml:"url"`
+	SHA1      string   `yaml:"sha1"`
+	Stemcells []string `yaml:"stemcells"`
+}
+
+// ConcourseVersions is the compatibility table of Concourse releases that can be chosen with --concourse-version
+var ConcourseVersions = file.MustAsset("assets/concourse-versions.yml")
+
+// DefaultConcourseVersion returns the version of Concourse pinned by a release versions ops file
+func DefaultConcourseVersion(releaseVersions string) string {
+	var ops []struct {
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(releaseVersions), &ops); err != nil {
+		return ""
+	}
+	var version string
+	for _, op := range ops {
+		if op.Path == "/releases/name=concourse/version" {
+			_ = json.Unmarshal(op.Value, &version)
+		}
+	}
+	return version
+}
+
+// FindConcourseVersion returns the entry for version from the compatibility table, checking that it runs on
+// stemcellOS. The default version needs no entry, and is returned without a URL as the pinned release is used
+func FindConcourseVersion(table []byte, defaultVersion, version, stemcellOS string) (ConcourseVersion, error) {
+	if version == defaultVersion {
+		return ConcourseVersion{Version: version}, nil
+	}
+
+	var versions []ConcourseVersion
+	if err := yaml.Unmarshal(table, &versions); err != nil {
+		return ConcourseVersion{}, fmt.Errorf("failed to parse Concourse versions: [%v]", err)
+	}
+
+	supported := []string{defaultVersion}
+	for _, v := range versions {
+		supported = append(supported, v.Version)
+		if v.Version != version {
+			continue
+		}
+		for _, stemcell := range v.Stemcells {
+			if stemcell == stemcellOS {
+				return v, nil
+			}
+		}
+		return ConcourseVersion{}, fmt.Errorf("Concourse %s does not support the %s stemcell. Supported stemcells are: %v", version, stemcellOS, v.Stemcells)
+	}
+	return ConcourseVersion{}, fmt.Errorf("Concourse %s is not supported by this version of control-tower. Supported versions are: %v", version, supported)
+}
@@ -0,0 +1,90 @@
+package resource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/resource"
+)
+
+const testConcourseVersions = `
+- version: 7.4.0
+  url: https://bosh.io/d/github.com/concourse/concourse-bosh-release?v=7.4.0
+  sha1: abc123
+  stemcells: [xenial, bionic]
+`
+
+func TestDefaultConcourseVersion(t *testing.T) {
+	versions := `[{"type": "replace", "path": "/releases/name=concourse/version", "value": "6.7.2"}, {"type": "replace", "path": "/stemcells/alias=xenial/version", "value": "621.94"}]`
+	if got := resource.DefaultConcourseVersion(versions); got != "6.7.2" {
+		t.Errorf("DefaultConcourseVersion() = %v, want 6.7.2", got)
+	}
+}
+
+func TestFindConcourseVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		stemcellOS string
+		wantURL    string
+		wantErr    string
+	}{
+		{
+			name:       "default version",
+			version:    "6.7.2",
+			stemcellOS: "jammy",
+		},
+		{
+			name:       "version in the table",
+			version:    "7.4.0",
+			stemcellOS: "bionic",
+			wantURL:    "https://bosh.io/d/github.com/concourse/concourse-bosh-release?v=7.4.0",
+		},
+		{
+			name:       "unsupported stemcell",
+			version:    "7.4.0",
+			stemcellOS: "jammy",
+			wantErr:    "Concourse 7.4.0 does not support the jammy stemcell. Supported stemcells are: [xenial bionic]",
+		},
+		{
+			name:       "unknown version",
+			version:    "5.0.0",
+			stemcellOS: "xenial",
+			wantErr:    "Concourse 5.0.0 is not supported by this version of control-tower. Supported versions are: [6.7.2 7.4.0]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resource.FindConcourseVersion([]byte(testConcourseVersions), "6.7.2", tt.version, tt.stemcellOS)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("FindConcourseVersion() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindConcourseVersion() unexpected error = %v", err)
+			}
+			if got.Version != tt.version || got.URL != tt.wantURL {
+				t.Errorf("FindConcourseVersion() = %+v, want version %v with URL %v", got, tt.version, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestConcourseVersionsTableParses(t *testing.T) {
+	_, err := resource.FindConcourseVersion(resource.ConcourseVersions, "", "0.0.0", "xenial")
+	if err == nil || strings.HasPrefix(err.Error(), "failed to parse") {
+		t.Errorf("FindConcourseVersion() with the bundled table error = %v, want 0.0.0 to be unsupported", err)
+	}
+}
+
+func TestConcourseVersionsTableSelectsNonDefault(t *testing.T) {
+	got, err := resource.FindConcourseVersion(resource.ConcourseVersions, "6.7.2", "6.6.0", "xenial")
+	if err != nil {
+		t.Fatalf("FindConcourseVersion() with the bundled table unexpected error = %v", err)
+	}
+	if want := "https://bosh.io/d/github.com/concourse/concourse-bosh-release?v=6.6.0"; got.Version != "6.6.0" || got.URL != want {
+		t.Errorf("FindConcourseVersion() = %+v, want version 6.6.0 with URL %v", got, want)
+	}
+}
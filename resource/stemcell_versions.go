@@ -0,0 +1,32 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/resource/internal/file"
+	"gopkg.in/yaml.v2"
+)
+
+// StemcellVersion is the version a stemcell line is pinned to when control-tower-ops does not pin it
+type StemcellVersion struct {
+	OS      string `yaml:"os"`
+	Version string `yaml:"version"`
+}
+
+// StemcellVersions is the table of stemcell lines that can be chosen with --stemcell-os besides xenial
+var StemcellVersions = file.MustAsset("assets/stemcell-versions.yml")
+
+// FindStemcellVersion returns the version that stemcellOS is pinned to in the table
+func FindStemcellVersion(table []byte, stemcellOS string) (string, error) {
+	var versions []StemcellVersion
+	if err := yaml.Unmarshal(table, &versions); err != nil {
+		return "", fmt.Errorf("failed to parse stemcell versions: [%v]", err)
+	}
+
+	for _, v := range versions {
+		if v.OS == stemcellOS {
+			return v.Version, nil
+		}
+	}
+	return "", fmt.Errorf("no version of the %s stemcell is pinned by this version of control-tower", stemcellOS)
+}
@@ -0,0 +1,45 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/resource"
+)
+
+func TestFindStemcellVersion(t *testing.T) {
+	table := `
+- os: bionic
+  version: "1.10"
+`
+	tests := []struct {
+		name       string
+		stemcellOS string
+		want       string
+		wantErr    string
+	}{
+		{name: "pinned line", stemcellOS: "bionic", want: "1.10"},
+		{name: "unpinned line", stemcellOS: "jammy", wantErr: "no version of the jammy stemcell is pinned by this version of control-tower"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resource.FindStemcellVersion([]byte(table), tt.stemcellOS)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("FindStemcellVersion() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("FindStemcellVersion() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStemcellVersionsTablePinsEveryLine(t *testing.T) {
+	for _, stemcellOS := range []string{"bionic", "jammy"} {
+		if _, err := resource.FindStemcellVersion(resource.StemcellVersions, stemcellOS); err != nil {
+			t.Errorf("FindStemcellVersion() with the bundled table error = %v", err)
+		}
+	}
+}
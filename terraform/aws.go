@@ -15,13 +15,20 @@ type AWSInputVars struct {
 	AllowIPs               string
 	AvailabilityZone       string
 	ConfigBucket           string
+	CredentialManager      string
+	CredentialPathPrefix   string
 	Deployment             string
+	DisableGrafana         bool
 	HostedZoneID           string
 	HostedZoneRecordPrefix string
+	Metrics                string
+	MetricsAllowIPs        string
 	Namespace              string
 	NetworkCIDR            string
+	NodeExporterPort       int
 	PrivateCIDR            string
 	Project                string
+	PrometheusPort         int
 	PublicCIDR             string
 	PublicKey              string
 	RDSDefaultDatabaseName string
@@ -33,6 +40,8 @@ type AWSInputVars struct {
 	Region                 string
 	SourceAccessIP         string
 	TFStatePath            string
+	TSAAllowIPs            string
+	WebCount               int
 }
 
 // ConfigureTerraform interpolates terraform contents and returns terraform config
@@ -44,6 +53,18 @@ func (v *AWSInputVars) ConfigureTerraform(terraformContents string) (string, err
 	return string(terraformConfig), err
 }
 
+// WebLoadBalancerPorts returns the ports the web load balancer forwards to the web instances
+func (v *AWSInputVars) WebLoadBalancerPorts() []int {
+	ports := []int{80, 443, 8443, 8844}
+	if !v.DisableGrafana {
+		ports = append(ports, 3000)
+	}
+	if v.TSAAllowIPs != "" {
+		ports = append(ports, 2222)
+	}
+	return ports
+}
+
 // MetadataStringValue is a terraform output string variable
 type MetadataStringValue struct {
 	Value string `json:"value"`
@@ -60,6 +81,7 @@ type AWSOutputs struct {
 	BoshDBPort               MetadataStringValue `json:"bosh_db_port" valid:"required"`
 	BoshSecretAccessKey      MetadataStringValue `json:"bosh_user_secret_access_key" valid:"required"`
 	BoshUserAccessKeyID      MetadataStringValue `json:"bosh_user_access_key_id" valid:"required"`
+	DBIdentifier             MetadataStringValue `json:"db_identifier"`
 	DirectorKeyPair          MetadataStringValue `json:"director_key_pair" valid:"required"`
 	DirectorPublicIP         MetadataStringValue `json:"director_public_ip" valid:"required"`
 	DirectorSecurityGroupID  MetadataStringValue `json:"director_security_group_id" valid:"required"`
@@ -69,6 +91,9 @@ type AWSOutputs struct {
 	SourceAccessIP           MetadataStringValue `json:"source_access_ip"`
 	VMsSecurityGroupID       MetadataStringValue `json:"vms_security_group_id" valid:"required"`
 	VPCID                    MetadataStringValue `json:"vpc_id" valid:"required"`
+	WebInstanceProfile       MetadataStringValue `json:"web_instance_profile"`
+	WebPublicIPs             MetadataStringValue `json:"web_public_ips"`
+	WebTargetGroups          MetadataStringValue `json:"web_target_groups"`
 }
 
 // AssertValid returns an error if the struct contains any missing fields
@@ -3,8 +3,10 @@ package terraform_test
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/EngineerBetter/control-tower/resource"
 	. "github.com/EngineerBetter/control-tower/terraform"
 )
 
@@ -60,6 +62,24 @@ func TestAWSInputVars_ConfigureTerraform(t *testing.T) {
 	}
 }
 
+func TestAWSInputVars_ConfigureTerraformPrometheusPorts(t *testing.T) {
+	v := &AWSInputVars{Metrics: "prometheus", MetricsAllowIPs: `"10.0.0.1/32"`, PrometheusPort: 9391, NodeExporterPort: 9100}
+	got, err := v.ConfigureTerraform(resource.AWSTerraformConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`from_port   = 9391
+    to_port     = 9391`,
+		`from_port   = 9100
+    to_port     = 9100`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("InputVars.ConfigureTerraform() does not open the metrics port with %s", want)
+		}
+	}
+}
+
 func TestAWSMetadata_Get(t *testing.T) {
 	type fields struct {
 		VPCID MetadataStringValue
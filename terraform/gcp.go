@@ -19,16 +19,23 @@ type GCPInputVars struct {
 	DBTier             string
 	DBUsername         string
 	Deployment         string
+	DisableGrafana     bool
 	DNSManagedZoneName string
 	DNSRecordSetPrefix string
 	ExternalIP         string
 	GCPCredentialsJSON string
+	Metrics            string
+	MetricsAllowIPs    string
 	Namespace          string
+	NodeExporterPort   int
 	PrivateCIDR        string
 	Project            string
+	PrometheusPort     int
 	PublicCIDR         string
 	Region             string
 	Tags               string
+	TSAAllowIPs        string
+	WebCount           int
 	Zone               string
 }
 
@@ -41,6 +48,15 @@ func (v *GCPInputVars) ConfigureTerraform(terraformContents string) (string, err
 	return string(terraformConfig), err
 }
 
+// AdditionalWebIPIndexes returns the index of each web instance public IP beyond the first, which uses the ATC IP
+func (v *GCPInputVars) AdditionalWebIPIndexes() []int {
+	var indexes []int
+	for i := 0; i < v.WebCount-1; i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
 // Metadata represents output from terraform on GCP or GCP
 type GCPOutputs struct {
 	ATCPublicIP                 MetadataStringValue `json:"atc_public_ip" valid:"required"`
@@ -56,6 +72,8 @@ type GCPOutputs struct {
 	PublicSubnetworkInternalGw  MetadataStringValue `json:"public_subnetwork_internal_gw" valid:"required"`
 	PublicSubnetworkName        MetadataStringValue `json:"public_subnetwork_name" valid:"required"`
 	SQLServerCert               MetadataStringValue `json:"server_ca_cert" valid:"required"`
+	WebPublicIPs                MetadataStringValue `json:"web_public_ips"`
+	WebTargetPool               MetadataStringValue `json:"web_target_pool"`
 }
 
 // AssertValid returns an error if the struct contains any missing fields
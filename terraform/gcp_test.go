@@ -3,8 +3,10 @@ package terraform_test
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/EngineerBetter/control-tower/resource"
 	. "github.com/EngineerBetter/control-tower/terraform"
 )
 
@@ -66,6 +68,21 @@ func TestGCPInputVars_ConfigureTerraform(t *testing.T) {
 	}
 }
 
+func TestGCPInputVars_ConfigureTerraformPrometheusPorts(t *testing.T) {
+	v := &GCPInputVars{Metrics: "prometheus", MetricsAllowIPs: `"10.0.0.1/32"`, PrometheusPort: 9391, NodeExporterPort: 9100}
+	got, err := v.ConfigureTerraform(resource.GCPTerraformConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`ports = ["9391", "9100"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("InputVars.ConfigureTerraform() does not open the metrics port with %s", want)
+		}
+	}
+}
+
 func TestGCPMetadata_Get(t *testing.T) {
 	type fields struct {
 		Network MetadataStringValue
@@ -1,19 +1,24 @@
 package terraform
 
 import (
+	"archive/zip"
 	"bytes"
 	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"runtime"
 
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/resource"
 	"github.com/EngineerBetter/control-tower/util"
+	"github.com/EngineerBetter/control-tower/util/mirror"
 )
 
 // InputVars exposes ConfigureDirectorManifestCPI
@@ -42,6 +47,36 @@ type CLI struct {
 	execCmd func(string, ...string) *exec.Cmd
 	Path    string
 	iaas    iaas.Name
+	mirror  string
+}
+
+type provider struct {
+	name    string
+	version string
+}
+
+// providers are the releases of the provider each IAAS's config is written for. They are installed
+// from an artefact mirror when one is used, as terraform init cannot resolve versions from a mirror
+var providers = map[iaas.Name]provider{
+	iaas.AWS: {"aws", "1.60.0"},
+	iaas.GCP: {"google", "2.8.0"},
+}
+
+// providerOSes are the platforms the provider is mirrored for, matching the binaries in the versions file
+var providerOSes = []string{"darwin", "linux"}
+
+func providerURL(name iaas.Name, goos string) string {
+	p := providers[name]
+	return fmt.Sprintf("https://releases.hashicorp.com/terraform-provider-%[1]s/%[2]s/terraform-provider-%[1]s_%[2]s_%[3]s_amd64.zip", p.name, p.version, goos)
+}
+
+// ProviderURLs returns the download URLs of the provider for an IAAS on each supported OS
+func ProviderURLs(name iaas.Name) []string {
+	var urls []string
+	for _, goos := range providerOSes {
+		urls = append(urls, providerURL(name, goos))
+	}
+	return urls
 }
 
 //Factory function to return iaas-specific outputs
@@ -82,6 +117,14 @@ func DownloadTerraform(versionFile []byte) Option {
 	}
 }
 
+// ArtefactMirror returns an Option that installs the provider from an artefact mirror
+func ArtefactMirror(mirror string) Option {
+	return func(c *CLI) error {
+		c.mirror = mirror
+		return nil
+	}
+}
+
 // New provides a new CLI
 func New(iaas iaas.Name, ops ...Option) (*CLI, error) {
 	cli := &CLI{
@@ -133,7 +176,16 @@ func (c *CLI) init(config InputVars) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	cmd := c.execCmd(c.Path, "init")
+	args := []string{"init"}
+	if c.mirror != "" {
+		pluginDir := filepath.Join(terraformConfigPath, "plugins")
+		if err = c.installProvider(pluginDir); err != nil {
+			os.RemoveAll(terraformConfigPath)
+			return "", err
+		}
+		args = append(args, "-plugin-dir="+pluginDir)
+	}
+	cmd := c.execCmd(c.Path, args...)
 	cmd.Dir = terraformConfigPath
 	cmd.Stderr = os.Stderr
 	err = cmd.Run()
@@ -144,6 +196,52 @@ func (c *CLI) init(config InputVars) (string, error) {
 	return terraformConfigPath, nil
 }
 
+// installProvider unpacks the provider from the artefact mirror into dir, for terraform init -plugin-dir
+func (c *CLI) installProvider(dir string) error {
+	url, err := mirror.URL(c.mirror, providerURL(c.iaas, runtime.GOOS))
+	if err != nil {
+		return err
+	}
+	resp, err := mirror.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	r, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return fmt.Errorf("failed to unpack terraform provider from %s: [%v]", url, err)
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for _, f := range r.File {
+		if err = unzipExecutable(f, filepath.Join(dir, filepath.Base(f.Name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unzipExecutable(f *zip.File, path string) error {
+	in, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0700)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return err
+}
+
 // Apply runs terraform apply for a given config
 func (c *CLI) Apply(config InputVars) error {
 	terraformConfigPath, err := c.init(config)
@@ -1,12 +1,19 @@
 package terraform_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"github.com/EngineerBetter/control-tower/iaas"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/EngineerBetter/control-tower/internal/fakeexec"
 	"github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/util/mirror"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,3 +104,46 @@ func TestCLI_Destroy(t *testing.T) {
 	err = mockCLIent.Destroy(config)
 	require.NoError(t, err)
 }
+
+func TestCLI_ApplyFromArtefactMirror(t *testing.T) {
+	mirrorDir, err := ioutil.TempDir("", "mirror")
+	require.NoError(t, err)
+	defer os.RemoveAll(mirrorDir)
+
+	var providerURL string
+	for _, u := range terraform.ProviderURLs(iaas.AWS) {
+		if strings.Contains(u, "_"+runtime.GOOS+"_") {
+			providerURL = u
+		}
+	}
+	require.NotEmpty(t, providerURL)
+	providerPath, err := mirror.Path(providerURL)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(mirrorDir, providerPath)), 0700))
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	f, err := w.Create("terraform-provider-aws_v1.60.0_x4")
+	require.NoError(t, err)
+	f.Write([]byte("provider"))
+	require.NoError(t, w.Close())
+	require.NoError(t, ioutil.WriteFile(filepath.Join(mirrorDir, providerPath), buf.Bytes(), 0600))
+
+	e := fakeexec.New(t)
+	defer e.Finish()
+	mockCLIent, err := terraform.New(iaas.AWS, terraform.FakeExec(e.Cmd()), terraform.ArtefactMirror(mirrorDir))
+	require.NoError(t, err)
+
+	e.ExpectFunc(func(t testing.TB, command string, args ...string) {
+		require.Equal(t, "init", args[0])
+		require.True(t, strings.HasPrefix(args[1], "-plugin-dir="))
+		contents, err := ioutil.ReadFile(filepath.Join(strings.TrimPrefix(args[1], "-plugin-dir="), "terraform-provider-aws_v1.60.0_x4"))
+		require.NoError(t, err)
+		require.Equal(t, "provider", string(contents))
+	})
+	e.ExpectFunc(func(t testing.TB, command string, args ...string) {
+		require.Equal(t, "apply", args[0])
+	})
+	err = mockCLIent.Apply(&mockTerraformInputVars{})
+	require.NoError(t, err)
+}
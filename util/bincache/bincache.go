@@ -5,67 +5,130 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/EngineerBetter/control-tower/util/mirror"
 )
 
-// Download a file from url and check with a sha1
-func Download(url string) (string, error) {
-	path, err := os.UserCacheDir()
+// Download a file from url, check it against the hex encoded SHA256 of the file as downloaded,
+// and return the path of the executable it contains. Files are cached by their SHA256, and
+// cache entries that were only partly written or have since changed are downloaded again.
+// When sha256Sum is empty the download is not verified, and is cached by its URL instead
+func Download(url, sha256Sum string) (string, error) {
+	dir, err := os.UserCacheDir()
 	if err != nil {
 		return "", err
 	}
-	path = filepath.Join(path, "control-tower", "bin")
-	err = os.MkdirAll(path, 0700)
+	dir = filepath.Join(dir, "control-tower", "bin")
+	err = os.MkdirAll(dir, 0700)
 	if err != nil {
 		return "", err
 	}
-	path = filepath.Join(path, hash(url))
-	if _, err = os.Stat(path); !os.IsNotExist(err) {
+	key := strings.ToLower(sha256Sum)
+	if key == "" {
+		key = hash(url)
+	}
+	path := filepath.Join(dir, key)
+	if isCached(path) {
 		return path, nil
 	}
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0700)
+
+	tmp, err := ioutil.TempFile(dir, "download-")
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
-	resp, err := http.Get(url)
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err = fetch(url, sha256Sum, tmp); err != nil {
+		return "", err
+	}
+	if err = tmp.Chmod(0700); err != nil {
+		return "", err
+	}
+	sum, err := fileSum(tmp.Name())
 	if err != nil {
-		os.Remove(path)
 		return "", err
 	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	// The checksum of the executable is written last, so an entry without one is incomplete
+	if err = ioutil.WriteFile(path+".sha256", []byte(sum), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// fetch writes the executable at url to f, once the download has matched sha256Sum
+func fetch(url, sha256Sum string, f *os.File) error {
+	resp, err := mirror.Get(url)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
-	var closer io.ReadCloser
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	if actual := hex.EncodeToString(sum[:]); sha256Sum != "" && !strings.EqualFold(actual, sha256Sum) {
+		return fmt.Errorf("SHA256 of %s is %s, but %s is pinned", url, actual, sha256Sum)
+	}
 
+	var r io.Reader = bytes.NewReader(body)
 	if isZip(url, resp) {
-		closer, err = handleZipFile(resp)
+		closer, err := handleZipFile(body)
 		if err != nil {
-			return "", nil
+			return err
 		}
-	} else {
-		closer = resp.Body
+		defer closer.Close()
+		r = closer
 	}
-	_, err = io.Copy(f, closer)
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func isCached(path string) bool {
+	expected, err := ioutil.ReadFile(path + ".sha256")
+	if err != nil {
+		return false
+	}
+	actual, err := fileSum(path)
+	if err != nil {
+		return false
+	}
+	return actual == string(expected)
+}
+
+func fileSum(path string) (string, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		os.Remove(path)
 		return "", err
 	}
-	return path, nil
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-func handleZipFile(resp *http.Response) (io.ReadCloser, error) {
-	body, errz := ioutil.ReadAll(resp.Body)
+func handleZipFile(body []byte) (io.ReadCloser, error) {
+	r, errz := zip.NewReader(bytes.NewReader(body), int64(len(body)))
 	if errz != nil {
 		return nil, errz
 	}
-	r, errz := zip.NewReader(bytes.NewReader(body), resp.ContentLength)
-	if errz != nil {
-		return nil, errz
+	if len(r.File) == 0 {
+		return nil, errors.New("zip file is empty")
 	}
 	firstFile, errz := r.File[0].Open()
 	if errz != nil {
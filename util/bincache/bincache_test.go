@@ -3,6 +3,8 @@ package bincache_test
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -16,13 +18,19 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func sum(b []byte) string {
+	s := sha256.Sum256(b)
+	return hex.EncodeToString(s[:])
+}
+
 func TestDownload(t *testing.T) {
+	script := "#!/bin/bash\necho hi"
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		io.WriteString(w, "#!/bin/bash\necho hi")
+		io.WriteString(w, script)
 	}))
 
 	defer s.Close()
-	path, err := bincache.Download(s.URL)
+	path, err := bincache.Download(s.URL, sum([]byte(script)))
 	require.NoError(t, err)
 	defer os.Remove(path)
 	out, err := exec.Command(path).Output()
@@ -31,11 +39,77 @@ func TestDownload(t *testing.T) {
 
 	// check download does not happen if file already exists
 	s.Close()
-	path1, err := bincache.Download(s.URL)
+	path1, err := bincache.Download(s.URL, sum([]byte(script)))
+	require.NoError(t, err)
+	require.Equal(t, path, path1)
+}
+
+// check that nothing is cached when the download does not match the pinned SHA256
+func TestDownloadChecksumMismatch(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "#!/bin/bash\necho tampered")
+	}))
+	defer s.Close()
+
+	pinned := sum([]byte("#!/bin/bash\necho genuine"))
+	_, err := bincache.Download(s.URL, pinned)
+	require.EqualError(t, err, fmt.Sprintf("SHA256 of %s is %s, but %s is pinned", s.URL, sum([]byte("#!/bin/bash\necho tampered")), pinned))
+}
+
+// check that downloads with no pinned SHA256 are not verified, and are cached apart from pinned ones
+func TestDownloadUnpinned(t *testing.T) {
+	script := "#!/bin/bash\necho unpinned"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, script)
+	}))
+
+	path, err := bincache.Download(s.URL, "")
+	require.NoError(t, err)
+	defer os.Remove(path)
+	require.NotContains(t, path, sum([]byte(script)))
+	out, err := exec.Command(path).Output()
+	require.NoError(t, err)
+	require.Equal(t, "unpinned\n", string(out))
+
+	s.Close()
+	path1, err := bincache.Download(s.URL, "")
 	require.NoError(t, err)
 	require.Equal(t, path, path1)
 }
 
+// check that cache entries that were corrupted or only partly written are downloaded again
+func TestDownloadRepairsCache(t *testing.T) {
+	script := "#!/bin/bash\necho repaired"
+	downloads := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		io.WriteString(w, script)
+	}))
+	defer s.Close()
+
+	path, err := bincache.Download(s.URL, sum([]byte(script)))
+	require.NoError(t, err)
+	defer os.Remove(path)
+	defer os.Remove(path + ".sha256")
+	require.Equal(t, 1, downloads)
+
+	err = ioutil.WriteFile(path, []byte("#!/bin/bash\necho corrupted"), 0700)
+	require.NoError(t, err)
+	_, err = bincache.Download(s.URL, sum([]byte(script)))
+	require.NoError(t, err)
+	require.Equal(t, 2, downloads)
+
+	err = os.Remove(path + ".sha256")
+	require.NoError(t, err)
+	_, err = bincache.Download(s.URL, sum([]byte(script)))
+	require.NoError(t, err)
+	require.Equal(t, 3, downloads)
+
+	out, err := exec.Command(path).Output()
+	require.NoError(t, err)
+	require.Equal(t, "repaired\n", string(out))
+}
+
 // check that download handles zip files
 func TestDownloadZip(t *testing.T) {
 	file, _ := ioutil.TempFile("", "*fAKETerraformBinary.sh")
@@ -57,7 +131,7 @@ func TestDownloadZip(t *testing.T) {
 		w.Write(buf.Bytes())
 	}))
 
-	path, err := bincache.Download(s.URL)
+	path, err := bincache.Download(s.URL, sum(buf.Bytes()))
 	require.NoError(t, err)
 	defer os.Remove(path)
 	out, err := exec.Command(path).Output()
@@ -65,3 +139,18 @@ func TestDownloadZip(t *testing.T) {
 	require.Equal(t, "HELLO\n", string(out))
 	s.Close()
 }
+
+// check that download refuses a zip file with nothing in it
+func TestDownloadEmptyZip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zip.NewWriter(buf).Close()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(buf.Bytes())
+	}))
+	defer s.Close()
+
+	_, err := bincache.Download(s.URL, sum(buf.Bytes()))
+	require.EqualError(t, err, "zip file is empty")
+}
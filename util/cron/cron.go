@@ -0,0 +1,174 @@
+// Package cron parses standard five field cron expressions
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// Days match if either the day of month or the day of week matches when both are restricted
+	domStar bool
+	dowStar bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as Sunday as well as 0
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a cron expression with minute, hour, day of month, month and day of week fields
+func Parse(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("cron expression `%s` must have 5 fields: minute hour day-of-month month day-of-week", expr)
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return Schedule{}, err
+	}
+	if s.dayOfMonth, err = dayOfMonthField.parse(fields[2]); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return Schedule{}, err
+	}
+	if s.dayOfWeek, err = dayOfWeekField.parse(fields[4]); err != nil {
+		return Schedule{}, err
+	}
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+	return s, nil
+}
+
+func (f field) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s field `%s`", f.name, expr)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeExpr == "*":
+			low, high = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid %s field `%s`: [%v]", f.name, expr, err)
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid %s field `%s`: [%v]", f.name, expr, err)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeExpr); err != nil {
+				return 0, fmt.Errorf("invalid %s field `%s`: [%v]", f.name, expr, err)
+			}
+			high = low
+			if step > 1 {
+				high = f.max
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid %s field `%s`: range start is after its end", f.name, expr)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("`%s` is not a number", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is not between %d and %d", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Previous returns the latest time at or before t that matches the schedule, in t's location.
+// It returns false if the schedule has not matched in the year before t
+func (s Schedule) Previous(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i <= 366; i++ {
+		if s.matchesDay(day) {
+			for hour := 23; hour >= 0; hour-- {
+				if s.hour&(1<<uint(hour)) == 0 {
+					continue
+				}
+				for minute := 59; minute >= 0; minute-- {
+					if s.minute&(1<<uint(minute)) == 0 {
+						continue
+					}
+					candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, t.Location())
+					// Days of daylight saving changes can skip or repeat hours
+					if candidate.Hour() != hour || candidate.After(t) {
+						continue
+					}
+					return candidate, true
+				}
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}, false
+}
+
+func (s Schedule) matchesDay(day time.Time) bool {
+	if s.month&(1<<uint(day.Month())) == 0 {
+		return false
+	}
+	dom := s.dayOfMonth&(1<<uint(day.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(day.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,98 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/util/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "0 20 * *", wantErr: "cron expression `0 20 * *` must have 5 fields: minute hour day-of-month month day-of-week"},
+		{expr: "60 20 * * *", wantErr: "invalid minute field `60`: [60 is not between 0 and 59]"},
+		{expr: "0 20 * * fri-mon", wantErr: "invalid day of week field `fri-mon`: range start is after its end"},
+		{expr: "*/0 20 * * *", wantErr: "invalid step in minute field `*/0`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := cron.Parse(tt.expr)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSchedule_Previous(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		expr string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "earlier the same day",
+			expr: "0 20 * * 1-5",
+			now:  time.Date(2020, 3, 4, 21, 15, 0, 0, time.UTC),
+			want: time.Date(2020, 3, 4, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "matching minute",
+			expr: "30 7 * * *",
+			now:  time.Date(2020, 3, 4, 7, 30, 59, 0, time.UTC),
+			want: time.Date(2020, 3, 4, 7, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "friday evening seen from the weekend",
+			expr: "0 20 * * mon-fri",
+			now:  time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 3, 6, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 6 * * 7",
+			now:  time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 3, 8, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 1 * mon",
+			now:  time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "steps and lists",
+			expr: "*/15 8,18 * * *",
+			now:  time.Date(2020, 3, 4, 18, 44, 0, 0, time.UTC),
+			want: time.Date(2020, 3, 4, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "in the location of now",
+			expr: "0 20 * * *",
+			now:  time.Date(2020, 7, 1, 19, 30, 0, 0, time.UTC).In(london),
+			want: time.Date(2020, 7, 1, 20, 0, 0, 0, london),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := cron.Parse(tt.expr)
+			require.NoError(t, err)
+			got, ok := s.Previous(tt.now)
+			require.True(t, ok)
+			require.True(t, tt.want.Equal(got), "Previous() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestSchedule_PreviousNeverMatched(t *testing.T) {
+	s, err := cron.Parse("0 0 31 2 *")
+	require.NoError(t, err)
+	_, ok := s.Previous(time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC))
+	require.False(t, ok)
+}
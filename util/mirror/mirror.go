@@ -0,0 +1,160 @@
+// Package mirror maps the URLs that artefacts are downloaded from onto an artefact mirror, so that
+// Control Tower can be used where GitHub, bosh.io and the HashiCorp releases site are unreachable.
+// A mirror is a directory, or an HTTP server or bucket serving one, that keeps each artefact at the
+// host and path of its original URL
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/util/proxy"
+)
+
+// versionFileURLKeys are the keys of a versions file entry that hold download URLs
+var versionFileURLKeys = []string{"url", "mac", "linux"}
+
+var client = &http.Client{Transport: fileTransport()}
+
+func fileTransport() http.RoundTripper {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = proxy.Func
+	t.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	return t
+}
+
+// Get fetches url, which may also be a file:// URL into a local mirror
+func Get(url string) (*http.Response, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// Path returns where the artefact at rawURL is kept in a mirror
+func Path(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("artefact URL `%s` has no host", rawURL)
+	}
+	p := path.Join(u.Host, u.Path)
+	if u.RawQuery != "" {
+		p = path.Join(p, u.RawQuery)
+	}
+	return p, nil
+}
+
+// URL returns the URL of the artefact at rawURL in mirror, which is either an http(s) URL or a local
+// directory. Local directories are given as file:// URLs, which the bosh CLI uploads from directly.
+// rawURL is returned unchanged when no mirror is set
+func URL(mirror, rawURL string) (string, error) {
+	if mirror == "" || rawURL == "" {
+		return rawURL, nil
+	}
+	p, err := Path(rawURL)
+	if err != nil {
+		return "", err
+	}
+	base, err := baseURL(mirror)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(base, "/") + "/" + p, nil
+}
+
+// LocalPath returns the path of a file:// URL, for commands such as bosh upload-stemcell that take
+// either a URL or a path. Any other URL is returned unchanged
+func LocalPath(rawURL string) string {
+	if strings.HasPrefix(rawURL, "file://") {
+		return strings.TrimPrefix(rawURL, "file://")
+	}
+	return rawURL
+}
+
+// Validate returns an error if mirror is neither an http(s) URL nor a local directory
+func Validate(mirror string) error {
+	if mirror == "" {
+		return nil
+	}
+	_, err := baseURL(mirror)
+	return err
+}
+
+func baseURL(mirror string) (string, error) {
+	u, err := url.Parse(mirror)
+	if err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return mirror, nil
+	}
+	if err == nil && u.Scheme == "file" {
+		mirror = u.Path
+	} else if err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		return "", fmt.Errorf("artefact mirror `%s` must be an http or https URL or a local directory", mirror)
+	}
+	dir, err := filepath.Abs(mirror)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("artefact mirror `%s` is not a directory", mirror)
+	}
+	return "file://" + filepath.ToSlash(dir), nil
+}
+
+// RewriteVersionFile returns a copy of a createenv dependencies and CLI versions file with every
+// download URL moved to mirror
+func RewriteVersionFile(versionFile []byte, mirror string) ([]byte, error) {
+	if mirror == "" {
+		return versionFile, nil
+	}
+	var entries map[string]map[string]interface{}
+	if err := json.Unmarshal(versionFile, &entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		for _, key := range versionFileURLKeys {
+			rawURL, ok := entry[key].(string)
+			if !ok {
+				continue
+			}
+			mirrored, err := URL(mirror, rawURL)
+			if err != nil {
+				return nil, err
+			}
+			entry[key] = mirrored
+		}
+	}
+	return json.Marshal(entries)
+}
+
+// VersionFileURLs returns every download URL in a createenv dependencies and CLI versions file
+func VersionFileURLs(versionFile []byte) ([]string, error) {
+	var entries map[string]map[string]interface{}
+	if err := json.Unmarshal(versionFile, &entries); err != nil {
+		return nil, err
+	}
+	var urls []string
+	for _, entry := range entries {
+		for _, key := range versionFileURLKeys {
+			if rawURL, ok := entry[key].(string); ok && rawURL != "" {
+				urls = append(urls, rawURL)
+			}
+		}
+	}
+	sort.Strings(urls)
+	return urls, nil
+}
@@ -0,0 +1,94 @@
+package mirror_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/util/mirror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	p, err := mirror.Path("https://releases.hashicorp.com/terraform/0.11.14/terraform_0.11.14_linux_amd64.zip")
+	require.NoError(t, err)
+	require.Equal(t, "releases.hashicorp.com/terraform/0.11.14/terraform_0.11.14_linux_amd64.zip", p)
+
+	p, err = mirror.Path("https://bosh.io/d/github.com/cloudfoundry/bpm-release?v=1.1.6")
+	require.NoError(t, err)
+	require.Equal(t, "bosh.io/d/github.com/cloudfoundry/bpm-release/v=1.1.6", p)
+
+	_, err = mirror.Path("bpm-release.tgz")
+	require.EqualError(t, err, "artefact URL `bpm-release.tgz` has no host")
+}
+
+func TestURL(t *testing.T) {
+	u, err := mirror.URL("", "https://bosh.io/d/github.com/cloudfoundry/bpm-release?v=1.1.6")
+	require.NoError(t, err)
+	require.Equal(t, "https://bosh.io/d/github.com/cloudfoundry/bpm-release?v=1.1.6", u)
+
+	u, err = mirror.URL("https://artefacts.internal/control-tower/", "https://bosh.io/d/github.com/cloudfoundry/bpm-release?v=1.1.6")
+	require.NoError(t, err)
+	require.Equal(t, "https://artefacts.internal/control-tower/bosh.io/d/github.com/cloudfoundry/bpm-release/v=1.1.6", u)
+
+	dir, err := ioutil.TempDir("", "mirror")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	u, err = mirror.URL(dir, "https://example.com/fly")
+	require.NoError(t, err)
+	require.Equal(t, "file://"+filepath.ToSlash(dir)+"/example.com/fly", u)
+	require.Equal(t, filepath.ToSlash(dir)+"/example.com/fly", mirror.LocalPath(u))
+
+	_, err = mirror.URL(filepath.Join(dir, "missing"), "https://example.com/fly")
+	require.EqualError(t, err, "artefact mirror `"+filepath.Join(dir, "missing")+"` is not a directory")
+
+	_, err = mirror.URL("ftp://artefacts.internal", "https://example.com/fly")
+	require.EqualError(t, err, "artefact mirror `ftp://artefacts.internal` must be an http or https URL or a local directory")
+}
+
+func TestRewriteVersionFile(t *testing.T) {
+	versionFile := []byte(`{
+		"bosh": {"url": "https://bosh.io/d/github.com/cloudfoundry/bosh?v=271.2.0", "version": "271.2.0", "sha1": "abc"},
+		"fly": {"mac": "https://example.com/fly-darwin", "mac_sha256": "def", "linux": "https://example.com/fly-linux", "linux_sha256": "123"}
+	}`)
+
+	urls, err := mirror.VersionFileURLs(versionFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://bosh.io/d/github.com/cloudfoundry/bosh?v=271.2.0", "https://example.com/fly-darwin", "https://example.com/fly-linux"}, urls)
+
+	rewritten, err := mirror.RewriteVersionFile(versionFile, "https://artefacts.internal")
+	require.NoError(t, err)
+	var entries map[string]map[string]string
+	require.NoError(t, json.Unmarshal(rewritten, &entries))
+	require.Equal(t, map[string]map[string]string{
+		"bosh": {"url": "https://artefacts.internal/bosh.io/d/github.com/cloudfoundry/bosh/v=271.2.0", "version": "271.2.0", "sha1": "abc"},
+		"fly":  {"mac": "https://artefacts.internal/example.com/fly-darwin", "mac_sha256": "def", "linux": "https://artefacts.internal/example.com/fly-linux", "linux_sha256": "123"},
+	}, entries)
+
+	unchanged, err := mirror.RewriteVersionFile(versionFile, "")
+	require.NoError(t, err)
+	require.Equal(t, versionFile, unchanged)
+}
+
+func TestGetFromLocalMirror(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "example.com"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "example.com", "fly"), []byte("fly"), 0600))
+
+	u, err := mirror.URL(dir, "https://example.com/fly")
+	require.NoError(t, err)
+	resp, err := mirror.Get(u)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "fly", string(contents))
+
+	missing, _ := mirror.URL(dir, "https://example.com/bosh")
+	_, err = mirror.Get(missing)
+	require.EqualError(t, err, "failed to download "+missing+": 404 Not Found")
+}
@@ -0,0 +1,37 @@
+// Package proxy chooses the proxy for Control Tower's own HTTP requests
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+var (
+	mutex     sync.RWMutex
+	proxyFunc = httpproxy.FromEnvironment().ProxyFunc()
+)
+
+// Reload reads the proxy settings from the environment again. http.ProxyFromEnvironment only reads them
+// once per process, so the saved proxy, which is only known once the config bucket has been read, would
+// otherwise never reach clients that have already made a request
+func Reload() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	proxyFunc = httpproxy.FromEnvironment().ProxyFunc()
+}
+
+// Func returns the proxy for req from the settings last read by Reload, for use as an http.Transport's Proxy
+func Func(req *http.Request) (*url.URL, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return proxyFunc(req.URL)
+}
+
+// UseInDefaultTransport makes http.DefaultTransport, and the clients built on it such as the AWS and GCP
+// SDKs, use Func
+func UseInDefaultTransport() {
+	http.DefaultTransport.(*http.Transport).Proxy = Func
+}
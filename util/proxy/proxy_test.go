@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Unsetenv(name)
+	}
+	Reload()
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos", nil)
+	if proxyURL, err := Func(req); err != nil || proxyURL != nil {
+		t.Fatalf("Func() = %v, %v before a proxy is set", proxyURL, err)
+	}
+
+	os.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	os.Setenv("NO_PROXY", ".internal.example.com")
+	Reload()
+
+	proxyURL, err := Func(req)
+	if err != nil || proxyURL == nil || proxyURL.String() != "http://proxy.example.com:3128" {
+		t.Errorf("Func() = %v, %v, want the proxy set after the first request", proxyURL, err)
+	}
+	internal, _ := http.NewRequest(http.MethodGet, "https://ci.internal.example.com", nil)
+	if proxyURL, err = Func(internal); err != nil || proxyURL != nil {
+		t.Errorf("Func() = %v, %v, want no proxy for a NO_PROXY domain", proxyURL, err)
+	}
+}
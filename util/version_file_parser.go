@@ -2,6 +2,7 @@ package util
 
 import (
 	"encoding/json"
+	"fmt"
 	"runtime"
 
 	"github.com/EngineerBetter/control-tower/util/bincache"
@@ -14,9 +15,12 @@ type Resource struct {
 	SHA1    string `json:"sha1"`
 }
 
+// BinaryPaths are the download URLs of a binary, with the SHA256 each download must match
 type BinaryPaths struct {
-	Mac   string `json:"mac"`
-	Linux string `json:"linux"`
+	Mac         string `json:"mac"`
+	MacSHA256   string `json:"mac_sha256"`
+	Linux       string `json:"linux"`
+	LinuxSHA256 string `json:"linux_sha256"`
 }
 
 func ParseVersionResources(versionFile []byte) map[string]Resource {
@@ -38,25 +42,43 @@ func GetResource(name string, resources map[string]Resource) Resource {
 	return r
 }
 
-func (p BinaryPaths) path() string {
+func (p BinaryPaths) path() (string, string) {
 	switch runtime.GOOS {
 	case "darwin":
-		return p.Mac
+		return p.Mac, p.MacSHA256
 	case "linux":
-		return p.Linux
+		return p.Linux, p.LinuxSHA256
 	default:
 		panic("OS not supported")
 	}
 }
 
+// AllowUnverifiedBinaries lets binaries with no SHA256 pinned in the versions file be downloaded unverified
+var AllowUnverifiedBinaries bool
+
+func download(binaries map[string]BinaryPaths, name string) (string, error) {
+	binary, ok := binaries[name]
+	if !ok {
+		return "", fmt.Errorf("no %s binary is listed in the versions file", name)
+	}
+	url, sha256 := binary.path()
+	if sha256 == "" && !AllowUnverifiedBinaries {
+		return "", fmt.Errorf("no SHA256 is pinned for the %s binary at %s, so its download cannot be verified. Pass --allow-unverified-binaries to download it anyway", name, url)
+	}
+	return bincache.Download(url, sha256)
+}
+
 // DownloadBOSHCLI returns the path of the downloaded bosh-cli
 func DownloadBOSHCLI(binaries map[string]BinaryPaths) (string, error) {
-	p := binaries["bosh-cli"].path()
-	return bincache.Download(p)
+	return download(binaries, "bosh-cli")
 }
 
 // DownloadTerraformCLI returns the path of the downloaded terraform-cli
 func DownloadTerraformCLI(binaries map[string]BinaryPaths) (string, error) {
-	p := binaries["terraform"].path()
-	return bincache.Download(p)
+	return download(binaries, "terraform")
+}
+
+// DownloadFly returns the path of the downloaded fly
+func DownloadFly(binaries map[string]BinaryPaths) (string, error) {
+	return download(binaries, "fly")
 }
@@ -17,6 +17,42 @@ func newOpsFromString(ops string) (patch.Op, error) {
 	return patch.NewOpsFromDefinitions(opDefs)
 }
 
+// JoinOps returns the operations of every ops file in files as a single ops file. Each file is parsed on its
+// own, so files may start with a document separator or lack a trailing newline
+func JoinOps(files ...string) (string, error) {
+	var ops []interface{}
+	for _, file := range files {
+		var fileOps []interface{}
+		if err := yamlenc.Unmarshal([]byte(file), &fileOps); err != nil {
+			return "", err
+		}
+		ops = append(ops, fileOps...)
+	}
+	if len(ops) == 0 {
+		return "", nil
+	}
+
+	contents, err := yamlenc.Marshal(ops)
+	return string(contents), err
+}
+
+// ValidateOps returns an error if ops is not a list of go-patch operations that apply to manifest.
+// Variables are left in place, so ops may use them
+func ValidateOps(ops, manifest string) error {
+	op, err := newOpsFromString(ops)
+	if err != nil {
+		return err
+	}
+	_, err = template.NewTemplate([]byte(manifest)).Evaluate(template.StaticVariables{}, op, template.EvaluateOpts{})
+	return err
+}
+
+// ValidateVars returns an error if vars is not a YAML map of variable names to values
+func ValidateVars(vars string) error {
+	var m map[string]interface{}
+	return yamlenc.Unmarshal([]byte(vars), &m)
+}
+
 // Interpolate returns an interpolated string using vars
 func Interpolate(s string, ops string, vars map[string]interface{}) (string, error) {
 	t := template.NewTemplate([]byte(s))
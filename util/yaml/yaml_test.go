@@ -148,3 +148,40 @@ a:
 		})
 	}
 }
+
+func TestJoinOps(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "no ops",
+			files: []string{"", ""},
+			want:  "",
+		},
+		{
+			name:  "files with document separators and without trailing newlines",
+			files: []string{"---\n- type: remove\n  path: /a", "", "\n- type: replace\n  path: /b\n  value: ((b))"},
+			want:  "- path: /a\n  type: remove\n- path: /b\n  type: replace\n  value: ((b))\n",
+		},
+		{
+			name:    "file that is not a list",
+			files:   []string{"type: remove"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yaml.JoinOps(tt.files...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JoinOps() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JoinOps() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}